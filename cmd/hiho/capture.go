@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"hiho/internal/ansi"
+	"hiho/internal/tmux"
+)
+
+const captureUsage = "usage: hiho capture [--lines N] [--strip-ansi] <session>"
+
+// capturer is the part of tmux.Manager the capture subcommand needs.
+type capturer interface {
+	CaptureLines(name string, lines int) (string, error)
+}
+
+// subcommandManager is the part of tmux.Manager the subcommands need.
+type subcommandManager interface {
+	capturer
+	lister
+}
+
+// runSubcommand runs a non-interactive subcommand named by args[0] and
+// returns its exit code. ok is false when args do not name a subcommand, in
+// which case the TUI should start.
+func runSubcommand(args []string, manager subcommandManager, stdout, stderr io.Writer) (code int, ok bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	switch args[0] {
+	case "capture":
+		return runCapture(args[1:], manager, stdout, stderr), true
+	case "list":
+		return runList(args[1:], manager, stdout, stderr), true
+	}
+	return 0, false
+}
+
+// runCapture prints a session's current output. It exits 1 when the
+// session cannot be captured and 2 on bad arguments.
+func runCapture(args []string, c capturer, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("capture", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	lines := fs.Int("lines", tmux.DefaultScrollbackLines, "lines of scrollback to include")
+	stripANSI := fs.Bool("strip-ansi", false, "remove terminal escape sequences")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || *lines < 0 {
+		fmt.Fprintln(stderr, captureUsage)
+		return 2
+	}
+
+	name := fs.Arg(0)
+	output, err := c.CaptureLines(name, *lines)
+	if errors.Is(err, tmux.ErrSessionNotFound) {
+		fmt.Fprintf(stderr, "hiho: session %s not found\n", name)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "hiho: %v\n", err)
+		return 1
+	}
+	if *stripANSI {
+		output = ansi.Strip(output)
+	}
+	fmt.Fprint(stdout, output)
+	return 0
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+type stubCapturer struct {
+	output   map[string]string
+	lines    int
+	sessions []tmux.Session
+	listErr  error
+}
+
+func (s *stubCapturer) ListHiho() ([]tmux.Session, error) {
+	return s.sessions, s.listErr
+}
+
+func (s *stubCapturer) CaptureLines(name string, lines int) (string, error) {
+	s.lines = lines
+	out, ok := s.output[name]
+	if !ok {
+		return "", &tmux.SessionError{Name: name, Op: tmux.OpCapture, Err: tmux.ErrSessionNotFound}
+	}
+	return out, nil
+}
+
+func TestRunSubcommandDispatch(t *testing.T) {
+	tests := []struct {
+		args   []string
+		ok     bool
+		code   int
+		stdout string
+		lines  int
+	}{
+		{args: nil, ok: false},
+		{args: []string{"--profile", "work"}, ok: false},
+		{args: []string{"capture", "hiho-1-0"}, ok: true, code: 0, stdout: "\x1b[1mhi\x1b[0m\n", lines: 200},
+		{args: []string{"capture", "--lines", "50", "--strip-ansi", "hiho-1-0"}, ok: true, code: 0, stdout: "hi\n", lines: 50},
+		{args: []string{"capture", "missing"}, ok: true, code: 1},
+		{args: []string{"capture"}, ok: true, code: 2},
+		{args: []string{"capture", "--lines", "x", "hiho-1-0"}, ok: true, code: 2},
+	}
+	for _, tt := range tests {
+		c := &stubCapturer{output: map[string]string{"hiho-1-0": "\x1b[1mhi\x1b[0m\n"}}
+		var stdout, stderr bytes.Buffer
+		code, ok := runSubcommand(tt.args, c, &stdout, &stderr)
+		if ok != tt.ok || code != tt.code {
+			t.Errorf("%v: got code %d ok %v, want %d %v (stderr %q)", tt.args, code, ok, tt.code, tt.ok, stderr.String())
+			continue
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%v: stdout %q, want %q", tt.args, stdout.String(), tt.stdout)
+		}
+		if tt.lines != 0 && c.lines != tt.lines {
+			t.Errorf("%v: captured %d lines, want %d", tt.args, c.lines, tt.lines)
+		}
+	}
+}
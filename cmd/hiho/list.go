@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"hiho/internal/tmux"
+)
+
+const listUsage = "usage: hiho list [--json]"
+
+// lister is the part of tmux.Manager the list subcommand needs.
+type lister interface {
+	ListHiho() ([]tmux.Session, error)
+}
+
+// listedSession is one session in hiho list --json output. Command is what
+// the session was started with; CurrentCommand is whatever runs in the
+// foreground now, often just the shell.
+type listedSession struct {
+	Name           string    `json:"name"`
+	Command        string    `json:"command"`
+	CurrentCommand string    `json:"current_command"`
+	Created        time.Time `json:"created"`
+	Running        bool      `json:"running"`
+}
+
+// runList prints the hiho sessions, one name per line or as a JSON array.
+// It exits 1 when tmux cannot list them and 2 on bad arguments.
+func runList(args []string, l lister, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print name, command, current_command, created and running for each session as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(stderr, listUsage)
+		return 2
+	}
+
+	sessions, err := l.ListHiho()
+	if err != nil {
+		fmt.Fprintf(stderr, "hiho: %v\n", err)
+		return 1
+	}
+	if !*asJSON {
+		for _, session := range sessions {
+			fmt.Fprintln(stdout, session.Name)
+		}
+		return 0
+	}
+	// An empty list prints [] rather than null
+	listed := make([]listedSession, 0, len(sessions))
+	for _, session := range sessions {
+		listed = append(listed, listedSession{
+			Name:           session.Name,
+			Command:        session.StartCommand,
+			CurrentCommand: session.Command,
+			Created:        session.CreatedAt,
+			Running:        session.Running,
+		})
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(listed); err != nil {
+		fmt.Fprintf(stderr, "hiho: %v\n", err)
+		return 1
+	}
+	return 0
+}
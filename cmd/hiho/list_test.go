@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"hiho/internal/tmux"
+)
+
+func TestRunList(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sessions := []tmux.Session{
+		{Name: "hiho-1-1", Command: "node", StartCommand: "npm run dev", Running: true, CreatedAt: created},
+		{Name: "hiho-1-0", Command: "bash", CreatedAt: created.Add(-time.Minute)},
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		sessions []tmux.Session
+		listErr  error
+		code     int
+		stdout   string
+	}{
+		{name: "names", args: []string{"list"}, sessions: sessions, stdout: "hiho-1-1\nhiho-1-0\n"},
+		{name: "json", args: []string{"list", "--json"}, sessions: sessions, stdout: `[
+  {
+    "name": "hiho-1-1",
+    "command": "npm run dev",
+    "current_command": "node",
+    "created": "2026-01-02T03:04:05Z",
+    "running": true
+  },
+  {
+    "name": "hiho-1-0",
+    "command": "",
+    "current_command": "bash",
+    "created": "2026-01-02T03:03:05Z",
+    "running": false
+  }
+]
+`},
+		{name: "empty json", args: []string{"list", "--json"}, stdout: "[]\n"},
+		{name: "tmux error", args: []string{"list", "--json"}, listErr: errors.New("tmux failed"), code: 1},
+		{name: "extra argument", args: []string{"list", "all"}, code: 2},
+		{name: "unknown flag", args: []string{"list", "--yaml"}, code: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &stubCapturer{sessions: tt.sessions, listErr: tt.listErr}
+			var stdout, stderr bytes.Buffer
+			code, ok := runSubcommand(tt.args, c, &stdout, &stderr)
+			if !ok || code != tt.code {
+				t.Fatalf("got code %d ok %v, want %d true (stderr %q)", code, ok, tt.code, stderr.String())
+			}
+			if stdout.String() != tt.stdout {
+				t.Fatalf("stdout %q, want %q", stdout.String(), tt.stdout)
+			}
+		})
+	}
+}
@@ -1,24 +1,86 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"hiho/internal/config"
+	"hiho/internal/events"
+	"hiho/internal/history"
 	"hiho/internal/tmux"
 	"hiho/internal/ui"
 )
 
 func main() {
-	// Load configuration
-	cfg := config.LoadConfig()
+	if code, ok := runSubcommand(os.Args[1:], tmux.NewManager(), os.Stdout, os.Stderr); ok {
+		os.Exit(code)
+	}
+
+	configFile := flag.String("config", "", "path to a config file (default ~/.config/hiho/config.yaml)")
+	profile := flag.String("profile", "", "load ~/.config/hiho/<name>.yaml, falling back to config.yaml")
+	eventsPath := flag.String("events", "", "write JSON session events to this unix socket or file")
+	flag.Parse()
+
+	// Load configuration, remembering which file the layout is saved to
+	cfg := config.DefaultConfig()
+	configPath := config.Path()
+	var warnings []string
+	var err error
+	switch {
+	case *configFile != "" && *profile != "":
+		log.Fatalf("--config and --profile cannot be used together")
+	case *configFile != "":
+		if cfg, err = config.LoadConfigFrom(*configFile); err != nil {
+			log.Fatalf("failed to load config: %v", err)
+		}
+		configPath = *configFile
+	case *profile != "":
+		if cfg, err = config.LoadProfile(*profile); err != nil {
+			log.Fatalf("failed to load profile: %v", err)
+		}
+		// A profile that falls back to config.yaml keeps saving there
+		configPath = config.ProfileFile(*profile)
+	default:
+		// A broken config.yaml is reported in the UI rather than fatal
+		if cfg, err = config.LoadConfigWithError(); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	warnings = append(warnings, config.Validate(cfg)...)
 
 	// Create tmux manager
-	manager := tmux.NewManager()
+	manager := tmux.NewManager(
+		tmux.WithNameTemplate(cfg.SessionNameTemplate),
+		tmux.WithScrollbackLines(cfg.ScrollbackLines),
+	)
+
+	opts := []ui.Option{ui.WithProfile(*profile), ui.WithConfigPath(configPath)}
+	if *eventsPath != "" {
+		sink, err := events.Open(*eventsPath)
+		if err != nil {
+			log.Fatalf("failed to open event stream: %v", err)
+		}
+		defer sink.Close()
+		opts = append(opts, ui.WithEvents(sink))
+	}
+
+	if path := config.HistoryPath(); cfg.PersistHistory && path != "" {
+		// Persistence is optional; run without it rather than not at all
+		if store, err := history.Open(path); err != nil {
+			log.Printf("conversation history disabled: %v", err)
+		} else {
+			defer store.Close()
+			opts = append(opts, ui.WithHistory(store))
+		}
+	}
+
+	opts = append(opts, ui.WithWarnings(warnings...))
 
 	// Create UI model with config
-	model := ui.NewModel(manager, cfg)
+	model := ui.NewModel(manager, cfg, opts...)
 
 	// Create program with alt screen and mouse support
 	p := tea.NewProgram(
@@ -27,7 +89,11 @@ func main() {
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		log.Fatalf("failed to start TUI: %v", err)
 	}
+	if err := teardown(final, manager); err != nil {
+		log.Printf("kill_on_quit: %v", err)
+	}
 }
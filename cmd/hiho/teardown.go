@@ -0,0 +1,19 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// killer is the part of tmux.Manager the teardown needs.
+type killer interface {
+	KillAllHiho() error
+}
+
+// teardown runs after the TUI has exited, given its final model: with
+// kill_on_quit on, it kills the hiho sessions that would otherwise keep
+// running detached.
+func teardown(final tea.Model, k killer) error {
+	model, ok := final.(interface{ KillOnQuit() bool })
+	if !ok || !model.KillOnQuit() {
+		return nil
+	}
+	return k.KillAllHiho()
+}
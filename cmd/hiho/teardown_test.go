@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"hiho/internal/config"
+	"hiho/internal/ui"
+)
+
+type stubKiller struct {
+	calls int
+	err   error
+}
+
+func (s *stubKiller) KillAllHiho() error {
+	s.calls++
+	return s.err
+}
+
+func TestTeardownKillsSessionsOnlyWhenEnabled(t *testing.T) {
+	failed := errors.New("no server running")
+	tests := []struct {
+		name       string
+		killOnQuit bool
+		killErr    error
+		calls      int
+		wantErr    error
+	}{
+		{name: "off by default", calls: 0},
+		{name: "on", killOnQuit: true, calls: 1},
+		{name: "kill fails", killOnQuit: true, killErr: failed, calls: 1, wantErr: failed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.KillOnQuit = tt.killOnQuit
+			k := &stubKiller{err: tt.killErr}
+			err := teardown(ui.NewModel(nil, cfg), k)
+			if k.calls != tt.calls {
+				t.Fatalf("KillAllHiho called %d times, want %d", k.calls, tt.calls)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("teardown error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Package ansi handles ANSI escape sequences in terminal output.
+package ansi
+
+import "regexp"
+
+// sequence matches CSI sequences (colors, cursor movement), OSC sequences
+// terminated by BEL or ST, and two-byte escapes.
+var sequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// Strip removes ANSI escape sequences from s.
+func Strip(s string) string {
+	return sequence.ReplaceAllString(s, "")
+}
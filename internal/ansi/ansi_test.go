@@ -0,0 +1,25 @@
+package ansi
+
+import "testing"
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "hello", "hello"},
+		{"sgr colors", "\x1b[1;38;5;62mbold\x1b[0m text", "bold text"},
+		{"cursor movement", "\x1b[H\x1b[2Jscreen", "screen"},
+		{"osc title", "\x1b]0;title\x07prompt", "prompt"},
+		{"osc with st", "\x1b]8;;http://x\x1b\\link", "link"},
+		{"keeps newlines", "a\x1b[31m\nb", "a\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Strip(tt.input); got != tt.want {
+				t.Fatalf("Strip(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
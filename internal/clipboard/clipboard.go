@@ -0,0 +1,88 @@
+// Package clipboard copies text to the system clipboard by shelling out to
+// the platform's clipboard utility, falling back to a temp file on headless
+// machines.
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// tool is a clipboard command that reads the text to copy from stdin.
+type tool struct {
+	name string
+	args []string
+}
+
+// tools lists supported utilities in order of preference.
+var tools = []tool{
+	{name: "pbcopy"},
+	{name: "wl-copy"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}},
+	{name: "xsel", args: []string{"--clipboard", "--input"}},
+}
+
+// Result describes where copied text ended up.
+type Result struct {
+	// Tool is the clipboard utility used, empty when falling back to a file.
+	Tool string
+	// Path is the temp file holding the text when no utility is available.
+	Path string
+}
+
+// System copies text using the first clipboard utility found on PATH.
+// Availability is detected once, on first use.
+type System struct {
+	once     sync.Once
+	tool     tool
+	found    bool
+	lookPath func(string) (string, error)
+	tempDir  string // empty means os.TempDir()
+}
+
+// NewSystem constructs a System that searches PATH for a clipboard utility.
+func NewSystem() *System {
+	return &System{lookPath: exec.LookPath}
+}
+
+// Copy places text on the system clipboard. Without a clipboard utility the
+// text is written to a temp file whose path is reported in the Result.
+func (s *System) Copy(text string) (Result, error) {
+	s.once.Do(func() {
+		s.tool, s.found = findTool(s.lookPath)
+	})
+	if !s.found {
+		return s.copyToFile(text)
+	}
+
+	cmd := exec.Command(s.tool.name, s.tool.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return Result{}, fmt.Errorf("%s: %w (%s)", s.tool.name, err, strings.TrimSpace(string(out)))
+	}
+	return Result{Tool: s.tool.name}, nil
+}
+
+func (s *System) copyToFile(text string) (Result, error) {
+	file, err := os.CreateTemp(s.tempDir, "hiho-clipboard-*.txt")
+	if err != nil {
+		return Result{}, fmt.Errorf("clipboard fallback: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(text); err != nil {
+		return Result{}, fmt.Errorf("clipboard fallback: %w", err)
+	}
+	return Result{Path: file.Name()}, nil
+}
+
+func findTool(lookPath func(string) (string, error)) (tool, bool) {
+	for _, t := range tools {
+		if _, err := lookPath(t.name); err == nil {
+			return t, true
+		}
+	}
+	return tool{}, false
+}
@@ -0,0 +1,74 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func lookPathFor(available ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, a := range available {
+			if a == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestFindToolPrefersFirstAvailable(t *testing.T) {
+	tests := []struct {
+		available []string
+		want      string
+		wantOK    bool
+	}{
+		{[]string{"xclip", "pbcopy"}, "pbcopy", true},
+		{[]string{"xsel", "wl-copy"}, "wl-copy", true},
+		{[]string{"xsel"}, "xsel", true},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := findTool(lookPathFor(tt.available...))
+		if ok != tt.wantOK || got.name != tt.want {
+			t.Errorf("findTool(%v) = %q, %v; want %q, %v", tt.available, got.name, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCopyFallsBackToTempFileWithoutTool(t *testing.T) {
+	dir := t.TempDir()
+	lookups := 0
+	s := &System{
+		lookPath: func(name string) (string, error) {
+			lookups++
+			return lookPathFor()(name)
+		},
+		tempDir: dir,
+	}
+
+	result, err := s.Copy("copied text")
+	if err != nil {
+		t.Fatalf("Copy error: %v", err)
+	}
+	if result.Tool != "" || filepath.Dir(result.Path) != dir {
+		t.Fatalf("expected fallback file in %s, got %+v", dir, result)
+	}
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("read fallback: %v", err)
+	}
+	if string(data) != "copied text" {
+		t.Fatalf("fallback content = %q", data)
+	}
+
+	// Detection runs once
+	first := lookups
+	if _, err := s.Copy("again"); err != nil {
+		t.Fatalf("Copy error: %v", err)
+	}
+	if lookups != first {
+		t.Fatalf("expected tool detection to run once, got %d lookups", lookups)
+	}
+}
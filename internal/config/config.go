@@ -1,8 +1,16 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,99 +18,256 @@ import (
 // Config holds all configuration options.
 type Config struct {
 	KeyBindings KeyBindings `yaml:"keybindings"`
+	// TabSwitchCommands lists the commands that jump to the Tmux tab.
+	// Entries are slash-command names without the slash, plus "activate"
+	// for selecting a session from the sidebar.
+	TabSwitchCommands []string `yaml:"tab_switch_commands"`
+	// ConfirmCommands holds glob patterns (e.g. "rm *"); a /new command
+	// matching any of them asks for confirmation before it runs.
+	ConfirmCommands []string `yaml:"confirm_commands"`
+	// AlwaysConfirm adds patterns that always ask first; NeverConfirm lists
+	// trusted patterns that never ask, even if they also match
+	// ConfirmCommands or AlwaysConfirm.
+	AlwaysConfirm []string `yaml:"always_confirm"`
+	NeverConfirm  []string `yaml:"never_confirm"`
+	// Conversation controls message layout in the conversation view.
+	Conversation Conversation `yaml:"conversation"`
+	// CommandBindings maps a key to a slash command it runs, e.g.
+	// {"ctrl+n": "/new bash"}. Built-in keybindings take precedence.
+	CommandBindings map[string]string `yaml:"command_bindings"`
+	// IdleTimeout quits hiho after this long without input (e.g. "30m").
+	// tmux sessions keep running. Zero disables it.
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+	// RefreshInterval is how often the Tmux tab re-captures the current
+	// session (e.g. "2s"). Zero keeps the 2 second default.
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	// ScrollbackLines is how many lines of scrollback each capture
+	// includes. Zero or negative values fall back to 200.
+	ScrollbackLines int `yaml:"scrollback_lines"`
+	// PersistHistory saves the conversation to history.jsonl next to the
+	// config file and reloads it on the next start.
+	PersistHistory bool `yaml:"persist_history"`
+	// FollowOutput keeps the main panel at the newest output as captures
+	// come in, unless it has been scrolled up. Turn it off to keep the
+	// scroll position even at the bottom.
+	FollowOutput bool `yaml:"follow_output"`
+	// WrapLines wraps lines wider than the main panel onto the next row
+	// instead of cutting them off.
+	WrapLines bool `yaml:"wrap_lines"`
+	// ConfirmCloseAll asks "Close N sessions? (y/n)" before /closeall
+	// runs. Turn it off to close immediately.
+	ConfirmCloseAll bool `yaml:"confirm_closeall"`
+	// KillOnQuit kills every hiho session when hiho exits. Off by default
+	// so sessions keep running detached, to be picked up next time.
+	KillOnQuit bool `yaml:"kill_on_quit"`
+	// SidebarPreview shows a session as soon as it is selected in the
+	// sidebar instead of waiting for Enter.
+	SidebarPreview bool `yaml:"sidebar_preview"`
+	// SessionOrder lists sessions in the sidebar "newest" (the default) or
+	// "oldest" first, by creation time.
+	SessionOrder string `yaml:"session_order"`
+	// SessionNameTemplate names new tmux sessions. Placeholders: {pid},
+	// {seq}, {date}, {time}, {cmd}, {rand}. Empty keeps hiho-{pid}-{seq}.
+	SessionNameTemplate string `yaml:"session_name_template"`
+	// NavWrap makes session navigation (arrows and /next, /prev) wrap
+	// around at the ends of the list instead of stopping.
+	NavWrap bool `yaml:"nav_wrap"`
+	// TailLines starts the Tmux tab showing only this many of the last
+	// non-empty lines (see /tailn). Zero shows full captures.
+	TailLines int `yaml:"tail_lines"`
+	// Workspaces maps a name to commands /open starts together, e.g.
+	// {"web": ["npm run dev", "go run ./api"]}.
+	Workspaces map[string][]string `yaml:"workspaces"`
+	// Theme sets UI colors.
+	Theme Theme `yaml:"theme"`
+	// Prompt sets the input prompt symbol for each input state.
+	Prompt Prompt `yaml:"prompt"`
+	// Layout sets panel proportions; the resize keys update it.
+	Layout Layout `yaml:"layout"`
 }
 
-// KeyBindings defines keyboard shortcuts for the application.
-type KeyBindings struct {
-	Quit         string `yaml:"quit"`
-	CycleWindows string `yaml:"cycle_windows"`
-	NextSession  string `yaml:"next_session"`
-	PrevSession  string `yaml:"prev_session"`
-	ToggleTab    string `yaml:"toggle_tab"`
-	SessionUp    string `yaml:"session_up"`
-	SessionDown  string `yaml:"session_down"`
-	FocusSidebar string `yaml:"focus_sidebar"`
-	FocusMain    string `yaml:"focus_main"`
+// Theme holds colors (ANSI 256 numbers or #rrggbb) used by the UI.
+type Theme struct {
+	// LogHeader colors the session name/command/time line above a capture.
+	LogHeader string `yaml:"log_header"`
+	// LogFooter colors the status line below a capture.
+	LogFooter string `yaml:"log_footer"`
+	// SelectedFocused, SelectedUnfocused and Current style sidebar rows:
+	// space-separated attributes from bold, faint, reverse, fg:<color>,
+	// bg:<color>, or "none".
+	SelectedFocused   string `yaml:"selected_focused"`
+	SelectedUnfocused string `yaml:"selected_unfocused"`
+	Current           string `yaml:"current"`
+	// FocusBorder colors the border of the panel that has focus; Border
+	// colors the others.
+	FocusBorder string `yaml:"focus_border"`
+	Border      string `yaml:"border"`
+	// ActiveTabBg and ActiveTabFg color the selected tab, InactiveTab the
+	// others; CurrentSession colors the session name after the tabs.
+	ActiveTabBg    string `yaml:"active_tab_bg"`
+	ActiveTabFg    string `yaml:"active_tab_fg"`
+	InactiveTab    string `yaml:"inactive_tab"`
+	CurrentSession string `yaml:"current_session"`
+	// HelpText colors the key hints below the input line.
+	HelpText string `yaml:"help_text"`
 }
 
-// DefaultConfig returns a Config with default keybindings.
+// Prompt holds the symbols shown before the input line: Normal for notes,
+// Command once the input starts with "/", Confirm while a yes/no question
+// is pending.
+type Prompt struct {
+	Normal  string `yaml:"normal"`
+	Command string `yaml:"command"`
+	Confirm string `yaml:"confirm"`
+}
+
+// Conversation controls how the conversation view lays out messages.
+type Conversation struct {
+	// MessageSpacing is the number of blank lines between messages.
+	MessageSpacing int `yaml:"message_spacing"`
+	// RoleIndent indents messages by role, e.g. {"info": 2}.
+	RoleIndent map[string]int `yaml:"role_indent"`
+}
+
+// DefaultConfig returns a Config with default settings and keybindings.
 func DefaultConfig() Config {
 	return Config{
-		KeyBindings: KeyBindings{
-			Quit:         "ctrl+c",
-			CycleWindows: "ctrl+o",
-			NextSession:  "alt+right",
-			PrevSession:  "alt+left",
-			ToggleTab:    "tab",
-			SessionUp:    "up",
-			SessionDown:  "down",
-			FocusSidebar: "ctrl+1",
-			FocusMain:    "ctrl+2",
+		KeyBindings:       DefaultKeyBindings(),
+		TabSwitchCommands: []string{"new", "activate"},
+		NavWrap:           true,
+		RefreshInterval:   2 * time.Second,
+		ScrollbackLines:   200,
+		PersistHistory:    true,
+		ConfirmCloseAll:   true,
+		WrapLines:         true,
+		FollowOutput:      true,
+		SessionOrder:      "newest",
+		Conversation: Conversation{
+			MessageSpacing: 1,
+		},
+		Theme: Theme{
+			LogHeader: "75",
+			LogFooter: "244",
+
+			SelectedFocused:   "reverse",
+			SelectedUnfocused: "none",
+			Current:           "bold",
+			FocusBorder:       "51",
+			Border:            "240",
+
+			ActiveTabBg:    "62",
+			ActiveTabFg:    "230",
+			InactiveTab:    "250",
+			CurrentSession: "244",
+			HelpText:       "240",
+		},
+		Prompt: Prompt{
+			Normal:  ">",
+			Command: "/",
+			Confirm: "?",
 		},
+		Layout: DefaultLayout(),
 	}
 }
 
-// configPath returns the path to the config file.
-func configPath() string {
+// configDir returns the directory holding config.yaml and profiles.
+func configDir() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ""
 	}
-	return filepath.Join(home, ".config", "hiho", "config.yaml")
+	return filepath.Join(home, ".config", "hiho")
+}
+
+// configPath returns the path to the config file.
+func configPath() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// HistoryPath returns where the conversation is saved when PersistHistory
+// is on, or "" without a home directory.
+func HistoryPath() string {
+	dir := configDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "history.jsonl")
 }
 
 // LoadConfig loads configuration from the config file.
 // If the file doesn't exist, it returns the default config.
 func LoadConfig() Config {
-	cfg := DefaultConfig()
+	cfg, _ := LoadConfigWithError()
+	return cfg
+}
 
+// LoadConfigWithError loads the config file like LoadConfig but also
+// reports what went wrong with it. A missing file is not an error. A file
+// that is not valid YAML yields the defaults; unknown options and values
+// of the wrong type are reported while the rest of the file still applies.
+func LoadConfigWithError() (Config, error) {
 	path := configPath()
 	if path == "" {
-		return cfg
+		return DefaultConfig(), nil
 	}
+	cfg, err := loadFile(path, true)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultConfig(), nil
+	}
+	return cfg, err
+}
 
+// LoadConfigFrom loads configuration from an explicit path. Unlike
+// LoadConfig, a missing or malformed file is reported as an error.
+func LoadConfigFrom(path string) (Config, error) {
+	return loadFile(path, false)
+}
+
+// loadFile parses the config at path on top of the defaults. When strict,
+// unknown options are reported too.
+func loadFile(path string, strict bool) (Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		// File doesn't exist or can't be read, use defaults
-		return cfg
+		return DefaultConfig(), fmt.Errorf("read config: %w", err)
 	}
 
-	// Parse YAML and merge with defaults
-	var fileCfg Config
-	if err := yaml.Unmarshal(data, &fileCfg); err != nil {
-		return cfg
+	// Parse YAML on top of the defaults so unset options keep their values
+	cfg := DefaultConfig()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(strict)
+	err = decoder.Decode(&cfg)
+	var typeErr *yaml.TypeError
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		err = nil
+	case errors.As(err, &typeErr):
+		// Decoding went on past the bad fields; keep what did apply
+		err = fmt.Errorf("config %s: %s", path, strings.Join(typeErr.Errors, "; "))
+	default:
+		return DefaultConfig(), fmt.Errorf("parse config %s: %w", path, err)
 	}
+	defaults := DefaultConfig()
+	fillEmpty(&cfg.KeyBindings, defaults.KeyBindings)
+	fillEmpty(&cfg.Theme, defaults.Theme)
 
-	// Merge: only override non-empty values
-	if fileCfg.KeyBindings.Quit != "" {
-		cfg.KeyBindings.Quit = fileCfg.KeyBindings.Quit
-	}
-	if fileCfg.KeyBindings.CycleWindows != "" {
-		cfg.KeyBindings.CycleWindows = fileCfg.KeyBindings.CycleWindows
-	}
-	if fileCfg.KeyBindings.NextSession != "" {
-		cfg.KeyBindings.NextSession = fileCfg.KeyBindings.NextSession
-	}
-	if fileCfg.KeyBindings.PrevSession != "" {
-		cfg.KeyBindings.PrevSession = fileCfg.KeyBindings.PrevSession
-	}
-	if fileCfg.KeyBindings.ToggleTab != "" {
-		cfg.KeyBindings.ToggleTab = fileCfg.KeyBindings.ToggleTab
-	}
-	if fileCfg.KeyBindings.SessionUp != "" {
-		cfg.KeyBindings.SessionUp = fileCfg.KeyBindings.SessionUp
-	}
-	if fileCfg.KeyBindings.SessionDown != "" {
-		cfg.KeyBindings.SessionDown = fileCfg.KeyBindings.SessionDown
-	}
-	if fileCfg.KeyBindings.FocusSidebar != "" {
-		cfg.KeyBindings.FocusSidebar = fileCfg.KeyBindings.FocusSidebar
-	}
-	if fileCfg.KeyBindings.FocusMain != "" {
-		cfg.KeyBindings.FocusMain = fileCfg.KeyBindings.FocusMain
-	}
+	return cfg, err
+}
 
-	return cfg
+// fillEmpty restores defaults for string fields (keybindings, theme
+// colors) left empty in the file. dst must point to a struct of the same
+// type as defaults.
+func fillEmpty(dst, defaults any) {
+	v := reflect.ValueOf(dst).Elem()
+	d := reflect.ValueOf(defaults)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.String && v.Field(i).String() == "" {
+			v.Field(i).Set(d.Field(i))
+		}
+	}
 }
 
 // SaveDefaultConfig creates a default config file if it doesn't exist.
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFromCustomPath(t *testing.T) {
+	path := writeConfig(t, `keybindings:
+  quit: ctrl+q
+  cycle_windows: ctrl+g
+  next_session: ""
+tab_switch_commands: []
+`)
+
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom error: %v", err)
+	}
+
+	defaults := DefaultConfig()
+	if cfg.KeyBindings.Quit != "ctrl+q" || cfg.KeyBindings.CycleWindows != "ctrl+g" {
+		t.Fatalf("expected keybindings from file, got %+v", cfg.KeyBindings)
+	}
+	if cfg.KeyBindings.NextSession != defaults.KeyBindings.NextSession {
+		t.Fatalf("expected empty keybinding to keep default, got %q", cfg.KeyBindings.NextSession)
+	}
+	if cfg.KeyBindings.ToggleTab != defaults.KeyBindings.ToggleTab {
+		t.Fatalf("expected unset keybinding to keep default, got %q", cfg.KeyBindings.ToggleTab)
+	}
+	if len(cfg.TabSwitchCommands) != 0 {
+		t.Fatalf("expected explicit empty list to override default, got %v", cfg.TabSwitchCommands)
+	}
+	if !reflect.DeepEqual(cfg.Conversation, defaults.Conversation) {
+		t.Fatalf("expected unset section to keep defaults, got %+v", cfg.Conversation)
+	}
+}
+
+func TestLoadConfigFromMissingPathErrors(t *testing.T) {
+	_, err := LoadConfigFrom(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil {
+		t.Fatalf("expected error for missing config file")
+	}
+}
+
+func TestLoadConfigFromInvalidYAMLErrors(t *testing.T) {
+	path := writeConfig(t, "keybindings: [not, a, map]\n")
+	if _, err := LoadConfigFrom(path); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
+
+func TestLoadConfigFromParsesIdleTimeout(t *testing.T) {
+	path := writeConfig(t, "idle_timeout: 30m\n")
+
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom error: %v", err)
+	}
+	if cfg.IdleTimeout != 30*time.Minute {
+		t.Fatalf("expected 30m idle timeout, got %v", cfg.IdleTimeout)
+	}
+}
+
+func TestLoadConfigFromThemeFallsBackToDefaults(t *testing.T) {
+	path := writeConfig(t, "theme:\n  active_tab_bg: \"#005f87\"\n  help_text: \"\"\n")
+
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom error: %v", err)
+	}
+	defaults := DefaultConfig().Theme
+	if cfg.Theme.ActiveTabBg != "#005f87" {
+		t.Fatalf("expected active_tab_bg from file, got %q", cfg.Theme.ActiveTabBg)
+	}
+	if cfg.Theme.HelpText != defaults.HelpText || cfg.Theme.ActiveTabFg != defaults.ActiveTabFg {
+		t.Fatalf("expected unset colors to keep defaults, got %+v", cfg.Theme)
+	}
+}
+
+func TestSaveLayoutKeepsOtherSettings(t *testing.T) {
+	path := writeConfig(t, "# my settings\nidle_timeout: 30m\nlayout:\n  sidebar_ratio: 0.25\n")
+
+	if err := SaveLayout(path, Layout{SidebarRatio: 0.4, SplitRatio: 0.6}); err != nil {
+		t.Fatalf("SaveLayout: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(data), "# my settings") {
+		t.Fatalf("expected comment to survive, got:\n%s", data)
+	}
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom: %v", err)
+	}
+	if cfg.IdleTimeout != 30*time.Minute {
+		t.Fatalf("idle_timeout lost: %v", cfg.IdleTimeout)
+	}
+	if cfg.Layout != (Layout{SidebarRatio: 0.4, SplitRatio: 0.6}) {
+		t.Fatalf("unexpected layout %+v", cfg.Layout)
+	}
+}
+
+func TestSaveLayoutKeepsFormatting(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "two-space indent and value comments",
+			content: "keybindings:\n  quit: ctrl+q\nlayout:\n  sidebar_ratio: 0.25 # narrow\n  split_ratio: 0.5\n",
+			want:    "keybindings:\n  quit: ctrl+q\nlayout:\n  sidebar_ratio: 0.4 # narrow\n  split_ratio: 0.6\n",
+		},
+		{
+			name:    "four-space indent, no layout yet",
+			content: "keybindings:\n    quit: ctrl+q\n",
+			want:    "keybindings:\n    quit: ctrl+q\nlayout:\n    sidebar_ratio: 0.4\n    split_ratio: 0.6\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.content)
+			if err := SaveLayout(path, Layout{SidebarRatio: 0.4, SplitRatio: 0.6}); err != nil {
+				t.Fatalf("SaveLayout: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveLayoutCreatesMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hiho", "config.yaml")
+	if err := SaveLayout(path, DefaultLayout()); err != nil {
+		t.Fatalf("SaveLayout: %v", err)
+	}
+	cfg, err := LoadConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom: %v", err)
+	}
+	if cfg.Layout != DefaultLayout() {
+		t.Fatalf("unexpected layout %+v", cfg.Layout)
+	}
+}
+
+func TestLoadConfigWithError(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string // "" leaves config.yaml missing
+		wantErr  string
+		wantQuit string
+	}{
+		{"missing file", "", "", "ctrl+c"},
+		{"valid", "keybindings:\n  quit: ctrl+q\n", "", "ctrl+q"},
+		{"not YAML", "keybindings: [\n", "parse config", "ctrl+c"},
+		{"unknown option", "keybindings:\n  quit: ctrl+q\n  qiut: ctrl+x\n", "field qiut not found", "ctrl+q"},
+		{"wrong type", "idle_timeout: soon\nkeybindings:\n  quit: ctrl+q\n", "line 1", "ctrl+q"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := withConfigDir(t)
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.content), 0644); err != nil {
+					t.Fatalf("write config: %v", err)
+				}
+			}
+			cfg, err := LoadConfigWithError()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+			if cfg.KeyBindings.Quit != tt.wantQuit {
+				t.Fatalf("quit = %q, want %q", cfg.KeyBindings.Quit, tt.wantQuit)
+			}
+			if loaded := LoadConfig(); loaded.KeyBindings.Quit != tt.wantQuit {
+				t.Fatalf("LoadConfig quit = %q, want %q", loaded.KeyBindings.Quit, tt.wantQuit)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+// KeyBindings defines keyboard shortcuts for the application.
+type KeyBindings struct {
+	Quit         string `yaml:"quit"`
+	CycleWindows string `yaml:"cycle_windows"`
+	NextSession  string `yaml:"next_session"`
+	PrevSession  string `yaml:"prev_session"`
+	ToggleTab    string `yaml:"toggle_tab"`
+	NextTab      string `yaml:"next_tab"`
+	PrevTab      string `yaml:"prev_tab"`
+	SessionUp    string `yaml:"session_up"`
+	SessionDown  string `yaml:"session_down"`
+	FocusSidebar string `yaml:"focus_sidebar"`
+	FocusMain    string `yaml:"focus_main"`
+	TogglePause  string `yaml:"toggle_pause"`
+	ToggleTail   string `yaml:"toggle_tail"`
+	ResizeLeft   string `yaml:"resize_left"`
+	ResizeRight  string `yaml:"resize_right"`
+	ReloadConfig string `yaml:"reload_config"`
+	// ScrollTop and ScrollBottom jump to the start or end of the main
+	// panel while it has focus; home and end always do too.
+	ScrollTop    string `yaml:"scroll_top"`
+	ScrollBottom string `yaml:"scroll_bottom"`
+}
+
+// DefaultKeyBindings returns the keybindings used when the config sets none.
+func DefaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		Quit:         "ctrl+c",
+		CycleWindows: "ctrl+o",
+		NextSession:  "alt+right",
+		PrevSession:  "alt+left",
+		ToggleTab:    "tab",
+		NextTab:      "shift+right",
+		PrevTab:      "shift+left",
+		SessionUp:    "up",
+		SessionDown:  "down",
+		FocusSidebar: "ctrl+1",
+		FocusMain:    "ctrl+2",
+		TogglePause:  "ctrl+p",
+		ToggleTail:   "ctrl+t",
+		ResizeLeft:   "ctrl+left",
+		ResizeRight:  "ctrl+right",
+		ReloadConfig: "ctrl+r",
+		ScrollTop:    "g",
+		ScrollBottom: "G",
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layout holds panel proportions, adjusted at runtime with the resize keys.
+type Layout struct {
+	// SidebarRatio is the share of the width given to the sidebar.
+	SidebarRatio float64 `yaml:"sidebar_ratio"`
+	// SplitRatio is the share of the main panel given to the left pane of
+	// a /split.
+	SplitRatio float64 `yaml:"split_ratio"`
+}
+
+// DefaultLayout gives the sidebar a third of the width and splits the main
+// panel evenly.
+func DefaultLayout() Layout {
+	return Layout{SidebarRatio: 1.0 / 3, SplitRatio: 0.5}
+}
+
+// Path returns the default config file, ~/.config/hiho/config.yaml, or ""
+// when there is no home directory.
+func Path() string {
+	return configPath()
+}
+
+// SaveLayout writes layout into the config file at path, leaving every
+// other setting, its comments and its indentation as they are. A missing
+// file is created.
+func SaveLayout(path string, layout Layout) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("save layout: %w", err)
+	default:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("save layout: parse %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("save layout: %s is not a YAML mapping", path)
+	}
+
+	var value yaml.Node
+	if err := value.Encode(layout); err != nil {
+		return fmt.Errorf("save layout: %w", err)
+	}
+	if existing := mappingValue(root, "layout"); existing != nil && existing.Kind == yaml.MappingNode {
+		// Update the ratios in place so comments on them survive
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			setMappingScalar(existing, value.Content[i].Value, value.Content[i+1])
+		}
+	} else {
+		setMappingKey(root, "layout", &value)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(indentOf(data))
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("save layout: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("save layout: %w", err)
+	}
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
+
+// indentOf guesses the indentation step of a YAML file from its first
+// indented line, defaulting to two spaces.
+func indentOf(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if n := len(line) - len(trimmed); n > 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return n
+		}
+	}
+	return 2
+}
+
+// mappingValue returns the value of key in a YAML mapping, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingScalar is setMappingKey for a scalar value that keeps the
+// comments of the value it replaces.
+func setMappingScalar(mapping *yaml.Node, key string, value *yaml.Node) {
+	if old := mappingValue(mapping, key); old != nil && old.Kind == yaml.ScalarNode {
+		old.Value, old.Tag, old.Style = value.Value, value.Tag, value.Style
+		return
+	}
+	setMappingKey(mapping, key, value)
+}
+
+// setMappingKey replaces the value of key in a YAML mapping, appending the
+// key if it is not there yet.
+func setMappingKey(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProfilePath returns the file for a named profile,
+// ~/.config/hiho/<name>.yaml.
+func ProfilePath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	dir := configDir()
+	if dir == "" {
+		return "", fmt.Errorf("no home directory for profile %q", name)
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// ProfileFile returns the file a profile is read from, and so the one its
+// layout is saved to: its own file when it exists, otherwise config.yaml it
+// falls back to. It is "" for an invalid name or without a home directory.
+func ProfileFile(name string) string {
+	path, err := ProfilePath(name)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return configPath()
+	}
+	return path
+}
+
+// LoadProfile loads a named profile. When the profile file does not exist
+// it falls back to config.yaml (or the defaults if that is missing too),
+// so a profile only needs to exist where it differs. A profile that exists
+// but cannot be parsed is an error.
+func LoadProfile(name string) (Config, error) {
+	path, err := ProfilePath(name)
+	if err != nil {
+		return DefaultConfig(), err
+	}
+	cfg, err := LoadConfigFrom(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return LoadConfig(), nil
+	}
+	return cfg, err
+}
+
+// Profiles lists the profile names available in the config directory,
+// i.e. every *.yaml file other than config.yaml.
+func Profiles() ([]string, error) {
+	dir := configDir()
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list profiles: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if ok && !entry.IsDir() && name != "config" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// withConfigDir points the config directory at a temp HOME.
+func withConfigDir(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "hiho")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	return dir
+}
+
+func TestProfilePath(t *testing.T) {
+	dir := withConfigDir(t)
+
+	got, err := ProfilePath("work")
+	if err != nil || got != filepath.Join(dir, "work.yaml") {
+		t.Fatalf("ProfilePath(work) = %q, %v", got, err)
+	}
+	for _, bad := range []string{"", "../work", "a/b", ".hidden"} {
+		if _, err := ProfilePath(bad); err == nil {
+			t.Errorf("ProfilePath(%q) expected error", bad)
+		}
+	}
+}
+
+func TestProfileFile(t *testing.T) {
+	dir := withConfigDir(t)
+	os.WriteFile(filepath.Join(dir, "work.yaml"), nil, 0644)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"work", filepath.Join(dir, "work.yaml")},
+		{"personal", filepath.Join(dir, "config.yaml")},
+		{"../work", ""},
+	}
+	for _, tt := range tests {
+		if got := ProfileFile(tt.name); got != tt.want {
+			t.Errorf("ProfileFile(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadProfileFallsBackToConfigYAML(t *testing.T) {
+	dir := withConfigDir(t)
+	os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("keybindings:\n  quit: ctrl+q\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "work.yaml"), []byte("keybindings:\n  quit: ctrl+w\n"), 0644)
+
+	cfg, err := LoadProfile("work")
+	if err != nil || cfg.KeyBindings.Quit != "ctrl+w" {
+		t.Fatalf("expected work profile, got %q, %v", cfg.KeyBindings.Quit, err)
+	}
+
+	cfg, err = LoadProfile("personal")
+	if err != nil || cfg.KeyBindings.Quit != "ctrl+q" {
+		t.Fatalf("expected fallback to config.yaml, got %q, %v", cfg.KeyBindings.Quit, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("keybindings: ["), 0644)
+	if _, err := LoadProfile("broken"); err == nil {
+		t.Fatalf("expected parse error for a broken profile")
+	}
+}
+
+func TestProfilesListsYAMLFiles(t *testing.T) {
+	dir := withConfigDir(t)
+	for _, name := range []string{"config.yaml", "work.yaml", "personal.yaml", "notes.txt"} {
+		os.WriteFile(filepath.Join(dir, name), nil, 0644)
+	}
+
+	got, err := Profiles()
+	if err != nil {
+		t.Fatalf("Profiles error: %v", err)
+	}
+	if want := []string{"personal", "work"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Profiles() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// namedKeys are the key names the terminal input parser produces besides
+// single characters.
+var namedKeys = map[string]bool{
+	"up": true, "down": true, "left": true, "right": true,
+	"home": true, "end": true, "pgup": true, "pgdown": true,
+	"insert": true, "delete": true, "backspace": true,
+	"tab": true, "enter": true, "esc": true,
+	"f1": true, "f2": true, "f3": true, "f4": true, "f5": true, "f6": true,
+	"f7": true, "f8": true, "f9": true, "f10": true, "f11": true, "f12": true,
+}
+
+// modifiableKeys are the named keys the terminal reports with any
+// combination of modifiers, e.g. "shift+alt+ctrl+up". The other named keys
+// only come bare, apart from shift+tab and alt+enter.
+var modifiableKeys = map[string]bool{
+	"up": true, "down": true, "left": true, "right": true,
+	"home": true, "end": true, "pgup": true, "pgdown": true,
+	"insert": true, "delete": true,
+	"f1": true, "f2": true, "f3": true, "f4": true, "f5": true, "f6": true,
+	"f7": true, "f8": true, "f9": true, "f10": true, "f11": true, "f12": true,
+}
+
+// ctrlLetters are the letters whose ctrl+ key the input parser reports,
+// from the control byte the terminal sends for it.
+const ctrlLetters = "abcdefklnoprtuw"
+
+// modifiers in the order the input parser writes them, e.g. "shift+ctrl+up".
+var modifiers = []string{"shift+", "alt+", "ctrl+"}
+
+// Validate reports keybindings that can never fire: key names the input
+// parser does not produce, and keys bound twice, where the first binding
+// shadows the other. It also reports values hiho does not recognise. The
+// config is still usable; these are warnings.
+func Validate(cfg Config) []string {
+	var warnings []string
+	switch cfg.SessionOrder {
+	case "", "newest", "oldest":
+	default:
+		warnings = append(warnings, fmt.Sprintf("session_order: unknown order %q (want newest or oldest)", cfg.SessionOrder))
+	}
+	owner := make(map[string]string) // key -> first keybinding using it
+
+	v := reflect.ValueOf(cfg.KeyBindings)
+	for i := 0; i < v.NumField(); i++ {
+		name := "keybindings." + v.Type().Field(i).Tag.Get("yaml")
+		key := v.Field(i).String()
+		if problem := checkKey(key); problem != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", name, problem))
+			continue
+		}
+		if first, ok := owner[key]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: %q is already bound to %s", name, key, first))
+			continue
+		}
+		owner[key] = name
+	}
+
+	keys := make([]string, 0, len(cfg.CommandBindings))
+	for key := range cfg.CommandBindings {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		name := fmt.Sprintf("command_bindings[%q]", key)
+		if problem := checkKey(key); problem != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", name, problem))
+		} else if first, ok := owner[key]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: shadowed by %s", name, first))
+		}
+	}
+	return warnings
+}
+
+// checkKey describes why key is not a key name the input parser produces,
+// or returns "" if it is one.
+func checkKey(key string) string {
+	base, next := key, 0
+	for {
+		i := slices.IndexFunc(modifiers, func(m string) bool { return strings.HasPrefix(base, m) })
+		if i < 0 {
+			break
+		}
+		if i < next {
+			return fmt.Sprintf("unknown key %q (modifiers go in the order shift+alt+ctrl+)", key)
+		}
+		base, next = base[len(modifiers[i]):], i+1
+	}
+	if !namedKeys[base] && utf8.RuneCountInString(base) != 1 {
+		return fmt.Sprintf("unknown key %q", key)
+	}
+	if !reported(key[:len(key)-len(base)], base) {
+		return fmt.Sprintf("key %q never reaches hiho: the terminal does not report it", key)
+	}
+	return ""
+}
+
+// reported reports whether the input parser can produce base pressed with
+// mods, where base is a named key or a single character.
+func reported(mods, base string) bool {
+	r, _ := utf8.DecodeRuneInString(base)
+	switch {
+	case namedKeys[base]:
+		return mods == "" || modifiableKeys[base] || mods+base == "shift+tab" || mods+base == "alt+enter"
+	case mods == "":
+		return true
+	case mods == "alt+":
+		// ESC [ and ESC O start escape sequences instead
+		return base != "[" && base != "O"
+	case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		return mods == "ctrl+" && strings.ContainsRune(ctrlLetters, r)
+	default:
+		// Other characters, such as ctrl+1, have no control byte; terminals
+		// send them as modifyOtherKeys reports, which always include ctrl
+		return strings.Contains(mods, "ctrl+")
+	}
+}
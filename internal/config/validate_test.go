@@ -0,0 +1,65 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   []string
+	}{
+		{"defaults", func(*Config) {}, nil},
+		{"known keys", func(c *Config) {
+			c.KeyBindings.Quit = "ctrl+k"
+			c.KeyBindings.FocusSidebar = "ctrl+!"
+			c.KeyBindings.FocusMain = "alt+x"
+			c.KeyBindings.ToggleTail = "shift+f5"
+			c.KeyBindings.TogglePause = "shift+alt+ctrl+up"
+			c.CommandBindings = map[string]string{"f6": "/refresh", "é": "/list"}
+		}, nil},
+		{"unknown key", func(c *Config) { c.KeyBindings.Quit = "ctrl+qq" }, []string{
+			`keybindings.quit: unknown key "ctrl+qq"`,
+		}},
+		{"keys the terminal never reports", func(c *Config) {
+			c.KeyBindings.Quit = "ctrl+q"
+			c.KeyBindings.ToggleTail = "ctrl+T"
+			c.KeyBindings.TogglePause = "shift+a"
+			c.KeyBindings.ReloadConfig = "alt+["
+			c.CommandBindings = map[string]string{"ctrl+enter": "/list", "shift+1": "/help"}
+		}, []string{
+			`keybindings.quit: key "ctrl+q" never reaches hiho: the terminal does not report it`,
+			`keybindings.toggle_pause: key "shift+a" never reaches hiho: the terminal does not report it`,
+			`keybindings.toggle_tail: key "ctrl+T" never reaches hiho: the terminal does not report it`,
+			`keybindings.reload_config: key "alt+[" never reaches hiho: the terminal does not report it`,
+			`command_bindings["ctrl+enter"]: key "ctrl+enter" never reaches hiho: the terminal does not report it`,
+			`command_bindings["shift+1"]: key "shift+1" never reaches hiho: the terminal does not report it`,
+		}},
+		{"modifiers out of order", func(c *Config) { c.KeyBindings.Quit = "ctrl+shift+up" }, []string{
+			`keybindings.quit: unknown key "ctrl+shift+up" (modifiers go in the order shift+alt+ctrl+)`,
+		}},
+		{"duplicate keybinding", func(c *Config) { c.KeyBindings.ToggleTab = "ctrl+c" }, []string{
+			`keybindings.toggle_tab: "ctrl+c" is already bound to keybindings.quit`,
+		}},
+		{"session order", func(c *Config) { c.SessionOrder = "recent" }, []string{
+			`session_order: unknown order "recent" (want newest or oldest)`,
+		}},
+		{"command bindings", func(c *Config) {
+			c.CommandBindings = map[string]string{"tab": "/list", "f13": "/help"}
+		}, []string{
+			`command_bindings["f13"]: unknown key "f13"`,
+			`command_bindings["tab"]: shadowed by keybindings.toggle_tab`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if got := Validate(cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package envfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `# project settings
+NODE_ENV=development
+
+export API_URL="http://localhost:8080"
+GREETING='hello world'
+EMPTY=
+  PADDED = value  
+`
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	want := []string{
+		"NODE_ENV=development",
+		"API_URL=http://localhost:8080",
+		"GREETING=hello world",
+		"EMPTY=",
+		"PADDED=value",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Parse() = %q, want %q", got, want)
+	}
+}
+
+func TestParseRejectsMalformedLines(t *testing.T) {
+	tests := []string{
+		"NOVALUE",
+		"1BAD=x",
+		"WITH SPACE=x",
+	}
+	for _, input := range tests {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.env"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+}
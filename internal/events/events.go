@@ -0,0 +1,88 @@
+// Package events writes hiho lifecycle events as JSON lines so external
+// tools (dashboards, monitors) can follow what the TUI is doing.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Type names an event.
+type Type string
+
+const (
+	SessionCreated  Type = "session_created"
+	SessionKilled   Type = "session_killed"
+	SessionFinished Type = "session_finished"
+	CaptureUpdated  Type = "capture_updated"
+)
+
+// Event is one JSON line on the stream.
+type Event struct {
+	Type    Type   `json:"type"`
+	Session string `json:"session"`
+	Command string `json:"command,omitempty"`
+	// ExitCode is the exit status of the session's command on a
+	// session_finished event for a command that exited; it is left out
+	// when the session disappeared instead.
+	ExitCode *int      `json:"exit_code,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// JSON encodes events as newline-delimited JSON. It is safe for
+// concurrent use.
+type JSON struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	c   io.Closer
+}
+
+// NewJSON writes events to w.
+func NewJSON(w io.Writer) *JSON {
+	j := &JSON{enc: json.NewEncoder(w)}
+	if c, ok := w.(io.Closer); ok {
+		j.c = c
+	}
+	return j
+}
+
+// Open connects to the unix socket at path or, if path is not a socket,
+// appends to it as a file (a FIFO or /dev/fd/N work too).
+func Open(path string) (*JSON, error) {
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("events: %w", err)
+		}
+		return NewJSON(conn), nil
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	return NewJSON(file), nil
+}
+
+// Emit writes e, stamping the current time if unset. Write errors are
+// dropped: a slow or vanished listener must never disturb the TUI.
+func (j *JSON) Emit(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = j.enc.Encode(e)
+}
+
+// Close releases the underlying socket or file.
+func (j *JSON) Close() error {
+	if j.c == nil {
+		return nil
+	}
+	return j.c.Close()
+}
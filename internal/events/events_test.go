@@ -0,0 +1,69 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONWritesOneLinePerEvent(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSON(&buf)
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sink.Emit(Event{Type: SessionCreated, Session: "hiho-1-0", Command: "top", Time: at})
+	sink.Emit(Event{Type: SessionKilled, Session: "hiho-1-0", Time: at})
+
+	want := `{"type":"session_created","session":"hiho-1-0","command":"top","time":"2024-01-02T03:04:05Z"}
+{"type":"session_killed","session":"hiho-1-0","time":"2024-01-02T03:04:05Z"}
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestEmitStampsTime(t *testing.T) {
+	var buf bytes.Buffer
+	NewJSON(&buf).Emit(Event{Type: CaptureUpdated, Session: "s"})
+
+	var e Event
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if e.Time.IsZero() {
+		t.Fatal("expected time to be set")
+	}
+}
+
+func TestOpenUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer ln.Close()
+
+	sink, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer sink.Close()
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	defer conn.Close()
+
+	sink.Emit(Event{Type: SessionFinished, Session: "hiho-1-0"})
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var e Event
+	if err := json.Unmarshal(line, &e); err != nil || e.Type != SessionFinished {
+		t.Fatalf("unexpected event %q (%v)", line, err)
+	}
+}
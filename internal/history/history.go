@@ -0,0 +1,187 @@
+// Package history keeps the conversation log in a JSON lines file so it
+// survives restarts. Each message is appended as it is added, so a crash
+// loses at most the line being written. The file is trimmed back to the
+// newest entries as it grows, so it stays bounded.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultLimit is how many of the newest entries Load returns.
+const DefaultLimit = 1000
+
+// compactAt is how many lines the file may grow to before Append trims it
+// back to the newest DefaultLimit entries.
+const compactAt = 2 * DefaultLimit
+
+// Entry is one conversation message. Session names the session whose
+// conversation it belongs to, empty for the global one.
+type Entry struct {
+	Session string `json:"session,omitempty"`
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// File appends entries to a JSON lines file. It is safe for concurrent use.
+type File struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	lines int // lines in the file, counted at Open and kept up to date
+}
+
+// Open opens (creating if needed) the history file at path, along with its
+// directory.
+func Open(path string) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	lines, err := countLines(path)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	return &File{path: path, file: file, lines: lines}, nil
+}
+
+// countLines returns how many newline-terminated lines the file at path
+// holds.
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	lines := 0
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := file.Read(buf)
+		lines += bytes.Count(buf[:n], []byte{'\n'})
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Load returns up to limit of the newest entries in the file. Lines that
+// do not parse, such as one cut short by a crash, are skipped.
+func (f *File) Load(limit int) ([]Entry, error) {
+	file, err := os.Open(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var e Entry
+		if len(line) > 0 && json.Unmarshal(line, &e) == nil {
+			entries = append(entries, e)
+			if limit > 0 && len(entries) > 2*limit {
+				entries = append(entries[:0], entries[len(entries)-limit:]...)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("history: %w", err)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// Append writes e as one line, first trimming the file to the newest
+// entries once it has reached compactAt lines.
+func (f *File) Append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lines >= compactAt {
+		if err := f.compact(DefaultLimit - 1); err != nil {
+			return err
+		}
+	}
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	f.lines++
+	return nil
+}
+
+// compact rewrites the file with only its newest keep entries. The new
+// content is written beside the file and renamed over it, so a crash
+// leaves either the old file or the new one. The caller holds f.mu.
+func (f *File) compact(keep int) error {
+	entries, err := f.Load(keep)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("history: %w", err)
+		}
+		buf.Write(append(line, '\n'))
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("history: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	f.file.Close()
+	f.file, f.lines = file, len(entries)
+	return nil
+}
+
+// Clear removes every entry from the file.
+func (f *File) Clear() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.file.Truncate(0); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	f.lines = 0
+	return nil
+}
+
+// Close closes the file.
+func (f *File) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.jsonl")
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	want := []Entry{
+		{Role: "user", Content: "first note"},
+		{Role: "info", Content: "multi\nline"},
+	}
+	for _, e := range want {
+		if err := f.Append(e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	got, err := f.Load(DefaultLimit)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadKeepsNewestAndSkipsBrokenLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"role":"user","content":"one"}
+not json
+{"role":"user","content":"two"}
+{"role":"user","content":"three"}
+{"role":"user","cont`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	got, err := f.Load(2)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []Entry{{Role: "user", Content: "two"}, {Role: "user", Content: "three"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestClear(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "history.jsonl"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	if err := f.Append(Entry{Role: "user", Content: "old"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := f.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if err := f.Append(Entry{Role: "user", Content: "new"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	got, err := f.Load(DefaultLimit)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []Entry{{Role: "user", Content: "new"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppendCompactsLongFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  int
+		wantLines int
+	}{
+		{"below threshold", compactAt - 1, compactAt},
+		{"at threshold", compactAt, DefaultLimit},
+		{"well past threshold", compactAt + 500, DefaultLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history.jsonl")
+			var content strings.Builder
+			for i := 0; i < tt.existing; i++ {
+				fmt.Fprintf(&content, "{\"role\":\"user\",\"content\":\"%d\"}\n", i)
+			}
+			if err := os.WriteFile(path, []byte(content.String()), 0o600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			f, err := Open(path)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			defer f.Close()
+
+			if err := f.Append(Entry{Role: "user", Content: "last"}); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if got := strings.Count(string(data), "\n"); got != tt.wantLines {
+				t.Fatalf("file has %d lines, want %d", got, tt.wantLines)
+			}
+			got, err := f.Load(2)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			want := []Entry{
+				{Role: "user", Content: fmt.Sprint(tt.existing - 1)},
+				{Role: "user", Content: "last"},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Load = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package textdiff computes line-based differences between two texts.
+package textdiff
+
+// Op describes how a line differs between the old and new text.
+type Op int
+
+const (
+	Equal Op = iota
+	Added
+	Removed
+	Changed
+)
+
+// Line is one line of a diff. For Changed lines Old holds the previous text.
+type Line struct {
+	Op   Op
+	Text string
+	Old  string
+}
+
+// maxTableCells bounds the LCS table Lines builds for the part of the two
+// texts that differs. Past it the differing lines are simply paired up, so
+// diffing two unrelated screens of scrollback stays cheap.
+const maxTableCells = 1 << 20
+
+// Lines diffs old against new using a longest common subsequence. A run of
+// removed lines directly followed by a run of added lines is reported as
+// Changed lines, pairing them up in order; any surplus stays Removed or Added.
+// The common head and tail of the texts are matched up front, so the table
+// only covers the lines in between.
+func Lines(old, new []string) []Line {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	var out []Line
+	for _, s := range new[:prefix] {
+		out = append(out, Line{Op: Equal, Text: s})
+	}
+	out = append(out, diffMiddle(old[prefix:len(old)-suffix], new[prefix:len(new)-suffix])...)
+	for _, s := range new[len(new)-suffix:] {
+		out = append(out, Line{Op: Equal, Text: s})
+	}
+	return out
+}
+
+// diffMiddle is Lines without the shortcut for a common head and tail.
+func diffMiddle(old, new []string) []Line {
+	// lcs[i][j] is the LCS length of old[i:] and new[j:]; left nil when too
+	// large, which lines everything up as changed
+	var lcs [][]int
+	if len(old)*len(new) <= maxTableCells {
+		lcs = make([][]int, len(old)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(new)+1)
+		}
+		for i := len(old) - 1; i >= 0; i-- {
+			for j := len(new) - 1; j >= 0; j-- {
+				if old[i] == new[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+	}
+
+	var out []Line
+	var removed, added []string
+	flush := func() {
+		n := min(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			out = append(out, Line{Op: Changed, Text: added[k], Old: removed[k]})
+		}
+		for _, s := range removed[n:] {
+			out = append(out, Line{Op: Removed, Text: s})
+		}
+		for _, s := range added[n:] {
+			out = append(out, Line{Op: Added, Text: s})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < len(old) || j < len(new) {
+		switch {
+		case lcs != nil && i < len(old) && j < len(new) && old[i] == new[j]:
+			flush()
+			out = append(out, Line{Op: Equal, Text: new[j]})
+			i++
+			j++
+		case j < len(new) && (i == len(old) || lcs == nil || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, new[j])
+			j++
+		default:
+			removed = append(removed, old[i])
+			i++
+		}
+	}
+	flush()
+	return out
+}
@@ -0,0 +1,91 @@
+package textdiff
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  []string
+		new  []string
+		want []Line
+	}{
+		{
+			name: "identical",
+			old:  []string{"a", "b"},
+			new:  []string{"a", "b"},
+			want: []Line{{Op: Equal, Text: "a"}, {Op: Equal, Text: "b"}},
+		},
+		{
+			name: "appended output",
+			old:  []string{"$ make", "building"},
+			new:  []string{"$ make", "building", "done"},
+			want: []Line{{Op: Equal, Text: "$ make"}, {Op: Equal, Text: "building"}, {Op: Added, Text: "done"}},
+		},
+		{
+			name: "removed line",
+			old:  []string{"a", "b", "c"},
+			new:  []string{"a", "c"},
+			want: []Line{{Op: Equal, Text: "a"}, {Op: Removed, Text: "b"}, {Op: Equal, Text: "c"}},
+		},
+		{
+			name: "changed line",
+			old:  []string{"progress 10%", "eta 5m"},
+			new:  []string{"progress 50%", "eta 5m"},
+			want: []Line{{Op: Changed, Text: "progress 50%", Old: "progress 10%"}, {Op: Equal, Text: "eta 5m"}},
+		},
+		{
+			name: "changed with surplus added",
+			old:  []string{"x", "end"},
+			new:  []string{"y", "z", "end"},
+			want: []Line{{Op: Changed, Text: "y", Old: "x"}, {Op: Added, Text: "z"}, {Op: Equal, Text: "end"}},
+		},
+		{
+			name: "change between a common head and tail",
+			old:  []string{"$ ls", "a", "b", "$"},
+			new:  []string{"$ ls", "a", "c", "d", "$"},
+			want: []Line{{Op: Equal, Text: "$ ls"}, {Op: Equal, Text: "a"}, {Op: Changed, Text: "c", Old: "b"}, {Op: Added, Text: "d"}, {Op: Equal, Text: "$"}},
+		},
+		{
+			name: "from empty",
+			old:  nil,
+			new:  []string{"a"},
+			want: []Line{{Op: Added, Text: "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lines(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Lines() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinesPairsUpDifferencesTooLargeToTable(t *testing.T) {
+	n := 2000 // n*n is past maxTableCells
+	old := make([]string, n+2)
+	new := make([]string, n+2)
+	old[0], new[0] = "head", "head"
+	old[n+1], new[n+1] = "tail", "tail"
+	for i := 1; i <= n; i++ {
+		old[i] = fmt.Sprintf("old %d", i)
+		new[i] = fmt.Sprintf("new %d", i)
+	}
+
+	got := Lines(old, new)
+	if len(got) != n+2 || got[0] != (Line{Op: Equal, Text: "head"}) || got[n+1] != (Line{Op: Equal, Text: "tail"}) {
+		t.Fatalf("expected head and tail kept equal around %d lines, got %d lines", n, len(got))
+	}
+	for _, line := range got[1 : n+1] {
+		if line.Op != Changed {
+			t.Fatalf("expected every line in between changed, got %+v", line)
+		}
+	}
+	if got[1] != (Line{Op: Changed, Text: "new 1", Old: "old 1"}) {
+		t.Fatalf("expected lines paired in order, got %+v", got[1])
+	}
+}
@@ -0,0 +1,12 @@
+package tmux
+
+// AttachArgs returns the tmux arguments that attach the terminal to a
+// session. A read-only attach (-r) lets the user watch the pane without
+// their keystrokes reaching it.
+func AttachArgs(name string, readOnly bool) []string {
+	args := []string{"attach-session"}
+	if readOnly {
+		args = append(args, "-r")
+	}
+	return append(args, "-t", name)
+}
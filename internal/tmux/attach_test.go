@@ -0,0 +1,21 @@
+package tmux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAttachArgs(t *testing.T) {
+	tests := []struct {
+		readOnly bool
+		want     []string
+	}{
+		{false, []string{"attach-session", "-t", "hiho-1-0"}},
+		{true, []string{"attach-session", "-r", "-t", "hiho-1-0"}},
+	}
+	for _, tt := range tests {
+		if got := AttachArgs("hiho-1-0", tt.readOnly); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("AttachArgs(readOnly=%v) = %v, want %v", tt.readOnly, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package tmux
+
+import "strings"
+
+// exportPrefix turns KEY=VALUE pairs into a shell export statement that
+// runs ahead of the session command.
+func exportPrefix(env []string) string {
+	if len(env) == 0 {
+		return ""
+	}
+	assignments := make([]string, 0, len(env))
+	for _, pair := range env {
+		key, value, _ := strings.Cut(pair, "=")
+		assignments = append(assignments, key+"="+shellQuote(value))
+	}
+	return "export " + strings.Join(assignments, " ") + "; "
+}
+
+// shellQuote wraps s in single quotes, escaping embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
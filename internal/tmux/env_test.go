@@ -0,0 +1,23 @@
+package tmux
+
+import "testing"
+
+func TestExportPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		env  []string
+		want string
+	}{
+		{"empty", nil, ""},
+		{"single", []string{"NODE_ENV=production"}, "export NODE_ENV='production'; "},
+		{"quotes", []string{"GREETING=it's me"}, `export GREETING='it'\''s me'; `},
+		{"several", []string{"A=1", "B=a b"}, "export A='1' B='a b'; "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exportPrefix(tt.env); got != tt.want {
+				t.Fatalf("exportPrefix(%q) = %q, want %q", tt.env, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Operations reported in SessionError.Op.
+const (
+	OpCreate   = "create"
+	OpCapture  = "capture"
+	OpSwitch   = "switch"
+	OpKill     = "kill"
+	OpSendKeys = "send keys"
+	OpRename   = "rename"
+	OpResize   = "resize"
+	OpStats    = "stats"
+	OpTag      = "tag"
+)
+
+// ErrSessionNotFound indicates the requested session could not be located.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionError is a failed tmux operation on a named session. It unwraps
+// to the underlying error, so errors.Is(err, ErrSessionNotFound) still
+// works when tmux reported the session missing.
+type SessionError struct {
+	Name string
+	Op   string
+	Err  error
+}
+
+func (e *SessionError) Error() string {
+	return fmt.Sprintf("session %s: %s failed: %v", e.Name, e.Op, e.Err)
+}
+
+func (e *SessionError) Unwrap() error {
+	return e.Err
+}
+
+// sessionError wraps err for the named session, marking it as
+// ErrSessionNotFound when tmux said the session (or server) is gone.
+func sessionError(name, op string, err error) error {
+	if !errors.Is(err, ErrSessionNotFound) && isMissingSession(err.Error()) {
+		err = fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	return &SessionError{Name: name, Op: op, Err: err}
+}
@@ -0,0 +1,48 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSessionErrorCarriesNameAndUnwraps(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+		wantMessage  string
+	}{
+		{
+			name:         "tmux reports missing session",
+			err:          sessionError("hiho-1-0", OpCapture, fmt.Errorf("exit status 1: can't find session: hiho-1-0")),
+			wantNotFound: true,
+			wantMessage:  "session hiho-1-0: capture failed: session not found: exit status 1: can't find session: hiho-1-0",
+		},
+		{
+			name:         "sentinel passed through",
+			err:          &SessionError{Name: "hiho-1-0", Op: OpSwitch, Err: ErrSessionNotFound},
+			wantNotFound: true,
+			wantMessage:  "session hiho-1-0: switch failed: session not found",
+		},
+		{
+			name:        "other failure",
+			err:         sessionError("hiho-1-0", OpKill, errors.New("exit status 1: permission denied")),
+			wantMessage: "session hiho-1-0: kill failed: exit status 1: permission denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sessionErr *SessionError
+			if !errors.As(tt.err, &sessionErr) || sessionErr.Name != "hiho-1-0" {
+				t.Fatalf("expected SessionError for hiho-1-0, got %#v", tt.err)
+			}
+			if got := errors.Is(tt.err, ErrSessionNotFound); got != tt.wantNotFound {
+				t.Fatalf("errors.Is(ErrSessionNotFound) = %v, want %v", got, tt.wantNotFound)
+			}
+			if tt.err.Error() != tt.wantMessage {
+				t.Fatalf("Error() = %q, want %q", tt.err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
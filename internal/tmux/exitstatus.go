@@ -0,0 +1,28 @@
+package tmux
+
+import "fmt"
+
+// exitOption is the session option NewSession stores its command's exit
+// status in.
+const exitOption = "@hiho_exit"
+
+// quietShell is what a new session's pane runs: bash, started with terminal
+// echo off so the line startLine types is not shown. The line turns echo
+// back on before anything else.
+const quietShell = "stty -echo; exec bash"
+
+// startLine is the line NewSession types into a session's shell to run cmd
+// with the env exports and record its exit status in exitOption. It prints
+// cmd in place of itself, since echo is off while it is typed (see
+// quietShell).
+//
+// cmd runs through eval in the interactive shell itself, so jobs it starts
+// and directories it changes to stay with the session, and a trailing & or
+// ; keeps its meaning. pipefail is on only while cmd runs, so a failing
+// pipeline counts as a failure; the option is then put back as it was.
+func startLine(env []string, cmd string) string {
+	return fmt.Sprintf("stty echo; printf '%%s\\n' %s; %s__hiho_pipefail=$(shopt -po pipefail); "+
+		"set -o pipefail; eval %s; __hiho_status=$?; eval \"$__hiho_pipefail\"; "+
+		"tmux set-option -q %s $__hiho_status; unset __hiho_pipefail __hiho_status",
+		shellQuote(cmd), exportPrefix(env), shellQuote(cmd), exitOption)
+}
@@ -0,0 +1,74 @@
+package tmux
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListReportsExitStatus(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	tests := []struct {
+		name string
+		cmd  string
+		want int
+	}{
+		{"failure", "false", 1},
+		{"failing pipeline", "false | true", 1},
+		{"trailing semicolon", "false;", 1},
+		{"trailing ampersand", "sleep 30 &", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager()
+			session, err := manager.NewSession(tt.cmd)
+			if err != nil {
+				t.Fatalf("failed to create session: %v", err)
+			}
+			defer manager.Kill(session.Name)
+
+			if code, ok := waitForExit(manager, session.Name); !ok {
+				t.Fatal("expected the session to report its command exited")
+			} else if code != tt.want {
+				t.Fatalf("ExitCode = %d, want %d", code, tt.want)
+			}
+
+			// The shell is left as the user would have it: the command's
+			// jobs are its own, pipefail is off and the line hiho typed
+			// to run the command is not shown
+			if err := manager.SendKeys(session.Name, "echo jobs=$(jobs | wc -l) $(shopt -po pipefail)"); err != nil {
+				t.Fatalf("SendKeys error: %v", err)
+			}
+			wantJobs := "jobs=0"
+			if strings.HasSuffix(tt.cmd, "&") {
+				wantJobs = "jobs=1"
+			}
+			output, ok := waitForOutput(manager, session.Name, "\n"+wantJobs+" set +o pipefail")
+			if !ok {
+				t.Fatalf("expected %s and pipefail off, got: %q", wantJobs, output)
+			}
+			if !strings.Contains(output, "\n"+tt.cmd+"\n") || strings.Contains(output, exitOption) {
+				t.Fatalf("expected only the command shown, got: %q", output)
+			}
+		})
+	}
+}
+
+// waitForExit lists sessions until name reports its command exited, for up
+// to five seconds, and returns the exit status.
+func waitForExit(manager *Manager, name string) (int, bool) {
+	for range 100 {
+		sessions, _ := manager.List()
+		for _, s := range sessions {
+			if s.Name == name && s.Exited {
+				return s.ExitCode, true
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return 0, false
+}
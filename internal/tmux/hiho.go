@@ -0,0 +1,53 @@
+package tmux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ListHiho returns only tmux sessions with the hiho- prefix, newest first.
+// Callers that honor session_order re-sort with SortByCreation.
+func (m *Manager) ListHiho() ([]Session, error) {
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	var hihoSessions []Session
+	for _, session := range sessions {
+		if strings.HasPrefix(session.Name, hihoPrefix) {
+			hihoSessions = append(hihoSessions, session)
+		}
+	}
+	SortByCreation(hihoSessions, false)
+	return hihoSessions, nil
+}
+
+// SortByCreation orders sessions newest first, or oldest first. Sessions
+// created in the same second keep tmux's order, which is by name.
+func SortByCreation(sessions []Session, oldestFirst bool) {
+	slices.SortStableFunc(sessions, func(a, b Session) int {
+		if oldestFirst {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+}
+
+// KillAllHiho terminates all sessions with the hiho- prefix.
+func (m *Manager) KillAllHiho() error {
+	sessions, err := m.ListHiho()
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, session := range sessions {
+		if err := m.Kill(session.Name); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to kill sessions: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
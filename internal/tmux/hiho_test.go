@@ -0,0 +1,116 @@
+package tmux
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortByCreation(t *testing.T) {
+	at := func(sec int64) time.Time { return time.Unix(sec, 0) }
+	sessions := []Session{
+		{Name: "hiho-1-0", CreatedAt: at(100)},
+		{Name: "hiho-1-1", CreatedAt: at(300)},
+		{Name: "hiho-1-2", CreatedAt: at(200)},
+		{Name: "hiho-1-3", CreatedAt: at(300)},
+	}
+	names := func(sessions []Session) []string {
+		var out []string
+		for _, s := range sessions {
+			out = append(out, s.Name)
+		}
+		return out
+	}
+	tests := []struct {
+		oldestFirst bool
+		want        []string
+	}{
+		{false, []string{"hiho-1-1", "hiho-1-3", "hiho-1-2", "hiho-1-0"}},
+		{true, []string{"hiho-1-0", "hiho-1-2", "hiho-1-1", "hiho-1-3"}},
+	}
+	for _, tt := range tests {
+		sorted := slices.Clone(sessions)
+		SortByCreation(sorted, tt.oldestFirst)
+		if got := names(sorted); !slices.Equal(got, tt.want) {
+			t.Errorf("SortByCreation(oldestFirst=%v) = %v, want %v", tt.oldestFirst, got, tt.want)
+		}
+	}
+}
+
+func TestListHihoFiltersCorrectly(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	manager := NewManager()
+
+	// Create a hiho session
+	session, err := manager.NewSession("true")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	defer manager.Kill(session.Name)
+
+	// ListHiho should return our session
+	hihoSessions, err := manager.ListHiho()
+	if err != nil {
+		t.Fatalf("ListHiho error: %v", err)
+	}
+
+	found := false
+	for _, s := range hihoSessions {
+		if s.Name == session.Name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("expected to find %q in ListHiho results", session.Name)
+	}
+
+	// All returned sessions should have hiho- prefix
+	for _, s := range hihoSessions {
+		if !strings.HasPrefix(s.Name, "hiho-") {
+			t.Fatalf("ListHiho returned non-hiho session: %q", s.Name)
+		}
+	}
+}
+
+func TestKillAllHiho(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	manager := NewManager()
+
+	// Create two hiho sessions
+	session1, err := manager.NewSession("true")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	session2, err := manager.NewSession("true")
+	if err != nil {
+		manager.Kill(session1.Name)
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Kill all hiho sessions
+	if err := manager.KillAllHiho(); err != nil {
+		t.Fatalf("KillAllHiho error: %v", err)
+	}
+
+	// Verify sessions are gone
+	hihoSessions, err := manager.ListHiho()
+	if err != nil {
+		t.Fatalf("ListHiho error: %v", err)
+	}
+
+	for _, s := range hihoSessions {
+		if s.Name == session1.Name || s.Name == session2.Name {
+			t.Fatalf("session %q should have been killed", s.Name)
+		}
+	}
+}
@@ -1,18 +1,19 @@
 package tmux
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
+	"time"
 )
 
 // SessionManager describes tmux operations used by the TUI.
 type SessionManager interface {
 	NewSession(cmd string) (Session, error)
+	NewSessionWithEnv(dir string, env []string, cmd string) (Session, error)
 	Capture(name string) (string, error)
 	List() ([]Session, error)
 	ListHiho() ([]Session, error)
@@ -21,59 +22,138 @@ type SessionManager interface {
 	Prev(current string) (Session, error)
 	Kill(name string) error
 	KillAllHiho() error
+	SendKeys(name, keys string) error
+	PressKeys(name string, keys ...string) error
+	Rename(name, newName string) error
+	Tag(name, tag string) error
+	Resize(name string, width, height int) error
+	Stats(name string) (ProcessStats, error)
 }
 
 // Session represents a tmux session.
 type Session struct {
 	Name string
+	// Command is the foreground process of the session's active pane.
+	Command string
+	// Running reports whether that process is still busy: the pane is
+	// alive and not back at a shell prompt.
+	Running bool
+	// Exited is set once the command the session was started with has
+	// finished; ExitCode is then its exit status.
+	Exited   bool
+	ExitCode int
+	// StartCommand is the command NewSession ran in the session, empty
+	// for sessions hiho did not start. Dir is the directory its shell
+	// started in.
+	StartCommand string
+	Dir          string
+	// Tag is the workspace the session was opened for (see Manager.Tag),
+	// empty for sessions outside any workspace.
+	Tag string
+	// CreatedAt is when tmux created the session, to the second.
+	CreatedAt time.Time
 }
 
 // Manager orchestrates tmux sessions.
 type Manager struct {
-	mu      sync.Mutex
-	pid     int
-	counter int64
+	mu           sync.Mutex
+	pid          int
+	counter      int64
+	nameTemplate string
+	scrollback   int
 }
 
-// ErrSessionNotFound indicates the requested session could not be located.
-var ErrSessionNotFound = errors.New("session not found")
-
 // NewManager constructs a Manager.
-func NewManager() *Manager {
-	return &Manager{
-		pid: os.Getpid(),
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		pid:          os.Getpid(),
+		nameTemplate: DefaultNameTemplate,
+		scrollback:   DefaultScrollbackLines,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // NewSession starts a detached tmux session and runs the provided command.
 func (m *Manager) NewSession(cmd string) (Session, error) {
-	name := m.uniqueName()
+	return m.NewSessionInDir("", cmd)
+}
+
+// NewSessionInDir is NewSession with the session's shell starting in dir.
+// An empty dir keeps hiho's own working directory.
+func (m *Manager) NewSessionInDir(dir, cmd string) (Session, error) {
+	return m.NewSessionWithEnv(dir, nil, cmd)
+}
 
-	if err := m.run("tmux", "new-session", "-d", "-s", name, "bash"); err != nil {
-		return Session{}, fmt.Errorf("create session: %w", err)
+// NewSessionWithEnv is NewSessionInDir with KEY=VALUE pairs exported in the
+// shell before cmd runs. Only cmd is recorded with the session (see
+// StartCommand) and used for its name, so the values, which may be
+// secrets, are not left readable in tmux options.
+func (m *Manager) NewSessionWithEnv(dir string, env []string, cmd string) (Session, error) {
+	args := []string{"new-session", "-d"}
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return Session{}, fmt.Errorf("working directory: %w", err)
+		}
+		if !info.IsDir() {
+			return Session{}, fmt.Errorf("working directory %s is not a directory", dir)
+		}
+		args = append(args, "-c", dir)
+	}
+	name := m.uniqueName(cmd)
+	args = append(args, "-s", name, quietShell)
+
+	if err := m.run("tmux", args...); err != nil {
+		return Session{}, sessionError(name, OpCreate, err)
+	}
+	// Keep the command with the session so List can report it later
+	if err := m.run("tmux", "set-option", "-q", "-t", name, commandOption, cmd); err != nil {
+		return Session{}, sessionError(name, OpCreate, fmt.Errorf("record command: %w", err))
 	}
-	command := fmt.Sprintf("set -o pipefail; %s", cmd)
-	if err := m.run("tmux", "send-keys", "-t", name, command, "C-m"); err != nil {
-		return Session{}, fmt.Errorf("send command: %w", err)
+	// Run the command and record its exit status for List to report
+	line := startLine(env, cmd)
+	if err := m.run("tmux", "send-keys", "-t", name, "-l", line, ";", "send-keys", "-t", name, "C-m"); err != nil {
+		return Session{}, sessionError(name, OpCreate, fmt.Errorf("send command: %w", err))
 	}
 
-	return Session{Name: name}, nil
+	if dir == "" {
+		// Without -c, tmux starts the shell in hiho's own directory
+		dir, _ = os.Getwd()
+	}
+	return Session{Name: name, StartCommand: cmd, Dir: dir, CreatedAt: time.Now().Truncate(time.Second)}, nil
 }
 
-// Capture returns the visible pane output for a session.
+// Capture returns the pane output for a session, including the configured
+// amount of scrollback.
 func (m *Manager) Capture(name string) (string, error) {
-	out, err := exec.Command("tmux", "capture-pane", "-p", "-t", name, "-S", "-200").CombinedOutput()
+	return m.CaptureLines(name, m.scrollback)
+}
+
+// CaptureLines returns the pane output for a session, starting lines rows
+// up into the scrollback. Colors and other text attributes are kept as ANSI
+// escape sequences.
+func (m *Manager) CaptureLines(name string, lines int) (string, error) {
+	start := fmt.Sprintf("-%d", lines)
+	out, err := exec.Command("tmux", "capture-pane", "-p", "-e", "-t", name, "-S", start).CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("capture output: %w (%s)", err, strings.TrimSpace(string(out)))
+		return "", sessionError(name, OpCapture, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out))))
 	}
 	return string(out), nil
 }
 
 // List returns all tmux sessions.
 func (m *Manager) List() ([]Session, error) {
-	out, err := exec.Command("tmux", "list-sessions", "-F", "#S").CombinedOutput()
+	out, err := exec.Command("tmux", "list-sessions", "-F", sessionFormat).CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("list sessions: %w (%s)", err, strings.TrimSpace(string(out)))
+		detail := strings.TrimSpace(string(out))
+		if isNoServer(detail) {
+			// The server exits with its last session, so nothing is running
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list sessions: %w (%s)", err, detail)
 	}
 	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	var sessions []Session
@@ -81,7 +161,7 @@ func (m *Manager) List() ([]Session, error) {
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
-		sessions = append(sessions, Session{Name: strings.TrimSpace(line)})
+		sessions = append(sessions, parseSession(line))
 	}
 	return sessions, nil
 }
@@ -97,7 +177,7 @@ func (m *Manager) Switch(name string) (Session, error) {
 			return session, nil
 		}
 	}
-	return Session{}, ErrSessionNotFound
+	return Session{}, &SessionError{Name: name, Op: OpSwitch, Err: ErrSessionNotFound}
 }
 
 // Next cycles to the next session after the provided name.
@@ -113,40 +193,51 @@ func (m *Manager) Prev(current string) (Session, error) {
 // Kill terminates the named session.
 func (m *Manager) Kill(name string) error {
 	if err := m.run("tmux", "kill-session", "-t", name); err != nil {
-		return fmt.Errorf("kill session: %w", err)
+		return sessionError(name, OpKill, err)
 	}
 	return nil
 }
 
-// ListHiho returns only tmux sessions with the hiho- prefix.
-func (m *Manager) ListHiho() ([]Session, error) {
-	sessions, err := m.List()
-	if err != nil {
-		return nil, err
+// SendKeys types keys into the session followed by Enter. The text is sent
+// literally, so words that happen to be tmux key names (e.g. "Up") are typed
+// rather than pressed.
+func (m *Manager) SendKeys(name, keys string) error {
+	if err := m.run("tmux", "send-keys", "-t", name, "-l", keys, ";", "send-keys", "-t", name, "C-m"); err != nil {
+		return sessionError(name, OpSendKeys, err)
 	}
-	var hihoSessions []Session
-	for _, session := range sessions {
-		if strings.HasPrefix(session.Name, "hiho-") {
-			hihoSessions = append(hihoSessions, session)
-		}
+	return nil
+}
+
+// PressKeys presses tmux key names such as C-c, Enter or Up in the session,
+// without a trailing Enter.
+func (m *Manager) PressKeys(name string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := append([]string{"send-keys", "-t", name}, keys...)
+	if err := m.run("tmux", args...); err != nil {
+		return sessionError(name, OpSendKeys, err)
 	}
-	return hihoSessions, nil
+	return nil
 }
 
-// KillAllHiho terminates all sessions with the hiho- prefix.
-func (m *Manager) KillAllHiho() error {
-	sessions, err := m.ListHiho()
-	if err != nil {
-		return err
+// Rename changes a session's name.
+func (m *Manager) Rename(name, newName string) error {
+	if err := m.run("tmux", "rename-session", "-t", name, newName); err != nil {
+		return sessionError(name, OpRename, err)
 	}
-	var errs []string
-	for _, session := range sessions {
-		if err := m.Kill(session.Name); err != nil {
-			errs = append(errs, fmt.Sprintf("%s: %v", session.Name, err))
-		}
+	return nil
+}
+
+// Resize sets the session's window to width columns by height rows, so
+// output wraps as it would in a terminal that size. The window then keeps
+// that size until resized again.
+func (m *Manager) Resize(name string, width, height int) error {
+	if width <= 0 || height <= 0 {
+		return nil
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to kill sessions: %s", strings.Join(errs, "; "))
+	if err := m.run("tmux", "resize-window", "-t", name, "-x", strconv.Itoa(width), "-y", strconv.Itoa(height)); err != nil {
+		return sessionError(name, OpResize, err)
 	}
 	return nil
 }
@@ -173,6 +264,19 @@ func (m *Manager) selectRelative(current string, delta int) (Session, error) {
 	return sessions[next], nil
 }
 
+// output runs a command and returns its stdout; on failure the error
+// includes stderr.
+func (m *Manager) output(command string, args ...string) (string, error) {
+	cmd := exec.Command(command, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return string(out), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
 func (m *Manager) run(command string, args ...string) error {
 	cmd := exec.Command(command, args...)
 	output, err := cmd.CombinedOutput()
@@ -182,7 +286,13 @@ func (m *Manager) run(command string, args ...string) error {
 	return nil
 }
 
-func (m *Manager) uniqueName() string {
-	count := atomic.AddInt64(&m.counter, 1) - 1
-	return fmt.Sprintf("hiho-%d-%d", m.pid, count)
+// isMissingSession reports whether tmux output says the target session
+// (or the whole server) no longer exists.
+func isMissingSession(output string) bool {
+	return strings.Contains(output, "can't find") || isNoServer(output)
+}
+
+// isNoServer reports whether tmux output says no server is running.
+func isNoServer(output string) bool {
+	return strings.Contains(output, "no server running") || strings.Contains(output, "error connecting to")
 }
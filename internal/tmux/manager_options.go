@@ -0,0 +1,28 @@
+package tmux
+
+// DefaultScrollbackLines is how much scrollback Capture includes unless
+// WithScrollbackLines says otherwise.
+const DefaultScrollbackLines = 200
+
+// Option configures a Manager.
+type Option func(*Manager)
+
+// WithNameTemplate sets the template used to name new sessions (see
+// renderName). An empty template keeps DefaultNameTemplate.
+func WithNameTemplate(template string) Option {
+	return func(m *Manager) {
+		if template != "" {
+			m.nameTemplate = template
+		}
+	}
+}
+
+// WithScrollbackLines sets how many lines of scrollback Capture includes.
+// Zero or negative values keep DefaultScrollbackLines.
+func WithScrollbackLines(lines int) Option {
+	return func(m *Manager) {
+		if lines > 0 {
+			m.scrollback = lines
+		}
+	}
+}
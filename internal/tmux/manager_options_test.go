@@ -0,0 +1,19 @@
+package tmux
+
+import "testing"
+
+func TestWithScrollbackLines(t *testing.T) {
+	tests := []struct {
+		lines int
+		want  int
+	}{
+		{1000, 1000},
+		{0, DefaultScrollbackLines},
+		{-5, DefaultScrollbackLines},
+	}
+	for _, tt := range tests {
+		if got := NewManager(WithScrollbackLines(tt.lines)).scrollback; got != tt.want {
+			t.Errorf("WithScrollbackLines(%d): scrollback = %d, want %d", tt.lines, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewSessionRunsCommand(t *testing.T) {
@@ -21,120 +22,244 @@ func TestNewSessionRunsCommand(t *testing.T) {
 	}
 	defer manager.Kill(session.Name)
 
-	output, err := manager.Capture(session.Name)
+	if output, ok := waitForOutput(manager, session.Name, "\nhello world"); !ok {
+		t.Fatalf("expected output to contain greeting, got: %q", output)
+	}
+}
+
+// waitForOutput captures the session until its output contains want, for
+// up to five seconds, and returns the last capture.
+func waitForOutput(manager *Manager, name, want string) (string, bool) {
+	var output string
+	for range 100 {
+		output, _ = manager.Capture(name)
+		if strings.Contains(output, want) {
+			return output, true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return output, false
+}
+
+func TestSendKeysTypesIntoSession(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	manager := NewManager()
+
+	session, err := manager.NewSession("true")
 	if err != nil {
-		t.Fatalf("failed to capture output: %v", err)
+		t.Fatalf("failed to create session: %v", err)
 	}
+	defer manager.Kill(session.Name)
 
-	if !strings.Contains(output, "hello world") {
-		t.Fatalf("expected output to contain greeting, got: %q", output)
+	if err := manager.SendKeys(session.Name, "echo sent-keys-ok"); err != nil {
+		t.Fatalf("SendKeys error: %v", err)
+	}
+
+	if output, ok := waitForOutput(manager, session.Name, "\nsent-keys-ok"); !ok {
+		t.Fatalf("expected output to contain sent keys, got: %q", output)
 	}
 }
 
-func TestSessionNamingFormat(t *testing.T) {
+func TestPressKeysInterruptsSession(t *testing.T) {
 	if _, err := exec.LookPath("tmux"); err != nil {
 		t.Skip("tmux binary not available")
 	}
 
 	manager := NewManager()
-	pid := os.Getpid()
 
-	session1, err := manager.NewSession("true")
+	session, err := manager.NewSession("sh")
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	defer manager.Kill(session1.Name)
+	defer manager.Kill(session.Name)
 
-	session2, err := manager.NewSession("true")
+	if err := manager.SendKeys(session.Name, "sleep 30"); err != nil {
+		t.Fatalf("SendKeys error: %v", err)
+	}
+	if err := manager.PressKeys(session.Name, "C-c"); err != nil {
+		t.Fatalf("PressKeys error: %v", err)
+	}
+	if err := manager.SendKeys(session.Name, "echo after-interrupt"); err != nil {
+		t.Fatalf("SendKeys error: %v", err)
+	}
+
+	var output string
+	for range 100 {
+		output, err = manager.Capture(session.Name)
+		if err != nil {
+			t.Fatalf("failed to capture output: %v", err)
+		}
+		if strings.Contains(output, "\nafter-interrupt") {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("expected the interrupted shell to run the next command, got: %q", output)
+}
+
+func TestNewSessionInDir(t *testing.T) {
+	manager := NewManager()
+	if _, err := manager.NewSessionInDir("/does/not/exist", "true"); err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+	dir := t.TempDir()
+	session, err := manager.NewSessionInDir(dir, "pwd")
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	defer manager.Kill(session2.Name)
+	defer manager.Kill(session.Name)
 
-	// Check naming format: hiho-<pid>-<counter>
-	expected1 := fmt.Sprintf("hiho-%d-0", pid)
-	expected2 := fmt.Sprintf("hiho-%d-1", pid)
+	var output string
+	for range 100 {
+		if output, err = manager.Capture(session.Name); err != nil {
+			t.Fatalf("failed to capture output: %v", err)
+		}
+		if strings.Contains(output, "\n"+dir) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("expected pwd to print %s, got %q", dir, output)
+}
 
-	if session1.Name != expected1 {
-		t.Fatalf("expected session name %q, got %q", expected1, session1.Name)
+func TestListReportsStartCommandAndDir(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
 	}
-	if session2.Name != expected2 {
-		t.Fatalf("expected session name %q, got %q", expected2, session2.Name)
+
+	manager := NewManager()
+	dir := t.TempDir()
+	created, err := manager.NewSessionInDir(dir, "sleep 5; echo done")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
 	}
+	defer manager.Kill(created.Name)
+	if created.StartCommand != "sleep 5; echo done" || created.Dir != dir {
+		t.Fatalf("NewSessionInDir returned %+v", created)
+	}
+
+	sessions, err := manager.List()
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	for _, s := range sessions {
+		if s.Name == created.Name {
+			if s.StartCommand != created.StartCommand || s.Dir != dir {
+				t.Fatalf("List reported command %q in %q, want %q in %q", s.StartCommand, s.Dir, created.StartCommand, dir)
+			}
+			if d := s.CreatedAt.Sub(created.CreatedAt); d < -time.Second || d > time.Second {
+				t.Fatalf("List reported creation at %v, want about %v", s.CreatedAt, created.CreatedAt)
+			}
+			return
+		}
+	}
+	t.Fatalf("session %s not listed", created.Name)
 }
 
-func TestListHihoFiltersCorrectly(t *testing.T) {
+func TestRenameSession(t *testing.T) {
 	if _, err := exec.LookPath("tmux"); err != nil {
 		t.Skip("tmux binary not available")
 	}
 
 	manager := NewManager()
 
-	// Create a hiho session
 	session, err := manager.NewSession("true")
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
-	defer manager.Kill(session.Name)
+	newName := fmt.Sprintf("hiho-renamed-%d", os.Getpid())
+	if err := manager.Rename(session.Name, newName); err != nil {
+		manager.Kill(session.Name)
+		t.Fatalf("Rename error: %v", err)
+	}
+	defer manager.Kill(newName)
 
-	// ListHiho should return our session
-	hihoSessions, err := manager.ListHiho()
-	if err != nil {
-		t.Fatalf("ListHiho error: %v", err)
+	if _, err := manager.Switch(newName); err != nil {
+		t.Fatalf("expected renamed session to exist: %v", err)
 	}
+	if _, err := manager.Switch(session.Name); err == nil {
+		t.Fatalf("expected old name %s to be gone", session.Name)
+	}
+}
 
-	found := false
-	for _, s := range hihoSessions {
-		if s.Name == session.Name {
-			found = true
-			break
-		}
+func TestResizeSession(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
 	}
-	if !found {
-		t.Fatalf("expected to find %q in ListHiho results", session.Name)
+
+	manager := NewManager()
+	session, err := manager.NewSession("true")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
 	}
+	defer manager.Kill(session.Name)
 
-	// All returned sessions should have hiho- prefix
-	for _, s := range hihoSessions {
-		if !strings.HasPrefix(s.Name, "hiho-") {
-			t.Fatalf("ListHiho returned non-hiho session: %q", s.Name)
-		}
+	if err := manager.Resize(session.Name, 123, 45); err != nil {
+		t.Fatalf("Resize error: %v", err)
+	}
+	size, err := manager.output("tmux", "display-message", "-p", "-t", session.Name, "#{window_width}x#{window_height}")
+	if err != nil {
+		t.Fatalf("display-message: %v", err)
+	}
+	if strings.TrimSpace(size) != "123x45" {
+		t.Fatalf("window size = %q, want 123x45", size)
 	}
 }
 
-func TestKillAllHiho(t *testing.T) {
+func TestSessionNamingFormat(t *testing.T) {
 	if _, err := exec.LookPath("tmux"); err != nil {
 		t.Skip("tmux binary not available")
 	}
 
 	manager := NewManager()
+	pid := os.Getpid()
 
-	// Create two hiho sessions
 	session1, err := manager.NewSession("true")
 	if err != nil {
 		t.Fatalf("failed to create session: %v", err)
 	}
+	defer manager.Kill(session1.Name)
 
 	session2, err := manager.NewSession("true")
 	if err != nil {
-		manager.Kill(session1.Name)
 		t.Fatalf("failed to create session: %v", err)
 	}
+	defer manager.Kill(session2.Name)
 
-	// Kill all hiho sessions
-	if err := manager.KillAllHiho(); err != nil {
-		t.Fatalf("KillAllHiho error: %v", err)
-	}
+	// Check naming format: hiho-<pid>-<counter>
+	expected1 := fmt.Sprintf("hiho-%d-0", pid)
+	expected2 := fmt.Sprintf("hiho-%d-1", pid)
 
-	// Verify sessions are gone
-	hihoSessions, err := manager.ListHiho()
-	if err != nil {
-		t.Fatalf("ListHiho error: %v", err)
+	if session1.Name != expected1 {
+		t.Fatalf("expected session name %q, got %q", expected1, session1.Name)
+	}
+	if session2.Name != expected2 {
+		t.Fatalf("expected session name %q, got %q", expected2, session2.Name)
 	}
+}
 
-	for _, s := range hihoSessions {
-		if s.Name == session1.Name || s.Name == session2.Name {
-			t.Fatalf("session %q should have been killed", s.Name)
+func TestIsMissingSession(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"can't find session: hiho-1-0", true},
+		{"can't find pane: %3", true},
+		{"no server running on /tmp/tmux-0/default", true},
+		{"error connecting to /tmp/tmux-0/default (No such file or directory)", true},
+		{"unknown option -- z", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isMissingSession(tt.output); got != tt.want {
+			t.Errorf("isMissingSession(%q) = %v, want %v", tt.output, got, tt.want)
 		}
 	}
 }
@@ -0,0 +1,107 @@
+package tmux
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultNameTemplate reproduces the classic hiho-<pid>-<n> names.
+const DefaultNameTemplate = "hiho-{pid}-{seq}"
+
+// hihoPrefix marks sessions owned by hiho; ListHiho filters on it.
+const hihoPrefix = "hiho-"
+
+// maxCmdPlaceholder caps how much of the command ends up in a name.
+const maxCmdPlaceholder = 24
+
+var (
+	unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+	repeatedDashes  = regexp.MustCompile(`-{2,}`)
+)
+
+// nameVars are the values available to a naming template.
+type nameVars struct {
+	pid  int
+	seq  int64
+	cmd  string
+	now  time.Time
+	rand string
+}
+
+// renderName fills in a naming template. Supported placeholders are {pid},
+// {seq}, {date} (YYYYMMDD), {time} (HHMMSS), {cmd} and {rand}. The result
+// always starts with the hiho- prefix.
+func renderName(template string, v nameVars) string {
+	name := strings.NewReplacer(
+		"{pid}", strconv.Itoa(v.pid),
+		"{seq}", strconv.FormatInt(v.seq, 10),
+		"{date}", v.now.Format("20060102"),
+		"{time}", v.now.Format("150405"),
+		"{cmd}", sanitizeCommand(v.cmd),
+		"{rand}", v.rand,
+	).Replace(template)
+	name = unsafeNameChars.ReplaceAllString(name, "-")
+	if !strings.HasPrefix(name, hihoPrefix) {
+		name = hihoPrefix + name
+	}
+	return name
+}
+
+// sanitizeCommand turns a shell command into a tmux-safe name fragment:
+// runs of anything but letters, digits, - and _ become a single dash.
+func sanitizeCommand(cmd string) string {
+	s := unsafeNameChars.ReplaceAllString(cmd, "-")
+	s = strings.Trim(repeatedDashes.ReplaceAllString(s, "-"), "-")
+	if len(s) > maxCmdPlaceholder {
+		s = strings.TrimRight(s[:maxCmdPlaceholder], "-")
+	}
+	if s == "" {
+		return "session"
+	}
+	return s
+}
+
+// dedupeName appends -2, -3, ... until the name is not taken.
+func dedupeName(name string, taken func(string) bool) string {
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+// uniqueName renders the configured template for cmd and makes sure no
+// existing session already uses the result.
+func (m *Manager) uniqueName(cmd string) string {
+	seq := atomic.AddInt64(&m.counter, 1) - 1
+	name := renderName(m.nameTemplate, nameVars{
+		pid:  m.pid,
+		seq:  seq,
+		cmd:  cmd,
+		now:  time.Now(),
+		rand: randomSuffix(),
+	})
+
+	existing := map[string]bool{}
+	if sessions, err := m.List(); err == nil {
+		for _, session := range sessions {
+			existing[session.Name] = true
+		}
+	}
+	return dedupeName(name, func(s string) bool { return existing[s] })
+}
+
+// randomSuffix returns four random hex characters for {rand}.
+func randomSuffix() string {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return "0000"
+	}
+	return hex.EncodeToString(b)
+}
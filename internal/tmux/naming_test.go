@@ -0,0 +1,48 @@
+package tmux
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderName(t *testing.T) {
+	vars := nameVars{
+		pid:  42,
+		seq:  3,
+		cmd:  "npm run dev && tail -f /var/log/app.log",
+		now:  time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		rand: "ab12",
+	}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{DefaultNameTemplate, "hiho-42-3"},
+		{"hiho-{date}-{time}", "hiho-20240506-070809"},
+		{"hiho-{cmd}", "hiho-npm-run-dev-tail-f-var-l"},
+		{"{cmd}-{rand}", "hiho-npm-run-dev-tail-f-var-l-ab12"},
+		{"hiho {seq}:x", "hiho-3-x"},
+	}
+	for _, tt := range tests {
+		if got := renderName(tt.template, vars); got != tt.want {
+			t.Errorf("renderName(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeCommandEmpty(t *testing.T) {
+	if got := sanitizeCommand("  && "); got != "session" {
+		t.Fatalf("expected fallback name, got %q", got)
+	}
+}
+
+func TestDedupeName(t *testing.T) {
+	taken := map[string]bool{"hiho-vim": true, "hiho-vim-2": true}
+	got := dedupeName("hiho-vim", func(s string) bool { return taken[s] })
+	if got != "hiho-vim-3" {
+		t.Fatalf("expected hiho-vim-3, got %q", got)
+	}
+	if got := dedupeName("hiho-top", func(s string) bool { return taken[s] }); got != "hiho-top" {
+		t.Fatalf("expected free name unchanged, got %q", got)
+	}
+}
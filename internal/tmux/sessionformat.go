@@ -0,0 +1,62 @@
+package tmux
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commandOption is the session option NewSession stores its command in.
+const commandOption = "@hiho_cmd"
+
+// tagOption is the session option Tag stores the workspace in.
+const tagOption = "@hiho_tag"
+
+// sessionFormat is the list-sessions format parseSession reads: name,
+// foreground command, whether the pane is dead, the recorded exit status,
+// the creation time in unix seconds, the start directory, the workspace tag
+// and the recorded command, tab separated. The command comes last so tabs
+// inside it survive.
+const sessionFormat = "#{session_name}\t#{pane_current_command}\t#{pane_dead}\t#{" + exitOption + "}\t#{session_created}\t#{session_path}\t#{" + tagOption + "}\t#{" + commandOption + "}"
+
+// shells are foreground commands that mean a session is idle at a prompt.
+var shells = map[string]bool{"bash": true, "zsh": true, "sh": true, "dash": true, "fish": true}
+
+// parseSession reads one line of sessionFormat output. A line with only a
+// name (older output) yields a Session with just the name.
+func parseSession(line string) Session {
+	fields := strings.SplitN(strings.TrimRight(line, "\r\n"), "\t", 8)
+	session := Session{Name: strings.TrimSpace(fields[0])}
+	if len(fields) >= 3 {
+		session.Command = fields[1]
+		session.Running = fields[2] != "1" && !shells[fields[1]]
+	}
+	if len(fields) >= 4 {
+		if code, err := strconv.Atoi(fields[3]); err == nil {
+			session.Exited = true
+			session.ExitCode = code
+		}
+	}
+	if len(fields) == 8 {
+		if created, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+			session.CreatedAt = time.Unix(created, 0)
+		}
+		session.Dir = fields[5]
+		session.Tag = fields[6]
+		session.StartCommand = fields[7]
+	}
+	return session
+}
+
+// Tag records the workspace a session belongs to as a session option, so
+// List reports it after hiho restarts. An empty tag clears it.
+func (m *Manager) Tag(name, tag string) error {
+	args := []string{"set-option", "-q", "-t", name, tagOption, tag}
+	if tag == "" {
+		args = []string{"set-option", "-q", "-u", "-t", name, tagOption}
+	}
+	if err := m.run("tmux", args...); err != nil {
+		return sessionError(name, OpTag, err)
+	}
+	return nil
+}
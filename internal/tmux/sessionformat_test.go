@@ -0,0 +1,71 @@
+package tmux
+
+import (
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseSession(t *testing.T) {
+	tests := []struct {
+		line string
+		want Session
+	}{
+		{"hiho-1-0\tnpm\t0", Session{Name: "hiho-1-0", Command: "npm", Running: true}},
+		{"hiho-1-1\tbash\t0", Session{Name: "hiho-1-1", Command: "bash"}},
+		{"hiho-1-2\tmake\t1", Session{Name: "hiho-1-2", Command: "make"}},
+		{"hiho-1-3\tbash\t0\t2", Session{Name: "hiho-1-3", Command: "bash", Exited: true, ExitCode: 2}},
+		{"hiho-1-4\tnpm\t0\t", Session{Name: "hiho-1-4", Command: "npm", Running: true}},
+		{"hiho-1-5\tbash\t0\t0\t1700000000\t/srv/app\tdev\tmake test\tTAB", Session{
+			Name: "hiho-1-5", Command: "bash", Exited: true, Dir: "/srv/app", Tag: "dev", StartCommand: "make test\tTAB",
+			CreatedAt: time.Unix(1700000000, 0),
+		}},
+		{"hiho-1-6\tnpm\t0\t\t\t/srv/app\t\t", Session{Name: "hiho-1-6", Command: "npm", Running: true, Dir: "/srv/app"}},
+		{"plain", Session{Name: "plain"}},
+	}
+	for _, tt := range tests {
+		if got := parseSession(tt.line); got != tt.want {
+			t.Errorf("parseSession(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestListReportsTag(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	manager := NewManager()
+	session, err := manager.NewSession("true")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	defer manager.Kill(session.Name)
+
+	tests := []struct {
+		name string
+		tag  string
+	}{
+		{"set", "dev"},
+		{"cleared", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := manager.Tag(session.Name, tt.tag); err != nil {
+				t.Fatalf("Tag error: %v", err)
+			}
+			sessions, err := manager.List()
+			if err != nil {
+				t.Fatalf("List error: %v", err)
+			}
+			i := slices.IndexFunc(sessions, func(s Session) bool { return s.Name == session.Name })
+			if i < 0 {
+				t.Fatalf("session %s not listed", session.Name)
+			}
+			if sessions[i].Tag != tt.tag {
+				t.Fatalf("List reported tag %q, want %q", sessions[i].Tag, tt.tag)
+			}
+		})
+	}
+}
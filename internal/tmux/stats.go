@@ -0,0 +1,78 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrProcessExited indicates the session's process is no longer running.
+var ErrProcessExited = errors.New("process exited")
+
+// ProcessStats is a snapshot of a session's foreground process.
+type ProcessStats struct {
+	PID     int
+	Command string
+	CPU     float64 // percent
+	RSSKB   int     // resident set size in KiB
+}
+
+// Stats reports CPU and memory for the foreground process of a session's
+// pane: the pane's shell when idle, or the command it is running.
+func (m *Manager) Stats(name string) (ProcessStats, error) {
+	out, err := m.output("tmux", "display-message", "-p", "-t", name, "#{pane_pid}")
+	if err != nil {
+		return ProcessStats{}, sessionError(name, OpStats, err)
+	}
+	panePID := strings.TrimSpace(out)
+
+	// The terminal's foreground process group leader is what the user sees running
+	pid := panePID
+	if out, err := m.output("ps", "-o", "tpgid=", "-p", panePID); err == nil {
+		if tpgid := strings.TrimSpace(out); tpgid != "" && tpgid != "-1" {
+			pid = tpgid
+		}
+	}
+
+	out, err = m.output("ps", "-o", "pid=,pcpu=,rss=,comm=", "-p", pid)
+	if err != nil && strings.TrimSpace(out) == "" {
+		return ProcessStats{}, sessionError(name, OpStats, ErrProcessExited)
+	}
+	stats, err := parsePS(out)
+	if err != nil {
+		return ProcessStats{}, sessionError(name, OpStats, err)
+	}
+	return stats, nil
+}
+
+// parsePS parses one line of `ps -o pid=,pcpu=,rss=,comm=` output. The
+// command may contain spaces, so it takes the rest of the line.
+func parsePS(out string) (ProcessStats, error) {
+	line := strings.TrimSpace(out)
+	if line == "" {
+		return ProcessStats{}, ErrProcessExited
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return ProcessStats{}, fmt.Errorf("unexpected ps output %q", line)
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("parse pid %q: %w", fields[0], err)
+	}
+	cpu, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("parse cpu %q: %w", fields[1], err)
+	}
+	rss, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return ProcessStats{}, fmt.Errorf("parse rss %q: %w", fields[2], err)
+	}
+	return ProcessStats{
+		PID:     pid,
+		CPU:     cpu,
+		RSSKB:   rss,
+		Command: strings.Join(fields[3:], " "),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package tmux
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestParsePS(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    ProcessStats
+		wantErr error
+	}{
+		{
+			name: "typical",
+			out:  "  4242  12.5 204800 node\n",
+			want: ProcessStats{PID: 4242, CPU: 12.5, RSSKB: 204800, Command: "node"},
+		},
+		{
+			name: "command with spaces",
+			out:  "17 0.0 3120 Google Chrome Helper",
+			want: ProcessStats{PID: 17, CPU: 0, RSSKB: 3120, Command: "Google Chrome Helper"},
+		},
+		{name: "exited", out: "\n", wantErr: ErrProcessExited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePS(tt.out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parsePS error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("parsePS() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parsePS("abc 1.0 10 sh"); err == nil {
+		t.Fatalf("expected error for a non-numeric pid")
+	}
+}
+
+func TestStatsReportsForegroundProcess(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux binary not available")
+	}
+
+	manager := NewManager()
+	session, err := manager.NewSession("true")
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	defer manager.Kill(session.Name)
+
+	stats, err := manager.Stats(session.Name)
+	if err != nil {
+		t.Fatalf("Stats error: %v", err)
+	}
+	if stats.PID <= 0 || stats.Command == "" {
+		t.Fatalf("expected a live process, got %+v", stats)
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultAggregateLines = 5
+
+// labelColors cycles through distinct colors so neighboring sessions in
+// aggregated output are easy to tell apart.
+var labelColors = []lipgloss.Color{"39", "170", "214", "42", "203", "141"}
+
+// sessionOutput is one session's capture in an aggregated view.
+type sessionOutput struct {
+	session string
+	output  string
+}
+
+// handleAll implements /all [n]: the last n lines of every hiho session,
+// each prefixed with a label naming the session it came from.
+func (m *Model) handleAll(arg string) error {
+	lines := defaultAggregateLines
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("usage: /all [lines]")
+		}
+		lines = n
+	}
+
+	m.refreshSessions()
+	if len(m.sessions) == 0 {
+		m.appendMessage("info", "No hiho sessions found")
+		return nil
+	}
+	outputs := make([]sessionOutput, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		output, err := m.manager.Capture(session.Name)
+		if err != nil {
+			output = "error: " + err.Error()
+		}
+		outputs = append(outputs, sessionOutput{session: session.Name, output: output})
+	}
+	m.appendMessage("all", aggregateOutput(outputs, lines))
+	return nil
+}
+
+// aggregateOutput merges the last n non-blank lines of each output,
+// prefixing every line with a colored, padded session label.
+func aggregateOutput(outputs []sessionOutput, n int) string {
+	width := 0
+	for _, o := range outputs {
+		width = max(width, len(shortSessionLabel(o.session)))
+	}
+
+	var lines []string
+	for i, o := range outputs {
+		style := lipgloss.NewStyle().Foreground(labelColors[i%len(labelColors)])
+		label := style.Render(fmt.Sprintf("[%-*s]", width, shortSessionLabel(o.session)))
+		for _, line := range lastLines(o.output, n) {
+			lines = append(lines, label+" "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shortSessionLabel drops the "hiho-<pid>-" prefix, leaving the counter.
+// Sessions not named by hiho keep their full name.
+func shortSessionLabel(name string) string {
+	rest, ok := strings.CutPrefix(name, "hiho-")
+	if !ok {
+		return name
+	}
+	if _, counter, ok := strings.Cut(rest, "-"); ok && counter != "" {
+		return counter
+	}
+	return name
+}
+
+// lastLines returns up to n trailing non-blank lines of s.
+func lastLines(s string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+	}
+	return lines[max(len(lines)-n, 0):]
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"hiho/internal/ansi"
+)
+
+func TestAggregateOutputLabelsEveryLine(t *testing.T) {
+	outputs := []sessionOutput{
+		{session: "hiho-123-0", output: "one\n\ntwo\nthree\n\n"},
+		{session: "hiho-123-10", output: "build ok\n"},
+		{session: "scratch", output: ""},
+	}
+
+	got := ansi.Strip(aggregateOutput(outputs, 2))
+	want := strings.Join([]string{
+		"[0      ] two",
+		"[0      ] three",
+		"[10     ] build ok",
+	}, "\n")
+	if got != want {
+		t.Fatalf("aggregateOutput() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAllCommandAppendsAggregatedMessage(t *testing.T) {
+	manager := &stubManager{
+		sessions: []string{"hiho-123-0", "hiho-123-1"},
+		outputByName: map[string]string{
+			"hiho-123-0": "alpha",
+			"hiho-123-1": "beta",
+		},
+	}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/all"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	last := lastMessage(model)
+	content := ansi.Strip(last.Content)
+	if last.Role != "all" || content != "[0] alpha\n[1] beta" {
+		t.Fatalf("unexpected aggregated message %q: %q", last.Role, content)
+	}
+
+	if _, err := model.handleSubmit("/all zero"); err == nil {
+		t.Fatalf("expected usage error for a non-numeric line count")
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/tmux"
+)
+
+const attachUsage = "usage: /attach [-r] [session]"
+
+// attachDoneMsg reports that the user detached from an /attach session.
+type attachDoneMsg struct {
+	session string
+	err     error
+}
+
+// handleAttach implements /attach: hiho hands the terminal to
+// "tmux attach" for the session (default: the current one) and resumes
+// once the user detaches. -r attaches read-only.
+func (m *Model) handleAttach(arg string) error {
+	readOnly := false
+	if flag, rest := nextToken(arg); flag == "-r" || flag == "--read-only" {
+		readOnly, arg = true, rest
+	}
+	if ref, rest := nextToken(arg); rest != "" || (ref != "" && ref[0] == '-') {
+		return fmt.Errorf(attachUsage)
+	}
+	ref := arg
+	if ref == "" {
+		ref = m.currentSession
+	}
+	if ref == "" {
+		return fmt.Errorf(attachUsage)
+	}
+	name, ok := m.resolveSession(ref)
+	if !ok {
+		return fmt.Errorf("%w: %s", tmux.ErrSessionNotFound, ref)
+	}
+	// A client inside tmux cannot attach without nesting
+	if os.Getenv("TMUX") != "" {
+		return fmt.Errorf("hiho is running inside tmux; use: tmux switch-client -t %s", name)
+	}
+
+	cmd := exec.Command("tmux", tmux.AttachArgs(name, readOnly)...)
+	m.queue(tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return attachDoneMsg{session: name, err: err}
+	}))
+	return nil
+}
+
+// handleAttachDone shows the session again after the user detaches.
+func (m *Model) handleAttachDone(msg attachDoneMsg) {
+	// The attached client resized the window to the terminal
+	delete(m.paneSizes, msg.session)
+	if msg.err != nil {
+		m.appendNotice("error", fmt.Sprintf("attach %s: %v", m.displayName(msg.session), msg.err))
+		return
+	}
+	m.appendMessage("info", fmt.Sprintf("Detached from %s", m.displayName(msg.session)))
+	if msg.session != m.currentSession {
+		return
+	}
+	if output, err := m.capture(msg.session, m.viewport.Width, m.viewport.Height); err == nil {
+		m.setSessionLog(output)
+	}
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAttachQueuesForegroundCommand(t *testing.T) {
+	t.Setenv("TMUX", "")
+	tests := []string{"/attach", "/attach hiho-123-1", "/attach -r", "/attach --read-only hiho-123-1"}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+			model := NewModel(manager, testConfig())
+			model.currentSession = "hiho-123-0"
+
+			if _, err := model.handleSubmit(input); err != nil {
+				t.Fatalf("attach: %v", err)
+			}
+			if len(model.queued) != 1 {
+				t.Fatalf("expected one queued command, got %d", len(model.queued))
+			}
+		})
+	}
+}
+
+func TestAttachErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		current string
+		tmuxEnv string
+		want    string
+	}{
+		{"no current session", "/attach", "", "", "usage"},
+		{"unknown session", "/attach nope", "hiho-123-0", "", "session not found"},
+		{"extra arguments", "/attach a b", "hiho-123-0", "", "usage"},
+		{"inside tmux", "/attach", "hiho-123-0", "/tmp/tmux-0/default,1,0", "switch-client"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmuxEnv)
+			model := NewModel(&stubManager{sessions: []string{"hiho-123-0"}}, testConfig())
+			model.currentSession = tt.current
+
+			_, err := model.handleSubmit(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if len(model.queued) != 0 {
+				t.Fatal("expected nothing queued")
+			}
+		})
+	}
+}
+
+func TestAttachDoneRefreshesSession(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "after detach"},
+	}
+	model := sizedModel(t, manager, 120, 40)
+	model.currentSession = "hiho-123-0"
+	model.paneSizes = map[string]paneSize{"hiho-123-0": {model.viewport.Width, model.viewport.Height}}
+
+	updated, _ := model.Update(attachDoneMsg{session: "hiho-123-0"})
+	model = updated.(Model)
+
+	if model.sessionLog != "after detach" {
+		t.Fatalf("expected a fresh capture, got %q", model.sessionLog)
+	}
+	if got := manager.resized["hiho-123-0"]; len(got) == 0 {
+		t.Fatal("expected the window fitted to the panel again after detaching")
+	}
+	if last := lastMessage(model); last.Content != "Detached from hiho-123-0" {
+		t.Fatalf("unexpected message %q", last.Content)
+	}
+}
+
+func TestAttachDoneReportsError(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	updated, _ := model.Update(attachDoneMsg{session: "hiho-123-0", err: errors.New("exit status 1")})
+	model = updated.(Model)
+
+	last := lastMessage(model)
+	if last.Role != "error" || !strings.Contains(last.Content, "exit status 1") {
+		t.Fatalf("unexpected message %+v", last)
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// handleBroadcast asks for confirmation, then types text into every hiho
+// session, like tmux's synchronize-panes.
+func (m *Model) handleBroadcast(text string) error {
+	if text == "" {
+		return fmt.Errorf("usage: /broadcast <text>")
+	}
+	m.refreshSessions()
+	if len(m.sessions) == 0 {
+		return fmt.Errorf("no hiho sessions available")
+	}
+	prompt := fmt.Sprintf("Send %q to %d sessions?", text, len(m.sessions))
+	m.requestConfirm(prompt, func(m *Model) error {
+		return m.broadcast(text)
+	})
+	return nil
+}
+
+func (m *Model) broadcast(text string) error {
+	m.refreshSessions()
+	var failures []string
+	for _, session := range m.sessions {
+		if err := m.manager.SendKeys(session.Name, text); err != nil {
+			failures = append(failures, sessionFailure(session.Name, err))
+		}
+	}
+	sent := len(m.sessions) - len(failures)
+	m.appendMessage("info", fmt.Sprintf("Broadcast sent to %d of %d sessions", sent, len(m.sessions)))
+	if len(failures) > 0 {
+		return fmt.Errorf("broadcast failed for %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBroadcastSendsToEveryHihoSession(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1", "other-session", "hiho-123-2"}}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/broadcast git pull"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if len(manager.sent) != 0 {
+		t.Fatalf("expected nothing sent before confirmation, got %v", manager.sent)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "y"})
+	model = updated.(Model)
+
+	for _, name := range []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"} {
+		if got := manager.sent[name]; len(got) != 1 || got[0] != "git pull" {
+			t.Fatalf("expected %s to receive 'git pull', got %v", name, got)
+		}
+	}
+	if _, ok := manager.sent["other-session"]; ok {
+		t.Fatalf("expected non-hiho session to be skipped")
+	}
+	last := lastMessage(model)
+	if !strings.Contains(last.Content, "3 of 3") {
+		t.Fatalf("expected delivery count in message, got %q", last.Content)
+	}
+}
+
+func TestBroadcastReportsFailures(t *testing.T) {
+	manager := &stubManager{
+		sessions: []string{"hiho-123-0", "hiho-123-1"},
+		sendErr:  map[string]error{"hiho-123-1": errors.New("pane is dead")},
+	}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/broadcast make"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "y"})
+	model = updated.(Model)
+
+	last := lastMessage(model)
+	if last.Role != "error" || !strings.Contains(last.Content, "hiho-123-1") {
+		t.Fatalf("expected failure to name hiho-123-1, got %+v", last)
+	}
+	if got := manager.sent["hiho-123-0"]; len(got) != 1 {
+		t.Fatalf("expected healthy session to still receive input, got %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// changeSet records what handling a message or command touched, so Update
+// can re-render the viewport once instead of after every step.
+type changeSet uint8
+
+const (
+	changedMessages changeSet = 1 << iota // conversation grew
+	changedSessions                       // session list differs
+	changedCapture                        // current session or its output changed
+	changedView                           // tab or display mode switched
+)
+
+// has reports whether every flag in c2 is set.
+func (c changeSet) has(c2 changeSet) bool {
+	return c&c2 == c2
+}
+
+// needsRedraw reports whether the viewport content may be stale. The
+// session list is drawn by the sidebar, which renders from scratch.
+func (c changeSet) needsRedraw() bool {
+	return c&(changedMessages|changedCapture|changedView) != 0
+}
+
+func (m *Model) markChanged(c changeSet) {
+	m.changes |= c
+}
+
+// setTab switches the main panel tab.
+func (m *Model) setTab(t tabType) {
+	if m.activeTab != t {
+		m.activeTab = t
+		m.markChanged(changedView)
+	}
+}
+
+// queue schedules cmd to run once the current update returns, for handlers
+// that have no tea.Cmd of their own to return.
+func (m *Model) queue(cmd tea.Cmd) {
+	m.queued = append(m.queued, cmd)
+}
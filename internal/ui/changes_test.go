@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestHandleSubmitReportsChangeSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   changeSet
+		redraw bool
+	}{
+		{"note", "hello", changedMessages, true},
+		{"help", "/help", changedMessages, true},
+		{"view", "/view tmux", changedView, true},
+		{"view unchanged", "/view conversation", 0, false},
+		{"new", "/new bash", changedMessages | changedSessions | changedCapture | changedView, true},
+		{"list", "/list", changedMessages | changedSessions, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-123-0"}}
+			model := NewModel(manager, testConfig())
+
+			got, err := model.handleSubmit(tt.input)
+			if err != nil {
+				t.Fatalf("handleSubmit error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("handleSubmit(%q) changes = %04b, want %04b", tt.input, got, tt.want)
+			}
+			if got.needsRedraw() != tt.redraw {
+				t.Fatalf("needsRedraw() = %v, want %v", got.needsRedraw(), tt.redraw)
+			}
+		})
+	}
+}
+
+func TestUpdateRefreshesViewportAfterSubmit(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model = updated.(Model)
+
+	for _, key := range []string{"h", "i", "enter"} {
+		updated, _ = model.Update(tea.KeyMsg{Type: key})
+		model = updated.(Model)
+	}
+
+	if !strings.Contains(ansi.Strip(model.viewport.View()), "user: hi") {
+		t.Fatalf("expected viewport to show the new note, got:\n%s", model.viewport.View())
+	}
+	if model.changes != changedMessages {
+		t.Fatalf("expected only the conversation to change, got %04b", model.changes)
+	}
+}
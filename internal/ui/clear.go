@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"hiho/internal/history"
+)
+
+// handleClear empties the current session's conversation, or the global
+// one when no session is active, and the notices, so the welcome text
+// shows again. "/clear all" empties every conversation, including the
+// saved history, and also leaves the current session view; tmux sessions
+// keep running either way.
+func (m *Model) handleClear(arg string) error {
+	switch arg {
+	case "":
+		delete(m.messages, m.currentSession)
+		m.notices = nil
+	case "all":
+		m.messages = nil
+		m.notices = nil
+		m.currentSession = ""
+		m.sessionLog = ""
+		m.markChanged(changedCapture)
+	default:
+		return fmt.Errorf("usage: /clear [all]")
+	}
+	m.markChanged(changedMessages)
+	if err := m.rewriteHistory(); err != nil {
+		return fmt.Errorf("clear saved history: %w", err)
+	}
+	return nil
+}
+
+// rewriteHistory replaces the saved history with the conversations still
+// held, one session after another.
+func (m *Model) rewriteHistory() error {
+	if m.historyStore == nil {
+		return nil
+	}
+	if err := m.historyStore.Clear(); err != nil {
+		return err
+	}
+	for _, session := range slices.Sorted(maps.Keys(m.messages)) {
+		for _, message := range m.messages[session] {
+			if message.unsaved {
+				continue
+			}
+			entry := history.Entry{Session: session, Role: message.Role, Content: message.Content}
+			if err := m.historyStore.Append(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"hiho/internal/history"
+)
+
+func TestClearEmptiesConversation(t *testing.T) {
+	tests := []struct {
+		arg         string
+		wantSession string
+	}{
+		{"", "hiho-123-0"},
+		{"all", ""},
+	}
+	for _, tt := range tests {
+		t.Run("/clear "+tt.arg, func(t *testing.T) {
+			store := &stubHistory{}
+			manager := &stubManager{sessions: []string{"hiho-123-0"}}
+			model := NewModel(manager, testConfig(), WithHistory(store))
+			model.currentSession = "hiho-123-0"
+			model.sessionLog = "output"
+			model.appendMessage("user", "note")
+
+			if _, err := model.handleSubmit(strings.TrimSpace("/clear " + tt.arg)); err != nil {
+				t.Fatalf("clear: %v", err)
+			}
+			if len(model.conversation()) != 0 {
+				t.Fatalf("expected no messages, got %v", model.conversation())
+			}
+			if !store.cleared {
+				t.Fatal("expected saved history cleared")
+			}
+			if model.currentSession != tt.wantSession {
+				t.Fatalf("currentSession = %q, want %q", model.currentSession, tt.wantSession)
+			}
+			if len(manager.killed) != 0 {
+				t.Fatalf("expected sessions left running, got %v killed", manager.killed)
+			}
+			if body := model.renderBody(); !strings.HasPrefix(body, "Welcome to hiho!") {
+				t.Fatalf("expected welcome text, got %q", body)
+			}
+		})
+	}
+}
+
+func TestClearRejectsUnknownArgument(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.appendMessage("user", "note")
+	if _, err := model.handleSubmit("/clear everything"); err == nil {
+		t.Fatal("expected usage error")
+	}
+	if len(model.conversation()) == 0 {
+		t.Fatal("expected messages kept after a usage error")
+	}
+}
+
+func TestClearKeepsOtherConversations(t *testing.T) {
+	store := &stubHistory{}
+	model := NewModel(&stubManager{sessions: []string{"hiho-123-0"}}, testConfig(), WithHistory(store))
+	model.appendMessage("user", "global")
+	model.currentSession = "hiho-123-0"
+	model.appendMessage("user", "for zero")
+
+	if _, err := model.handleSubmit("/clear"); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if len(model.conversation()) != 0 {
+		t.Fatalf("expected the session's conversation cleared, got %v", model.conversation())
+	}
+	want := []history.Entry{{Role: "user", Content: "global"}}
+	if !reflect.DeepEqual(store.appended, want) {
+		t.Fatalf("expected saved history rewritten to %v, got %v", want, store.appended)
+	}
+	model.currentSession = ""
+	if got := model.conversation(); len(got) != 1 || got[0].Content != "global" {
+		t.Fatalf("expected the global conversation kept, got %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"hiho/internal/events"
+)
+
+// handleCloseAll closes every hiho session, asking first unless
+// confirm_closeall is off or there is nothing to close.
+func (m *Model) handleCloseAll() error {
+	closing, err := m.manager.ListHiho()
+	if err != nil {
+		return err
+	}
+	if !m.config.ConfirmCloseAll || len(closing) == 0 {
+		return m.closeAll()
+	}
+	prompt := fmt.Sprintf("Close %d sessions?", len(closing))
+	if len(closing) == 1 {
+		prompt = "Close 1 session?"
+	}
+	m.requestConfirm(prompt, func(m *Model) error {
+		return m.closeAll()
+	})
+	return nil
+}
+
+func (m *Model) closeAll() error {
+	closing, err := m.manager.ListHiho()
+	if err != nil {
+		return err
+	}
+	if err := m.manager.KillAllHiho(); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(closing))
+	for _, session := range closing {
+		m.emit(events.SessionKilled, session.Name)
+		names = append(names, session.Name)
+	}
+	m.dropConversations(names...)
+	if strings.HasPrefix(m.currentSession, "hiho-") {
+		m.currentSession = ""
+		m.sessionLog = ""
+		m.markChanged(changedCapture)
+	}
+	m.refreshSessions()
+	m.appendMessage("info", "All hiho sessions closed")
+	return nil
+}
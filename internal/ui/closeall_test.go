@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCloseAllAsksFirst(t *testing.T) {
+	tests := []struct {
+		name       string
+		answer     string
+		wantKilled int
+	}{
+		{"yes closes", "y", 2},
+		{"no keeps sessions", "n", 0},
+		{"other keys are ignored", "x", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-1-0", "hiho-1-1", "other"}}
+			model := NewModel(manager, testConfig())
+
+			if _, err := model.handleSubmit("/closeall"); err != nil {
+				t.Fatalf("closeall: %v", err)
+			}
+			if len(manager.killed) != 0 {
+				t.Fatalf("expected nothing killed before answering, got %v", manager.killed)
+			}
+			last := lastMessage(model)
+			if last.Role != "confirm" || last.Content != "Close 2 sessions? (y/n)" {
+				t.Fatalf("unexpected prompt %+v", last)
+			}
+
+			model.handleConfirmKey(tt.answer)
+			if len(manager.killed) != tt.wantKilled {
+				t.Fatalf("expected %d killed, got %v", tt.wantKilled, manager.killed)
+			}
+		})
+	}
+}
+
+func TestCloseAllWithoutConfirmation(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-1-0"}}
+	cfg := testConfig()
+	cfg.ConfirmCloseAll = false
+	model := NewModel(manager, cfg)
+
+	if _, err := model.handleSubmit("/closeall"); err != nil {
+		t.Fatalf("closeall: %v", err)
+	}
+	if model.pendingConfirm != nil {
+		t.Fatal("expected no confirmation prompt")
+	}
+	if len(manager.killed) != 1 {
+		t.Fatalf("expected 1 session killed, got %v", manager.killed)
+	}
+}
+
+func TestCloseAllStopsWhenListingFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		confirm bool
+	}{
+		{"with confirmation", true},
+		{"without confirmation", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-1-0"}, listErr: errors.New("no server")}
+			cfg := testConfig()
+			cfg.ConfirmCloseAll = tt.confirm
+			model := NewModel(manager, cfg)
+
+			if _, err := model.handleSubmit("/closeall"); err == nil {
+				t.Fatal("expected the listing error")
+			}
+			if model.pendingConfirm != nil || len(manager.killed) != 0 {
+				t.Fatalf("expected nothing asked or killed, prompt %v killed %v", model.pendingConfirm != nil, manager.killed)
+			}
+		})
+	}
+}
+
+func TestCloseAllKillsHihoSessions(t *testing.T) {
+	manager := &stubManager{
+		sessions: []string{"hiho-123-0", "hiho-123-1", "other-session"},
+	}
+
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+
+	if _, err := model.handleSubmit("/closeall"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	model.handleConfirmKey("y")
+
+	// Should have killed the hiho sessions
+	if len(manager.killed) != 2 {
+		t.Fatalf("expected 2 sessions killed, got %d", len(manager.killed))
+	}
+	// Current session should be cleared
+	if model.currentSession != "" {
+		t.Fatalf("expected currentSession to be empty, got %q", model.currentSession)
+	}
+	// Other session should remain
+	if len(manager.sessions) != 1 || manager.sessions[0] != "other-session" {
+		t.Fatalf("expected only other-session to remain, got %v", manager.sessions)
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// parseCommand splits a slash command into its name and argument. The
+// argument keeps its inner spacing but is trimmed at both ends; any
+// whitespace (not only a space) ends the command name.
+func parseCommand(input string) (cmd, arg string, err error) {
+	input = strings.TrimSpace(input)
+	rest, ok := strings.CutPrefix(input, "/")
+	if !ok {
+		return "", "", fmt.Errorf("not a command: %q", input)
+	}
+	cmd = rest
+	if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+		cmd, arg = rest[:i], rest[i+1:]
+	}
+	if cmd == "" {
+		return "", "", fmt.Errorf("missing command name after /")
+	}
+	if strings.ContainsRune(cmd, '/') {
+		return "", "", fmt.Errorf("malformed command: %q", input)
+	}
+	return cmd, strings.TrimSpace(arg), nil
+}
+
+// handleSubmit runs a slash command or records a note, and reports what it
+// changed so the caller can refresh once.
+func (m *Model) handleSubmit(input string) (changeSet, error) {
+	before := m.changes
+	m.changes = 0
+	m.inputHistory.record(input)
+	var err error
+	if strings.HasPrefix(input, "/") {
+		err = m.handleCommand(input)
+	} else {
+		m.appendMessage("user", input)
+	}
+	changes := m.changes
+	m.changes |= before
+	return changes, err
+}
+
+func (m *Model) handleCommand(input string) error {
+	command, arg, err := parseCommand(input)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "help":
+		m.appendNotice("info", helpText(m.config.KeyBindings))
+	case "new":
+		return m.handleNew(arg)
+	case "next":
+		session, err := m.manager.Next(m.currentSession)
+		if err != nil {
+			return err
+		}
+		m.currentSession = session.Name
+		m.refreshSessions()
+		return m.captureCurrentSession()
+	case "prev":
+		session, err := m.manager.Prev(m.currentSession)
+		if err != nil {
+			return err
+		}
+		m.currentSession = session.Name
+		m.refreshSessions()
+		return m.captureCurrentSession()
+	case "switch":
+		if arg == "" {
+			if m.activeTab == tabTmux {
+				return m.navigateSession(1)
+			}
+			return fmt.Errorf("usage: /switch <session> (or use without arg in Tmux tab to cycle)")
+		}
+		session, err := m.manager.Switch(arg)
+		if err != nil {
+			return err
+		}
+		m.currentSession = session.Name
+		m.refreshSessions()
+		return m.captureCurrentSession()
+	case "list":
+		m.refreshSessions()
+		if len(m.sessions) == 0 {
+			m.appendNotice("info", "No hiho sessions found")
+			return nil
+		}
+		m.appendNotice("sessions", formatSessionList(m.sessions))
+	case "sessions":
+		sessions, err := m.manager.List()
+		if err != nil {
+			return err
+		}
+		if len(sessions) == 0 {
+			m.appendNotice("info", "No tmux sessions found")
+			return nil
+		}
+		m.appendNotice("sessions", formatSessionList(sessions))
+	case "find":
+		return m.handleFind(arg)
+	case "copy":
+		return m.handleCopy()
+	case "attach":
+		return m.handleAttach(arg)
+	case "reload":
+		return m.handleReload()
+	case "clear":
+		return m.handleClear(arg)
+	case "dup":
+		return m.handleDup(arg)
+	case "rename":
+		return m.handleRename(arg)
+	case "kill":
+		return m.handleKill(arg)
+	case "closeall":
+		return m.handleCloseAll()
+	case "open":
+		return m.handleOpen(arg)
+	case "close":
+		return m.handleClose(arg)
+	case "split":
+		return m.handleSplit(arg)
+	case "tailn":
+		return m.handleTailn(arg)
+	case "paste":
+		return m.handlePaste(arg)
+	case "secret":
+		return m.handleSecret(arg)
+	case "send":
+		return m.handleSend(arg)
+	case "broadcast":
+		return m.handleBroadcast(arg)
+	case "all":
+		return m.handleAll(arg)
+	case "pause":
+		m.setPaused(true)
+	case "resume":
+		m.setPaused(false)
+	case "refresh":
+		return m.handleRefresh()
+	case "stats":
+		return m.handleStats()
+	case "profile":
+		return m.handleProfile(arg)
+	case "screenshot":
+		return m.handleScreenshot(arg)
+	case "debug":
+		return m.handleDebug(arg)
+	case "diff":
+		return m.handleDiff(arg)
+	case "tab":
+		return m.handleTab(arg)
+	case "view":
+		switch arg {
+		case "session", "tmux":
+			m.setTab(tabTmux)
+		default:
+			m.setTab(tabConversation)
+		}
+	default:
+		return fmt.Errorf("unknown command: %s", command)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package ui
+
+import "testing"
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		input   string
+		cmd     string
+		arg     string
+		wantErr bool
+	}{
+		{input: "/help", cmd: "help"},
+		{input: "  /list  ", cmd: "list"},
+		{input: "/new npm  run   dev", cmd: "new", arg: "npm  run   dev"},
+		{input: "/broadcast\techo hi ", cmd: "broadcast", arg: "echo hi"},
+		{input: "/switch   hiho-1-0", cmd: "switch", arg: "hiho-1-0"},
+		{input: "help", wantErr: true},
+		{input: "/", wantErr: true},
+		{input: "/ new ls", wantErr: true},
+		{input: "//new", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		cmd, arg, err := parseCommand(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCommand(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if cmd != tt.cmd || arg != tt.arg {
+			t.Errorf("parseCommand(%q) = %q, %q; want %q, %q", tt.input, cmd, arg, tt.cmd, tt.arg)
+		}
+	}
+}
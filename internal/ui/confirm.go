@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// confirmation is a pending yes/no prompt that intercepts the next keypress.
+// The action receives the live model because Update works on copies.
+type confirmation struct {
+	prompt string
+	action func(m *Model) error
+}
+
+// requestConfirm shows the prompt and defers action until the user answers.
+func (m *Model) requestConfirm(prompt string, action func(m *Model) error) {
+	m.pendingConfirm = &confirmation{prompt: prompt, action: action}
+	m.appendMessage("confirm", prompt+" (y/n)")
+}
+
+// handleConfirmKey resolves the pending confirmation. Keys other than
+// yes/no answers are ignored so a stray keypress cannot run the action.
+func (m *Model) handleConfirmKey(key string) {
+	pending := m.pendingConfirm
+	switch key {
+	case "y", "Y":
+		m.pendingConfirm = nil
+		if err := pending.action(m); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+	case "n", "N", "esc":
+		m.pendingConfirm = nil
+		m.appendMessage("info", "Cancelled")
+	}
+}
+
+// needsConfirm reports whether a /new command should ask before running.
+// never_confirm wins over always_confirm and confirm_commands.
+func (m *Model) needsConfirm(command string) bool {
+	if matchesAny(m.config.NeverConfirm, command) {
+		return false
+	}
+	return matchesAny(m.config.AlwaysConfirm, command) || matchesAny(m.config.ConfirmCommands, command)
+}
+
+// matchesAny reports whether s matches any of the glob patterns, where
+// '*' matches any run of characters and '?' matches a single character.
+func matchesAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if globRegexp(pattern).MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func globRegexp(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(strings.TrimSpace(pattern))
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
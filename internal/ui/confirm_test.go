@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		input    string
+		want     bool
+	}{
+		{[]string{"rm *"}, "rm -rf /tmp/x", true},
+		{[]string{"rm *"}, "echo rm -rf", false},
+		{[]string{"git reset *"}, "git reset --hard", true},
+		{[]string{"make ?"}, "make a", true},
+		{[]string{"make ?"}, "make all", false},
+		{[]string{"a.b"}, "axb", false},
+		{nil, "rm -rf /", false},
+	}
+	for _, tt := range tests {
+		if got := matchesAny(tt.patterns, tt.input); got != tt.want {
+			t.Errorf("matchesAny(%v, %q) = %v, want %v", tt.patterns, tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNewMatchingConfirmPatternPrompts(t *testing.T) {
+	manager := &stubManager{}
+	cfg := testConfig()
+	cfg.ConfirmCommands = []string{"rm *"}
+	model := NewModel(manager, cfg)
+
+	if _, err := model.handleSubmit("/new rm -rf build"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if len(manager.created) != 0 {
+		t.Fatalf("expected no session before confirmation, got %v", manager.created)
+	}
+	if model.pendingConfirm == nil {
+		t.Fatalf("expected a pending confirmation")
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "y"})
+	model = updated.(Model)
+	if model.pendingConfirm != nil {
+		t.Fatalf("expected confirmation to be resolved")
+	}
+	if len(manager.created) != 1 || manager.created[0] != "rm -rf build" {
+		t.Fatalf("expected confirmed command to run, got %v", manager.created)
+	}
+}
+
+func TestNewConfirmDeclinedDoesNotRun(t *testing.T) {
+	manager := &stubManager{}
+	cfg := testConfig()
+	cfg.ConfirmCommands = []string{"rm *"}
+	model := NewModel(manager, cfg)
+
+	if _, err := model.handleSubmit("/new rm -rf build"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	updated, _ := model.Update(tea.KeyMsg{Type: "n"})
+	model = updated.(Model)
+	if model.pendingConfirm != nil || len(manager.created) != 0 {
+		t.Fatalf("expected declined command not to run, got %v", manager.created)
+	}
+}
+
+func TestNewNonMatchingCommandRunsDirectly(t *testing.T) {
+	manager := &stubManager{}
+	cfg := testConfig()
+	cfg.ConfirmCommands = []string{"rm *"}
+	model := NewModel(manager, cfg)
+
+	if _, err := model.handleSubmit("/new make test"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.pendingConfirm != nil {
+		t.Fatalf("expected no confirmation for a non-matching command")
+	}
+	if len(manager.created) != 1 {
+		t.Fatalf("expected session to be created directly, got %v", manager.created)
+	}
+}
+
+func TestNeverConfirmTakesPrecedence(t *testing.T) {
+	cfg := testConfig()
+	cfg.ConfirmCommands = []string{"rm *"}
+	cfg.AlwaysConfirm = []string{"git push*", "rm -rf build"}
+	cfg.NeverConfirm = []string{"rm -rf build", "git push --dry-run*"}
+	model := NewModel(&stubManager{}, cfg)
+
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"rm -rf build", false},       // in all three lists
+		{"rm -rf /", true},            // confirm_commands only
+		{"git push origin", true},     // always_confirm only
+		{"git push --dry-run", false}, // always and never
+		{"ls", false},
+	}
+	for _, tt := range tests {
+		if got := model.needsConfirm(tt.command); got != tt.want {
+			t.Errorf("needsConfirm(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
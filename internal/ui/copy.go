@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"hiho/internal/ansi"
+)
+
+// handleCopy copies the current session's capture, without colors, to
+// the clipboard.
+func (m *Model) handleCopy() error {
+	if m.currentSession == "" {
+		return fmt.Errorf("no current session to copy")
+	}
+	text := strings.TrimRight(ansi.Strip(m.sessionLog), "\n")
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("nothing to copy: %s has no output", m.displayName(m.currentSession))
+	}
+	if err := m.copyText(text); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCopyCurrentSessionOutput(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	clip := &stubClipboard{}
+	model.clipboard = clip
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "\x1b[32mok\x1b[0m\nline two\n\n"
+
+	if _, err := model.handleSubmit("/copy"); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if len(clip.copied) != 1 || clip.copied[0] != "ok\nline two" {
+		t.Fatalf("unexpected clipboard contents %q", clip.copied)
+	}
+	if last := lastMessage(model); last.Content != "Copied 11 characters" {
+		t.Fatalf("unexpected message %q", last.Content)
+	}
+}
+
+func TestCopyReportsFallbackFile(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.clipboard = &stubClipboard{fallback: "/tmp/hiho-clipboard-1.txt"}
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "done"
+
+	if _, err := model.handleSubmit("/copy"); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	last := lastMessage(model)
+	if !strings.Contains(last.Content, "No clipboard utility found") || !strings.Contains(last.Content, "/tmp/hiho-clipboard-1.txt") {
+		t.Fatalf("expected fallback path in message, got %q", last.Content)
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		log     string
+		want    string
+	}{
+		{"no session", "", "", "no current session"},
+		{"empty output", "hiho-123-0", " \n", "nothing to copy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewModel(&stubManager{}, testConfig())
+			clip := &stubClipboard{}
+			model.clipboard = clip
+			model.currentSession = tt.session
+			model.sessionLog = tt.log
+
+			_, err := model.handleSubmit("/copy")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+			if len(clip.copied) != 0 {
+				t.Fatalf("expected nothing copied, got %q", clip.copied)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// handleDebug toggles developer aids. It is intentionally left out of /help.
+func (m *Model) handleDebug(arg string) error {
+	switch arg {
+	case "escapes":
+		m.showEscapes = !m.showEscapes
+		m.markChanged(changedView)
+		state := "off"
+		if m.showEscapes {
+			state = "on"
+		}
+		m.appendMessage("info", fmt.Sprintf("Escape sequence display %s", state))
+		return nil
+	default:
+		return fmt.Errorf("usage: /debug escapes")
+	}
+}
+
+// showControlBytes renders control bytes in caret notation (ESC becomes ^[)
+// so escape sequences in captured output can be inspected. Newlines and tabs
+// are kept so the layout stays readable.
+func showControlBytes(s string) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\n' || c == '\t':
+			builder.WriteByte(c)
+		case c < 0x20:
+			builder.WriteByte('^')
+			builder.WriteByte(c + '@')
+		case c == 0x7f:
+			builder.WriteString("^?")
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
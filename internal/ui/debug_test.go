@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShowControlBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"escape sequence", "\033[31mred\033[0m", "^[[31mred^[[0m"},
+		{"keeps newlines and tabs", "a\tb\nc", "a\tb\nc"},
+		{"carriage return and bell", "x\r\a", "x^M^G"},
+		{"delete", "\x7f", "^?"},
+		{"utf-8 untouched", "héllo", "héllo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := showControlBytes(tt.input); got != tt.want {
+				t.Fatalf("showControlBytes(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugEscapesTogglesTmuxRendering(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	model.activeTab = tabTmux
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "\033[32mok\033[0m"
+
+	if _, err := model.handleSubmit("/debug escapes"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if !model.showEscapes {
+		t.Fatalf("expected showEscapes to be enabled")
+	}
+	if body := model.renderBody(); !strings.Contains(body, "^[[32mok^[[0m") {
+		t.Fatalf("expected visible escapes in body, got %q", body)
+	}
+
+	if _, err := model.handleSubmit("/debug escapes"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if body := model.renderBody(); strings.Contains(body, "^[") {
+		t.Fatalf("expected raw escapes after toggling off, got %q", body)
+	}
+}
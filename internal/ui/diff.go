@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"hiho/internal/textdiff"
+)
+
+// diffView keeps the capture before the latest one so /diff can highlight
+// what changed between refreshes.
+type diffView struct {
+	enabled  bool
+	session  string // session the previous capture belongs to
+	previous string
+}
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// handleDiff implements /diff [on|off].
+func (m *Model) handleDiff(arg string) error {
+	switch arg {
+	case "", "on":
+		m.diff.enabled = true
+		m.markChanged(changedView)
+		m.appendMessage("info", "Diff view on: changes since the previous capture are highlighted in the Tmux tab")
+	case "off":
+		m.diff.enabled = false
+		m.markChanged(changedView)
+		m.appendMessage("info", "Diff view off")
+	default:
+		return fmt.Errorf("usage: /diff [on|off]")
+	}
+	return nil
+}
+
+// renderDiff marks each line of the current capture against the previous
+// one: "+" added (green), "-" removed (red), "~" changed (yellow).
+func (m Model) renderDiff() string {
+	lines := textdiff.Lines(splitCapture(m.diff.previous), splitCapture(m.sessionLog))
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch line.Op {
+		case textdiff.Added:
+			out = append(out, diffAddedStyle.Render("+ "+line.Text))
+		case textdiff.Removed:
+			out = append(out, diffRemovedStyle.Render("- "+line.Text))
+		case textdiff.Changed:
+			out = append(out, diffChangedStyle.Render("~ "+line.Text))
+		default:
+			out = append(out, "  "+line.Text)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func splitCapture(s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
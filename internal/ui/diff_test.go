@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"hiho/internal/ansi"
+)
+
+func TestDiffHighlightsChangesBetweenCaptures(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "$ make\nstep 1\n"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.activeTab = tabTmux
+	if _, err := model.handleSubmit("/diff"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture error: %v", err)
+	}
+
+	manager.outputByName["hiho-123-0"] = "$ make\nstep 2\ndone\n"
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture error: %v", err)
+	}
+
+	body := ansi.Strip(model.renderBody())
+	for _, want := range []string{"  $ make", "~ step 2", "+ done"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected diff body to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	if _, err := model.handleSubmit("/diff off"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if body := model.renderBody(); strings.Contains(body, "+ done") {
+		t.Fatalf("expected plain output after /diff off, got:\n%s", body)
+	}
+}
+
+func TestSwitchingSessionsResetsDiffBaseline(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.setSessionLog("a")
+	model.setSessionLog("b")
+	if model.diff.previous != "a" {
+		t.Fatalf("expected previous capture a, got %q", model.diff.previous)
+	}
+
+	model.currentSession = "hiho-123-1"
+	model.setSessionLog("other")
+	if model.diff.previous != "other" {
+		t.Fatalf("expected new session to diff against itself, got %q", model.diff.previous)
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+
+	"hiho/internal/tmux"
+)
+
+// handleDup implements /dup: it starts a new session running the command
+// the current (or named) session was started with, in the same directory
+// and sidebar group, with the same variables if this hiho started it. Like
+// /new, it asks first when the command matches a confirm pattern, and the
+// new session becomes current.
+func (m *Model) handleDup(ref string) error {
+	if ref == "" {
+		ref = m.currentSession
+	}
+	if ref == "" {
+		return fmt.Errorf("usage: /dup [session]")
+	}
+	name, ok := m.resolveSession(ref)
+	if !ok {
+		return fmt.Errorf("%w: %s", tmux.ErrSessionNotFound, ref)
+	}
+	session, _ := m.sessionByName(name)
+	if session.StartCommand == "" {
+		return fmt.Errorf("%s was not started by hiho; there is no command to repeat", m.displayName(name))
+	}
+	opts := newOptions{command: session.StartCommand, env: m.sessionEnv[name], dir: session.Dir, tag: m.sessionTags[name]}
+	if m.needsConfirm(opts.command) {
+		m.requestConfirm(fmt.Sprintf("Run %q?", opts.command), func(m *Model) error {
+			return m.createSession(opts)
+		})
+		return nil
+	}
+	return m.createSession(opts)
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+func TestDupRunsCommandAgain(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	manager.dirs = map[string]string{"hiho-123-0": "/srv/app"}
+	if _, err := model.handleSubmit("/new npm test"); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	model.tagSession("hiho-123-0", "web")
+	model.activeTab = tabConversation
+
+	if _, err := model.handleSubmit("/dup"); err != nil {
+		t.Fatalf("dup: %v", err)
+	}
+	if !slices.Equal(manager.created, []string{"npm test", "npm test"}) {
+		t.Fatalf("expected the command run twice, got %v", manager.created)
+	}
+	if model.currentSession != "hiho-123-1" {
+		t.Fatalf("expected the copy to become current, got %q", model.currentSession)
+	}
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected the Tmux tab, got %v", model.activeTab)
+	}
+	if got := manager.dirs["hiho-123-1"]; got != "/srv/app" {
+		t.Fatalf("expected the copy to start in /srv/app, got %q", got)
+	}
+	if got := model.sessionTags["hiho-123-1"]; got != "web" {
+		t.Fatalf("expected the copy in the web group, got %q", got)
+	}
+}
+
+func TestDupReplaysEnvironment(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/new API_KEY=secret npm start"); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if _, err := model.handleSubmit("/dup"); err != nil {
+		t.Fatalf("dup: %v", err)
+	}
+	if !slices.Equal(manager.created, []string{"npm start", "npm start"}) {
+		t.Fatalf("expected the bare command run twice, got %v", manager.created)
+	}
+	if got := manager.envs["hiho-123-1"]; !slices.Equal(got, []string{"API_KEY=secret"}) {
+		t.Fatalf("expected the copy started with the same env, got %v", got)
+	}
+}
+
+func TestDupNamedSessionAsksFirstWhenConfirmed(t *testing.T) {
+	cfg := testConfig()
+	cfg.ConfirmCommands = []string{"rm *"}
+	manager := &stubManager{}
+	model := NewModel(manager, cfg)
+	if _, err := model.handleSubmit("/new rm -rf build"); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	model.handleConfirmKey("y")
+
+	if _, err := model.handleSubmit("/dup hiho-123-0"); err != nil {
+		t.Fatalf("dup: %v", err)
+	}
+	if len(manager.created) != 1 || model.pendingConfirm == nil {
+		t.Fatalf("expected a confirmation before running again, created %v", manager.created)
+	}
+	model.handleConfirmKey("y")
+	if len(manager.created) != 2 {
+		t.Fatalf("expected the command run again after yes, created %v", manager.created)
+	}
+}
+
+func TestDupErrors(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0"}}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/dup"); err == nil {
+		t.Fatal("expected a usage error without a current session")
+	}
+	if _, err := model.handleSubmit("/dup nope"); !errors.Is(err, tmux.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := model.handleSubmit("/dup hiho-123-0"); err == nil {
+		t.Fatal("expected an error for a session without a recorded command")
+	}
+	if len(manager.created) != 0 {
+		t.Fatalf("expected nothing created, got %v", manager.created)
+	}
+}
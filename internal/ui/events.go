@@ -0,0 +1,26 @@
+package ui
+
+import "hiho/internal/events"
+
+// EventEmitter receives session lifecycle events for external monitoring.
+type EventEmitter interface {
+	Emit(e events.Event)
+}
+
+// emit reports a session event when an emitter is configured; without one
+// it does nothing.
+func (m *Model) emit(t events.Type, session string) {
+	if m.events == nil {
+		return
+	}
+	m.events.Emit(events.Event{Type: t, Session: session, Command: m.sessionCmds[session]})
+}
+
+// emitExit reports that a session's command exited with code while its
+// session stays open.
+func (m *Model) emitExit(session string, code int) {
+	if m.events == nil {
+		return
+	}
+	m.events.Emit(events.Event{Type: events.SessionFinished, Session: session, Command: m.sessionCmds[session], ExitCode: &code})
+}
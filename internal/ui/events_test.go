@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"hiho/internal/events"
+)
+
+func TestCreateAndCloseEmitEvents(t *testing.T) {
+	var buf bytes.Buffer
+	manager := &stubManager{outputByName: map[string]string{"hiho-123-0": "hello"}}
+	model := NewModel(manager, testConfig(), WithEvents(events.NewJSON(&buf)))
+
+	if _, err := model.handleSubmit("/new top"); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if _, err := model.handleSubmit("/closeall"); err != nil {
+		t.Fatalf("closeall: %v", err)
+	}
+	model.handleConfirmKey("y")
+
+	var got []events.Event
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e events.Event
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		got = append(got, e)
+	}
+
+	want := []events.Event{
+		{Type: events.SessionCreated, Session: "hiho-123-0", Command: "top"},
+		{Type: events.CaptureUpdated, Session: "hiho-123-0", Command: "top"},
+		{Type: events.SessionKilled, Session: "hiho-123-0", Command: "top"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), got)
+	}
+	for i, e := range got {
+		if e.Type != want[i].Type || e.Session != want[i].Session || e.Command != want[i].Command {
+			t.Errorf("event %d: got %+v, want %+v", i, e, want[i])
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %d has no time", i)
+		}
+	}
+}
+
+func TestCommandExitEmitsFinished(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+	}{
+		{"success", 0},
+		{"failure", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			manager := &stubManager{}
+			model := NewModel(manager, testConfig(), WithEvents(events.NewJSON(&buf)))
+			if _, err := model.handleSubmit("/new make"); err != nil {
+				t.Fatalf("new: %v", err)
+			}
+
+			manager.exitCodes = map[string]int{"hiho-123-0": tt.code}
+			model.refreshSessions()
+			model.refreshSessions()
+
+			var finished []events.Event
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var e events.Event
+				if err := dec.Decode(&e); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if e.Type == events.SessionFinished {
+					finished = append(finished, e)
+				}
+			}
+			if len(finished) != 1 {
+				t.Fatalf("expected one session_finished event, got %+v", finished)
+			}
+			e := finished[0]
+			if e.Session != "hiho-123-0" || e.Command != "make" || e.ExitCode == nil || *e.ExitCode != tt.code {
+				t.Fatalf("unexpected event %+v", e)
+			}
+		})
+	}
+}
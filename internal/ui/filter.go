@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sidebarFilter narrows the sidebar to sessions whose name contains text.
+// It only changes what the sidebar lists; m.sessions stays complete.
+type sidebarFilter struct {
+	text    string
+	editing bool // typing into the filter, which takes keys first
+}
+
+// active reports whether the filter hides anything or is being typed.
+func (f sidebarFilter) active() bool {
+	return f.editing || f.text != ""
+}
+
+// matches reports whether session i passes the sidebar filter, comparing
+// its tmux and display names case-insensitively.
+func (m Model) matches(i int) bool {
+	if m.filter.text == "" {
+		return true
+	}
+	text := strings.ToLower(m.filter.text)
+	name := m.sessions[i].Name
+	return strings.Contains(strings.ToLower(name), text) ||
+		strings.Contains(strings.ToLower(m.displayName(name)), text)
+}
+
+// visibleSessions returns the indexes of the sessions the sidebar lists.
+func (m Model) visibleSessions() []int {
+	var visible []int
+	for i := range m.sessions {
+		if m.matches(i) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// filterPrompt is the sidebar row showing the filter, with a cursor while
+// it is being typed.
+func (m Model) filterPrompt() string {
+	if m.filter.editing {
+		return "/" + m.filter.text + "_"
+	}
+	return "/" + m.filter.text
+}
+
+// handleFilterKey edits the sidebar filter while it is being typed: enter
+// keeps it, esc clears it, and text narrows the list as it is typed. It
+// reports whether key was handled.
+func (m *Model) handleFilterKey(key string) (tea.Cmd, bool) {
+	if !m.filter.editing || m.focus != focusSidebar {
+		return nil, false
+	}
+	switch key {
+	case "enter":
+		m.filter.editing = false
+		return nil, true
+	case "esc":
+		m.filter = sidebarFilter{}
+	case "backspace":
+		if _, size := utf8.DecodeLastRuneInString(m.filter.text); size > 0 {
+			m.filter.text = m.filter.text[:len(m.filter.text)-size]
+		}
+	case "ctrl+u":
+		m.filter.text = ""
+	default:
+		if utf8.RuneCountInString(key) != 1 {
+			return nil, true
+		}
+		m.filter.text += key
+	}
+	if m.clampToFilter() {
+		return m.schedulePreview(), true
+	}
+	return nil, true
+}
+
+// clampToFilter moves the selection to the first listed session when the
+// filter hides the selected one, and reports whether it moved.
+func (m *Model) clampToFilter() bool {
+	visible := m.visibleSessions()
+	if len(visible) == 0 || slices.Contains(visible, m.sessionIndex) {
+		return false
+	}
+	m.sessionIndex = visible[0]
+	return true
+}
+
+// stepVisible is stepIndex over the sessions the sidebar lists.
+func (m *Model) stepVisible(index, delta int) int {
+	if m.filter.text == "" {
+		return m.stepIndex(index, delta)
+	}
+	visible := m.visibleSessions()
+	pos := slices.Index(visible, index)
+	if pos < 0 {
+		return index
+	}
+	return visible[step(pos, delta, len(visible), m.config.NavWrap)]
+}
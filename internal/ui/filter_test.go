@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestSidebarFilterNarrowsList(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-api-0", "hiho-web-1", "hiho-api-2", "hiho-db-3"}}
+	model := sizedModel(t, manager, 100, 30)
+	model.refreshSessions()
+	model.focus = focusSidebar
+	model.sessionIndex = 1
+	press := func(keys ...string) {
+		t.Helper()
+		for _, key := range keys {
+			updated, _ := model.Update(tea.KeyMsg{Type: key})
+			model = updated.(Model)
+		}
+	}
+	listed := func() []string {
+		var names []string
+		for _, row := range model.sidebarLayout() {
+			if row.session >= 0 {
+				names = append(names, model.sessions[row.session].Name)
+			}
+		}
+		return names
+	}
+
+	press("/", "a", "p", "i")
+	if got := listed(); strings.Join(got, ",") != "hiho-api-0,hiho-api-2" {
+		t.Fatalf("listed %q, want only the api sessions", got)
+	}
+	if model.sessionIndex != 0 {
+		t.Fatalf("expected the selection clamped to the first match, got %d", model.sessionIndex)
+	}
+	if sidebar := ansi.Strip(model.renderSidebar()); !strings.Contains(sidebar, "/api_") {
+		t.Fatalf("expected the filter shown in the sidebar, got:\n%s", sidebar)
+	}
+
+	press("enter", "j")
+	if model.sessionIndex != 2 {
+		t.Fatalf("expected j to skip hidden sessions, got index %d", model.sessionIndex)
+	}
+	press("enter")
+	if model.currentSession != "hiho-api-2" {
+		t.Fatalf("expected enter to open the selected match, got %q", model.currentSession)
+	}
+	if len(model.sessions) != 4 || len(manager.killed) != 0 {
+		t.Fatal("expected filtering to leave the sessions alone")
+	}
+
+	press("/", "x")
+	if rows := model.sidebarLayout(); rows[len(rows)-1].text != "No matches" {
+		t.Fatalf("expected a no-matches row, got %+v", rows)
+	}
+	press("esc")
+	if got := listed(); len(got) != 4 {
+		t.Fatalf("expected esc to restore the full list, got %q", got)
+	}
+}
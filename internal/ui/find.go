@@ -0,0 +1,67 @@
+package ui
+
+import "fmt"
+
+// handleFind implements /find: it highlights case-insensitive matches of
+// term in the focused main pane and jumps to the first one, moving focus
+// there so n and N work. Without a term the search is cleared.
+func (m *Model) handleFind(term string) error {
+	vp := m.focusedViewport()
+	if term == "" {
+		vp.ClearSearch()
+		return nil
+	}
+	if vp.SetSearch(term) == 0 {
+		return fmt.Errorf("no matches for %q", term)
+	}
+	m.syncFollow()
+	m.focus = focusMain
+	m.input.Blur()
+	return nil
+}
+
+// handleSearchKey handles the main panel's search keys: "/" starts a /find
+// in the input, n and N move between matches and esc clears the search. It
+// reports whether key was one of them.
+func (m *Model) handleSearchKey(key string) bool {
+	vp := m.focusedViewport()
+	switch key {
+	case "/":
+		m.focus = focusInput
+		m.input.Focus()
+		m.input.SetValue("/find ")
+	case "n":
+		if !vp.Searching() {
+			return false
+		}
+		vp.NextMatch()
+	case "N":
+		if !vp.Searching() {
+			return false
+		}
+		vp.PrevMatch()
+	case "esc":
+		if !vp.Searching() {
+			return false
+		}
+		vp.ClearSearch()
+	default:
+		return false
+	}
+	m.syncFollow()
+	return true
+}
+
+// searchHint describes the focused pane's search for the footer, or ""
+// when there is none.
+func (m Model) searchHint() string {
+	vp := m.viewport
+	if m.split != nil && m.split.focused {
+		vp = m.split.viewport
+	}
+	if !vp.Searching() {
+		return ""
+	}
+	current, total := vp.SearchStatus()
+	return fmt.Sprintf("match %d/%d, n/N: next/prev, esc: clear", current, total)
+}
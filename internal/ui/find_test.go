@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func findModel(t *testing.T) Model {
+	t.Helper()
+	model := sizedModel(t, &stubManager{}, 120, 30)
+	for i := range 60 {
+		text := fmt.Sprintf("message %d", i)
+		if i%20 == 5 {
+			text = "Build FAILED at step " + fmt.Sprint(i)
+		}
+		model.appendMessage("info", text)
+	}
+	model.refreshViewport()
+	model.viewport.GotoTop()
+	return model
+}
+
+func TestFindJumpsBetweenMatches(t *testing.T) {
+	model := findModel(t)
+	press := func(key string) {
+		updated, _ := model.Update(tea.KeyMsg{Type: key})
+		model = updated.(Model)
+	}
+
+	if _, err := model.handleSubmit("/find failed"); err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if model.focus != focusMain {
+		t.Fatalf("expected focus on the main panel after /find, got %d", model.focus)
+	}
+	if cur, total := model.viewport.SearchStatus(); cur != 1 || total != 3 {
+		t.Fatalf("SearchStatus = %d/%d, want 1/3", cur, total)
+	}
+	if !strings.Contains(model.footerHint(), "match 1/3") {
+		t.Fatalf("expected match count in footer, got %q", model.footerHint())
+	}
+
+	press("n")
+	press("n")
+	if cur, _ := model.viewport.SearchStatus(); cur != 3 {
+		t.Fatalf("after n n: current match %d, want 3", cur)
+	}
+	if !strings.Contains(model.viewport.View(), "step 45") {
+		t.Fatalf("expected the third match in view, got %q", model.viewport.View())
+	}
+	press("N")
+	if cur, _ := model.viewport.SearchStatus(); cur != 2 {
+		t.Fatalf("after N: current match %d, want 2", cur)
+	}
+	press("esc")
+	if model.viewport.Searching() {
+		t.Fatal("expected esc to clear the search")
+	}
+}
+
+func TestSlashKeyStartsFind(t *testing.T) {
+	model := findModel(t)
+	model.focus = focusMain
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "/"})
+	model = updated.(Model)
+	if model.focus != focusInput || model.input.Value() != "/find " {
+		t.Fatalf("expected /find prefilled in the input, got focus %d value %q", model.focus, model.input.Value())
+	}
+}
+
+func TestFindWithoutMatches(t *testing.T) {
+	model := findModel(t)
+	if err := model.handleFind("nowhere"); err == nil {
+		t.Fatal("expected an error when nothing matches")
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// Labels for the follow state, shown in the tab bar on the Tmux tab.
+const (
+	followingLabel = "[FOLLOWING]"
+	notFollowLabel = "[PAUSED]"
+)
+
+// syncFollow updates the follow state of the viewport the user just moved
+// (see focusedViewport): like tail -f, it follows the newest output exactly
+// while scrolled to the bottom, so scrolling up stops it and going back to
+// the end resumes it. The split pane and each main panel tab keep their
+// own state, so scrolling one does not pause the others.
+func (m *Model) syncFollow() {
+	if m.split != nil && m.split.focused {
+		m.split.scrolledBack = !m.split.viewport.AtBottom()
+		return
+	}
+	if m.scrolledBack == nil {
+		m.scrolledBack = make(map[tabType]bool)
+	}
+	m.scrolledBack[m.activeTab] = !m.viewport.AtBottom()
+}
+
+// following reports whether the main panel's active tab follows the
+// newest output.
+func (m Model) following() bool {
+	return !m.scrolledBack[m.activeTab]
+}
+
+// followIndicator labels the follow state of the focused pane on the Tmux
+// tab, or returns "" elsewhere and when follow_output is off.
+func (m Model) followIndicator() string {
+	if m.activeTab != tabTmux || m.currentSession == "" || !m.config.FollowOutput {
+		return ""
+	}
+	following := m.following()
+	if m.split != nil && m.split.focused {
+		following = !m.split.scrolledBack
+	}
+	if following {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(runningGlyphColor)).Render(followingLabel)
+	}
+	return lipgloss.NewStyle().Faint(true).Render(notFollowLabel)
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestFollowPausesOnScrollUpAndResumesAtBottom(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 120, 20)
+	model.activeTab = tabTmux
+	model.currentSession = "hiho-1-0"
+	model.focus = focusMain
+	model.setSessionLog(strings.Repeat("x\n", 50))
+	model.refreshViewport()
+
+	tabRow := func() string {
+		return strings.Split(ansi.Strip(model.renderMainPanel()), "\n")[1]
+	}
+	press := func(key string) {
+		t.Helper()
+		updated, _ := model.Update(tea.KeyMsg{Type: key})
+		model = updated.(Model)
+	}
+
+	if !model.following() || !strings.Contains(tabRow(), followingLabel) {
+		t.Fatalf("expected to follow a new session, got %q", tabRow())
+	}
+
+	press("up")
+	offset := model.viewport.YOffset
+	if model.following() || !strings.Contains(tabRow(), notFollowLabel) {
+		t.Fatalf("expected scrolling up to pause following, got %q", tabRow())
+	}
+	model.setSessionLog(strings.Repeat("x\n", 60))
+	model.refreshViewport()
+	if model.viewport.YOffset != offset {
+		t.Fatalf("expected paused view to stay at offset %d, got %d", offset, model.viewport.YOffset)
+	}
+
+	press("G")
+	if !model.following() || !strings.Contains(tabRow(), followingLabel) {
+		t.Fatalf("expected going to the bottom to resume following, got %q", tabRow())
+	}
+	model.setSessionLog(strings.Repeat("x\n", 70))
+	model.refreshViewport()
+	if !model.viewport.AtBottom() {
+		t.Fatal("expected the view to follow new output again")
+	}
+
+	model.config.FollowOutput = false
+	if row := tabRow(); strings.Contains(row, followingLabel) || strings.Contains(row, notFollowLabel) {
+		t.Fatalf("expected no follow label with follow_output off, got %q", row)
+	}
+}
+
+func TestFollowIsKeptPerView(t *testing.T) {
+	long := strings.Repeat("line\n", 80)
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1"},
+		outputByName: map[string]string{"hiho-123-0": long, "hiho-123-1": long},
+	}
+	model := sizedModel(t, manager, 120, 20)
+	model.currentSession = "hiho-123-0"
+	model.focus = focusMain
+	for range 40 {
+		model.appendMessage("user", "note")
+	}
+	model.refreshViewport()
+
+	// Scrolling the conversation back leaves the Tmux tab following
+	model.handleScrollKey("up")
+	if model.following() {
+		t.Fatal("expected the conversation to stop following")
+	}
+	model.setTab(tabTmux)
+	if !model.following() {
+		t.Fatal("expected the Tmux tab to keep following")
+	}
+
+	// So does scrolling the split pane
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if _, err := model.handleSubmit("/split hiho-123-1"); err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	model.split.focused = true
+	model.handleScrollKey("up")
+	offset := model.split.viewport.YOffset
+	if !model.split.scrolledBack || !model.following() {
+		t.Fatalf("expected only the split pane to stop following, split %v main %v", model.split.scrolledBack, model.following())
+	}
+	manager.outputByName["hiho-123-1"] = long + "more\n"
+	if err := model.captureSplit(); err != nil {
+		t.Fatalf("capture split: %v", err)
+	}
+	if model.split.viewport.YOffset != offset {
+		t.Fatalf("expected the paused split pane to stay at offset %d, got %d", offset, model.split.viewport.YOffset)
+	}
+	if row := strings.Split(ansi.Strip(model.renderMainPanel()), "\n")[1]; !strings.Contains(row, notFollowLabel) {
+		t.Fatalf("expected the focused split pane's state in the tab bar, got %q", row)
+	}
+}
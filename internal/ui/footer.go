@@ -0,0 +1,63 @@
+package ui
+
+import "strings"
+
+// footerSeparator joins hints in the input panel footer.
+const footerSeparator = " • "
+
+// footerHint returns the help line under the input, tailored to the focused
+// area and built from the configured keys. A pending confirmation replaces
+// it with the yes/no prompt.
+func (m Model) footerHint() string {
+	if m.pendingConfirm != nil {
+		return m.pendingConfirm.prompt + " (y/n)"
+	}
+	keys := m.config.KeyBindings
+	var hints []string
+	switch m.focus {
+	case focusSidebar:
+		switch {
+		case m.filter.editing:
+			hints = []string{"type to filter sessions", "enter: keep filter", "esc: clear filter"}
+		case m.filter.text != "":
+			hints = []string{
+				keys.SessionUp + "/" + keys.SessionDown + ": select session",
+				"enter: open",
+				"/: edit filter",
+				"esc: clear filter",
+			}
+		default:
+			hints = []string{
+				keys.SessionUp + "/" + keys.SessionDown + ": select session",
+				"enter: open",
+				"/: filter",
+			}
+		}
+	case focusMain:
+		if m.split != nil {
+			hints = append(hints, m.splitFocusHint())
+		}
+		if hint := m.searchHint(); hint != "" {
+			hints = append(hints, hint)
+		}
+		hints = append(hints,
+			"j/k pgup/pgdown: scroll",
+			keys.ScrollTop+"/"+keys.ScrollBottom+": top/bottom",
+			"/: find",
+			"drag: select & copy",
+			keys.NextTab+"/"+keys.PrevTab+": switch tab",
+			keys.TogglePause+": pause refresh",
+		)
+	default:
+		hints = []string{
+			"enter: run /command or add note",
+			"/help: commands",
+		}
+	}
+	hints = append(hints,
+		keys.CycleWindows+": cycle focus",
+		keys.ToggleTab+": toggle view",
+		keys.Quit+": quit",
+	)
+	return strings.Join(hints, footerSeparator)
+}
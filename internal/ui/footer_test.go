@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFooterFollowsFocus(t *testing.T) {
+	cfg := testConfig()
+	cfg.KeyBindings.SessionUp = "ctrl+k"
+	cfg.KeyBindings.TogglePause = "f9"
+	model := NewModel(&stubManager{}, cfg)
+	model.width = 200
+
+	tests := []struct {
+		focus focusArea
+		want  string
+		not   string
+	}{
+		{focusSidebar, "ctrl+k/down: select session", "drag: select"},
+		{focusMain, "f9: pause refresh", "enter: open"},
+		{focusInput, "/help: commands", "select session"},
+	}
+	for _, tt := range tests {
+		model.focus = tt.focus
+		panel := model.renderInputPanel()
+		if !strings.Contains(panel, tt.want) {
+			t.Errorf("focus %d: expected %q in footer, got %q", tt.focus, tt.want, panel)
+		}
+		if strings.Contains(panel, tt.not) {
+			t.Errorf("focus %d: did not expect %q in footer", tt.focus, tt.not)
+		}
+		if !strings.Contains(panel, "ctrl+c: quit") {
+			t.Errorf("focus %d: expected quit hint", tt.focus)
+		}
+	}
+}
@@ -1,14 +1,95 @@
 package ui
 
+import (
+	"fmt"
+	"strings"
+
+	"hiho/internal/config"
+)
+
 const commandHelp = `Commands:
   /help                 Show this help
   /new <cmd>            Create a tmux session and run the command
+  /new --name <label> <cmd>
+                        Create the session with a display name
+  /new -C <dir> <cmd>   Start the session in a directory
+  /new --env-file <path> <cmd>
+                        Load KEY=VALUE lines from a file before running
+  /new KEY=VALUE... <cmd>
+                        Export variables in the session before running
+  /new --force <cmd>    Run even if the command launches hiho itself
   /list                 List hiho-managed sessions
   /sessions             List all tmux sessions
   /next                 Cycle to next session
   /prev                 Cycle to previous session
   /switch <session>     Jump to a specific session
   /switch               Cycle to next session (Tmux tab only)
-  /closeall             Close all hiho-managed sessions
+  /all [n]              Last n lines of every session, labeled
+  /split <session>|off  Show another session beside the current one
+  /tailn <n>|off        Show only the last n lines of the capture
+  /refresh              Capture the current session now
+  /stats                CPU and memory of the current session's process
+  /pause, /resume       Stop or restart automatic refreshing
+  /paste [--delay <ms>] <file>
+                        Type a file into the current session line by line
+  /send [text]          Type text and Enter into the current session
+  /send --keys <key>... Press tmux keys, e.g. C-c
+  /secret               Mask the next entry and keep it out of history
+  /broadcast <text>     Type text into every hiho session (asks first)
+  /open <workspace>     Start every session of a configured workspace
+  /find [text]          Highlight matches in the main panel (n/N to move)
+  /copy                 Copy the current session's output to the clipboard
+  /clear [all]          Clear this conversation (all: every one, and the session view)
+  /dup [session]        Run a session's command again in a new session
+  /rename <name>        Rename the current session
+  /attach [-r] [session]
+                        Attach to a session in tmux; detach to come back
+  /kill [session]       Close one session (default: current)
+  /close <workspace>    Close only that workspace's sessions
+  /closeall             Close all hiho-managed sessions (asks first)
+  /diff [on|off]        Highlight changes between captures (Tmux tab)
+  /screenshot [--plain] <path>
+                        Save the current frame (ANSI, or plain text)
+  /tab next|prev        Cycle through the tabs
+  /profile [name]       List profiles, or switch to one
+  /reload               Re-read the config file (or profile)
   /view tmux            Switch to Tmux Window tab
   /view conversation    Switch to Conversation tab`
+
+// helpText returns the static command list followed by the keybindings
+// currently configured by the user.
+func helpText(keys config.KeyBindings) string {
+	bindings := []struct {
+		key  string
+		desc string
+	}{
+		{keys.ToggleTab, "Toggle Conversation/Tmux tab"},
+		{keys.NextTab + " / " + keys.PrevTab, "Next / previous tab"},
+		{keys.CycleWindows, "Cycle focus (sidebar, main, input)"},
+		{keys.FocusSidebar + " / " + keys.FocusMain, "Focus the sidebar / main panel"},
+		{keys.NextSession, "Next session"},
+		{keys.PrevSession, "Previous session"},
+		{keys.SessionUp + " / " + keys.SessionDown, "Move sidebar selection"},
+		{"/ (sidebar)", "Filter sessions by name; esc clears"},
+		{"up / down", "Input history (when the input is focused)"},
+		{"left / right, home / end", "Move the input cursor; delete removes the character under it"},
+		{"ctrl+w / ctrl+u", "Delete the previous word / clear the input"},
+		{"alt+enter", "New line in the input (enter submits)"},
+		{"j / k, pgup / pgdown", "Scroll the main panel (when focused)"},
+		{"home / end, " + keys.ScrollTop + " / " + keys.ScrollBottom, "Top / bottom of the main panel (bottom resumes following)"},
+		{"/, n / N, esc", "Search the main panel, next / previous match, clear"},
+		{keys.TogglePause, "Pause / resume auto-refresh"},
+		{keys.ToggleTail, "Expand / collapse the /tailn view"},
+		{keys.ResizeLeft + " / " + keys.ResizeRight, "Move the sidebar or split divider"},
+		{keys.ReloadConfig, "Reload the config file"},
+		{keys.Quit, "Quit"},
+	}
+
+	var builder strings.Builder
+	builder.WriteString(commandHelp)
+	builder.WriteString("\n\nKeys:")
+	for _, binding := range bindings {
+		builder.WriteString(fmt.Sprintf("\n  %-22s%s", binding.key, binding.desc))
+	}
+	return builder.String()
+}
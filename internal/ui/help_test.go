@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHelpShowsConfiguredKeybindings(t *testing.T) {
+	cfg := testConfig()
+	cfg.KeyBindings.CycleWindows = "ctrl+g"
+	cfg.KeyBindings.NextSession = "alt+n"
+	cfg.KeyBindings.Quit = "ctrl+q"
+	cfg.KeyBindings.FocusSidebar = "f7"
+	cfg.KeyBindings.FocusMain = "f8"
+
+	model := NewModel(&stubManager{}, cfg)
+	if _, err := model.handleSubmit("/help"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	content := lastMessage(model).Content
+	for _, want := range []string{"ctrl+g", "alt+n", "ctrl+q", "f7 / f8", "/new <cmd>"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in help, got %q", want, content)
+		}
+	}
+	if strings.Contains(content, "ctrl+o") {
+		t.Fatalf("expected default cycle key to be replaced, got %q", content)
+	}
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleAction is what the idle timer should do on a tick.
+type idleAction int
+
+const (
+	idleNone idleAction = iota
+	idleWarn
+	idleQuit
+)
+
+// idleWarnBefore is how long before the timeout a warning is shown. Short
+// timeouts warn after three quarters of the time instead.
+const idleWarnBefore = time.Minute
+
+// idleDecision decides whether hiho should warn or quit given the last
+// input time. A timeout of zero or less disables the check.
+func idleDecision(lastActivity, now time.Time, timeout time.Duration) idleAction {
+	if timeout <= 0 {
+		return idleNone
+	}
+	idle := now.Sub(lastActivity)
+	switch {
+	case idle >= timeout:
+		return idleQuit
+	case idle >= timeout-min(idleWarnBefore, timeout/4):
+		return idleWarn
+	default:
+		return idleNone
+	}
+}
+
+// noteActivity records user input: it restarts the idle clock and brings
+// auto-refresh back to its base rate.
+func (m *Model) noteActivity(now time.Time) {
+	m.lastActivity = now
+	m.idleWarned = false
+	m.resetRefreshBackoff()
+}
+
+// checkIdle runs on every timer tick and returns tea.Quit once the
+// configured idle timeout is exceeded. tmux sessions keep running.
+func (m *Model) checkIdle(now time.Time) tea.Cmd {
+	timeout := m.config.IdleTimeout
+	switch idleDecision(m.lastActivity, now, timeout) {
+	case idleQuit:
+		return tea.Quit
+	case idleWarn:
+		if !m.idleWarned {
+			m.idleWarned = true
+			left := timeout - now.Sub(m.lastActivity)
+			m.appendMessage("info", fmt.Sprintf("No input for a while; hiho will exit in %s (sessions keep running). Press any key to stay.", left.Round(time.Second)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIdleDecision(t *testing.T) {
+	last := time.Unix(1000, 0)
+	tests := []struct {
+		name    string
+		idle    time.Duration
+		timeout time.Duration
+		want    idleAction
+	}{
+		{"disabled", 10 * time.Hour, 0, idleNone},
+		{"active", 5 * time.Minute, 30 * time.Minute, idleNone},
+		{"warn a minute before", 29*time.Minute + 30*time.Second, 30 * time.Minute, idleWarn},
+		{"timed out", 30 * time.Minute, 30 * time.Minute, idleQuit},
+		{"short timeout warns at three quarters", 8 * time.Second, 10 * time.Second, idleWarn},
+		{"short timeout before warning", 7 * time.Second, 10 * time.Second, idleNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idleDecision(last, last.Add(tt.idle), tt.timeout); got != tt.want {
+				t.Fatalf("idleDecision(idle %v, timeout %v) = %v, want %v", tt.idle, tt.timeout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdleTickWarnsOnceThenQuits(t *testing.T) {
+	cfg := testConfig()
+	cfg.IdleTimeout = 10 * time.Minute
+	model := NewModel(&stubManager{}, cfg)
+	start := model.lastActivity
+
+	updated, _ := model.Update(refreshTickMsg(start.Add(9*time.Minute + 30*time.Second)))
+	model = updated.(Model)
+	updated, _ = model.Update(refreshTickMsg(start.Add(9*time.Minute + 40*time.Second)))
+	model = updated.(Model)
+	if len(model.conversation()) != 1 {
+		t.Fatalf("expected a single idle warning, got %v", model.conversation())
+	}
+
+	_, cmd := model.Update(refreshTickMsg(start.Add(10 * time.Minute)))
+	if cmd == nil || cmd() != tea.Quit() {
+		t.Fatalf("expected tea.Quit after the timeout")
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxHistoryLines bounds the output kept for one session.
+const maxHistoryLines = 5000
+
+// captureHistory accumulates a session's output across captures. Each
+// capture is a window onto the pane, so successive captures overlap; only
+// the lines past that overlap are appended, keeping earlier output (and the
+// viewport's scroll position over it) intact.
+type captureHistory struct {
+	session string
+	last    []string // previous capture, trailing blank lines removed
+	lines   []string // accumulated output
+}
+
+// apply folds a new capture of session into the history and returns the
+// accumulated output. A different session, or a capture that no longer
+// overlaps the previous one (the pane was cleared), starts over.
+func (h *captureHistory) apply(session, output string) string {
+	next := captureLines(output)
+	added, rewriteLast, reset := outputDelta(h.last, next)
+	switch {
+	case session != h.session || reset:
+		h.lines = slices.Clone(next)
+	default:
+		if rewriteLast && len(h.lines) > 0 {
+			h.lines = h.lines[:len(h.lines)-1]
+		}
+		h.lines = append(h.lines, added...)
+	}
+	if extra := len(h.lines) - maxHistoryLines; extra > 0 {
+		h.lines = h.lines[extra:]
+	}
+	h.session = session
+	h.last = next
+	return strings.Join(h.lines, "\n")
+}
+
+// captureLines splits a capture into lines, dropping the blank rows tmux
+// pads the pane with below the last output.
+func captureLines(output string) []string {
+	lines := strings.Split(output, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// outputDelta compares successive captures prev and next. It finds the
+// longest tail of prev that next starts with and returns the lines after
+// it. A single shared line only counts if next goes on past it (or prev
+// is that one line): a cleared screen shows just the prompt, which the old
+// screen usually ended with too. The tail's final line may have been
+// rewritten (a prompt gaining typed text) as long as an unchanged line
+// anchors the overlap; rewriteLast then reports that prev's final line
+// must be replaced. With no overlap reset is true and next stands alone.
+func outputDelta(prev, next []string) (added []string, rewriteLast, reset bool) {
+	if len(prev) == 0 {
+		return next, false, true
+	}
+	for start := range prev {
+		tail := prev[start:]
+		anchored := len(tail) > 1 || len(prev) == 1 || len(next) > 1
+		if anchored && len(next) >= len(tail) && slices.Equal(next[:len(tail)], tail) {
+			return next[len(tail):], false, false
+		}
+		stable := tail[:len(tail)-1]
+		if len(stable) == 0 && len(prev) > 1 {
+			continue
+		}
+		if len(next) > len(stable) && slices.Equal(next[:len(stable)], stable) {
+			return next[len(stable):], true, false
+		}
+	}
+	return next, false, true
+}
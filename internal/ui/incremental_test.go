@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOutputDelta(t *testing.T) {
+	tests := []struct {
+		name        string
+		prev, next  []string
+		added       []string
+		rewriteLast bool
+		reset       bool
+	}{
+		{"first capture", nil, []string{"a"}, []string{"a"}, false, true},
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, []string{}, false, false},
+		{"appended", []string{"a", "b"}, []string{"a", "b", "c"}, []string{"c"}, false, false},
+		{"scrolled", []string{"a", "b", "c"}, []string{"b", "c", "d", "e"}, []string{"d", "e"}, false, false},
+		{"prompt rewritten", []string{"out", "$ "}, []string{"out", "$ ls", "file"}, []string{"$ ls", "file"}, true, false},
+		{"cleared screen", []string{"a", "b", "$ clear"}, []string{"$ "}, []string{"$ "}, false, true},
+		{"cleared screen at a prompt", []string{"a", "b", "$ "}, []string{"$ "}, []string{"$ "}, false, true},
+		{"one line of overlap", []string{"a", "b"}, []string{"b", "c"}, []string{"c"}, false, false},
+		{"one-line prev unchanged", []string{"$ "}, []string{"$ "}, []string{}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, rewrite, reset := outputDelta(tt.prev, tt.next)
+			if len(added) != 0 || len(tt.added) != 0 {
+				if !reflect.DeepEqual(added, tt.added) {
+					t.Fatalf("added = %q, want %q", added, tt.added)
+				}
+			}
+			if rewrite != tt.rewriteLast || reset != tt.reset {
+				t.Fatalf("rewriteLast, reset = %v, %v; want %v, %v", rewrite, reset, tt.rewriteLast, tt.reset)
+			}
+		})
+	}
+}
+
+func TestCaptureHistoryAccumulatesAcrossCaptures(t *testing.T) {
+	var h captureHistory
+	captures := []string{
+		"line 1\nline 2\n\n\n",
+		"line 2\nline 3\n",
+		"line 3\nline 4\n$ clear",
+	}
+	var got string
+	for _, c := range captures {
+		got = h.apply("hiho-1-0", c)
+	}
+	if want := "line 1\nline 2\nline 3\nline 4\n$ clear"; got != want {
+		t.Fatalf("history = %q, want %q", got, want)
+	}
+
+	if got := h.apply("hiho-1-0", "$ "); got != "$ " {
+		t.Fatalf("expected a cleared pane to start over, got %q", got)
+	}
+	if got := h.apply("hiho-1-1", "other"); got != "other" {
+		t.Fatalf("expected a new session to start over, got %q", got)
+	}
+}
+
+func TestRefreshViewportStaysAtBottom(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 90, 20)
+	model.activeTab = tabTmux
+	model.currentSession = "hiho-1-0"
+	model.setSessionLog(strings.Repeat("x\n", 50))
+	model.refreshViewport()
+	if !model.viewport.AtBottom() {
+		t.Fatal("expected viewport to follow output at the bottom")
+	}
+
+	model.viewport.YOffset = 0
+	model.syncFollow() // as after scrolling up by hand
+	model.setSessionLog(strings.Repeat("x\n", 50) + "y")
+	model.refreshViewport()
+	if model.viewport.YOffset != 0 {
+		t.Fatalf("expected scroll position kept, got offset %d", model.viewport.YOffset)
+	}
+}
@@ -0,0 +1,53 @@
+package ui
+
+// maxInputHistory caps how many submitted inputs are remembered.
+const maxInputHistory = 500
+
+// inputHistory remembers submitted inputs for up/down recall in the input
+// box, like a shell. index points past the newest entry when not browsing;
+// draft keeps what was typed before browsing started.
+type inputHistory struct {
+	entries []string
+	index   int
+	draft   string
+}
+
+// record appends a submitted value, skipping a repeat of the newest entry,
+// and stops browsing.
+func (h *inputHistory) record(value string) {
+	if value != "" && (len(h.entries) == 0 || h.entries[len(h.entries)-1] != value) {
+		h.entries = append(h.entries, value)
+		if len(h.entries) > maxInputHistory {
+			h.entries = h.entries[len(h.entries)-maxInputHistory:]
+		}
+	}
+	h.index = len(h.entries)
+	h.draft = ""
+}
+
+// prev returns the entry before the current one. current is kept as the
+// draft when browsing starts. ok is false when there is nothing older.
+func (h *inputHistory) prev(current string) (string, bool) {
+	if h.index == 0 {
+		return "", false
+	}
+	if h.index >= len(h.entries) {
+		h.index = len(h.entries)
+		h.draft = current
+	}
+	h.index--
+	return h.entries[h.index], true
+}
+
+// next returns the entry after the current one, or the draft once past the
+// newest. ok is false when not browsing.
+func (h *inputHistory) next() (string, bool) {
+	if h.index >= len(h.entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.index], true
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInputHistoryRecordSkipsConsecutiveDuplicates(t *testing.T) {
+	var h inputHistory
+	for _, value := range []string{"/list", "/list", "note", "/list"} {
+		h.record(value)
+	}
+	if want := []string{"/list", "note", "/list"}; !slices.Equal(h.entries, want) {
+		t.Fatalf("entries = %q, want %q", h.entries, want)
+	}
+	if h.index != len(h.entries) {
+		t.Fatalf("expected index reset to the end, got %d", h.index)
+	}
+}
+
+func TestInputHistoryBrowsing(t *testing.T) {
+	var h inputHistory
+	h.record("one")
+	h.record("two")
+
+	steps := []struct {
+		name string
+		step func() (string, bool)
+		want string
+		ok   bool
+	}{
+		{"newest", func() (string, bool) { return h.prev("draft") }, "two", true},
+		{"older", func() (string, bool) { return h.prev("two") }, "one", true},
+		{"stops at oldest", func() (string, bool) { return h.prev("one") }, "", false},
+		{"newer", h.next, "two", true},
+		{"back to draft", h.next, "draft", true},
+		{"stops at draft", h.next, "", false},
+	}
+	for _, s := range steps {
+		got, ok := s.step()
+		if got != s.want || ok != s.ok {
+			t.Fatalf("%s: got (%q, %v), want (%q, %v)", s.name, got, ok, s.want, s.ok)
+		}
+	}
+}
+
+func TestUpDownRecallSubmittedInput(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.focus = focusInput
+	press := func(key string) {
+		updated, _ := model.Update(tea.KeyMsg{Type: key})
+		model = updated.(Model)
+	}
+	for _, value := range []string{"first note", "/list"} {
+		model.input.SetValue(value)
+		press("enter")
+	}
+
+	press("up")
+	if got := model.input.Value(); got != "/list" {
+		t.Fatalf("after up: %q, want /list", got)
+	}
+	press("up")
+	if got := model.input.Value(); got != "first note" {
+		t.Fatalf("after second up: %q, want first note", got)
+	}
+	press("down")
+	press("down")
+	if got := model.input.Value(); got != "" {
+		t.Fatalf("expected down past the newest to restore the empty draft, got %q", got)
+	}
+}
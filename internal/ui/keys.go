@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleKey routes a key press: a pending confirmation or the sidebar
+// filter first, then the configured bindings, then keys for whichever
+// panel has focus.
+func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	m.noteActivity(time.Now())
+	key := msg.String()
+
+	// A pending confirmation swallows everything except quit
+	if m.pendingConfirm != nil && key != m.config.KeyBindings.Quit {
+		m.handleConfirmKey(key)
+		return m, nil
+	}
+
+	// So does typing into the sidebar filter
+	if key != m.config.KeyBindings.Quit {
+		if cmd, ok := m.handleFilterKey(key); ok {
+			return m, cmd
+		}
+	}
+
+	// Check configurable keybindings first
+	switch key {
+	case m.config.KeyBindings.Quit:
+		m.flushLayout()
+		return m, tea.Quit
+	case m.config.KeyBindings.ToggleTab:
+		m.toggleTab()
+		return m, nil
+	case m.config.KeyBindings.NextTab:
+		m.nextTab()
+		return m, nil
+	case m.config.KeyBindings.PrevTab:
+		m.prevTab()
+		return m, nil
+	case m.config.KeyBindings.NextSession:
+		if err := m.navigateSession(1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return m, nil
+	case m.config.KeyBindings.PrevSession:
+		if err := m.navigateSession(-1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return m, nil
+	case m.config.KeyBindings.CycleWindows:
+		m.cycleFocus()
+		return m, nil
+	case m.config.KeyBindings.FocusSidebar:
+		m.focus = focusSidebar
+		m.input.Blur()
+		return m, nil
+	case m.config.KeyBindings.FocusMain:
+		m.focus = focusMain
+		m.input.Blur()
+		return m, nil
+	case m.config.KeyBindings.TogglePause:
+		m.setPaused(!m.paused)
+		return m, nil
+	case m.config.KeyBindings.ToggleTail:
+		m.toggleTail()
+		return m, nil
+	case m.config.KeyBindings.ReloadConfig:
+		if err := m.handleReload(); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return m, nil
+	case m.config.KeyBindings.ResizeLeft:
+		m.handleResize(-resizeStep)
+		return m, nil
+	case m.config.KeyBindings.ResizeRight:
+		m.handleResize(resizeStep)
+		return m, nil
+	}
+
+	// Then user-defined keys that run a slash command
+	if command, ok := m.config.CommandBindings[key]; ok {
+		if _, err := m.handleSubmit(command); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return m, nil
+	}
+
+	// Handle focus-specific keys
+	switch m.focus {
+	case focusSidebar:
+		switch key {
+		case m.config.KeyBindings.SessionUp, "up", "k":
+			m.selectPrevSession()
+			return m, m.schedulePreview()
+		case m.config.KeyBindings.SessionDown, "down", "j":
+			m.selectNextSession()
+			return m, m.schedulePreview()
+		case "enter":
+			m.activateSelectedSession()
+			return m, nil
+		case "/":
+			m.filter.editing = true
+			return m, nil
+		case "esc":
+			if m.filter.text != "" {
+				m.filter = sidebarFilter{}
+				return m, nil
+			}
+		}
+	case focusMain:
+		if m.handleScrollKey(key) || m.handleSearchKey(key) {
+			return m, nil
+		}
+	case focusInput:
+		switch key {
+		case "up":
+			if value, ok := m.inputHistory.prev(m.input.Value()); ok {
+				m.input.SetValue(value)
+			}
+			return m, nil
+		case "down":
+			if value, ok := m.inputHistory.next(); ok {
+				m.input.SetValue(value)
+			}
+			return m, nil
+		case "enter":
+			value := strings.TrimSpace(m.input.Value())
+			if value != "" {
+				if err := m.submitInput(value); err != nil {
+					m.appendNotice("error", err.Error())
+				}
+				m.input.Reset()
+			}
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Legacy key handling for backward compatibility
+	switch key {
+	case "alt+h":
+		if err := m.navigateSession(-1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+	case "alt+l":
+		if err := m.navigateSession(1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+	case "alt+j":
+		if err := m.navigateSession(-1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+	case "alt+k":
+		if err := m.navigateSession(1); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+	}
+	return m, nil
+}
+
+// cycleFocus moves focus between sidebar, main, and input. Blurring the
+// input keeps its in-progress value; it is only cleared on submit.
+func (m *Model) cycleFocus() {
+	switch m.focus {
+	case focusSidebar:
+		m.focus = focusMain
+	case focusMain:
+		if m.split != nil && !m.split.focused {
+			m.split.focused = true
+			return
+		}
+		if m.split != nil {
+			m.split.focused = false
+		}
+		m.focus = focusInput
+		m.input.Focus()
+	case focusInput:
+		m.focus = focusSidebar
+		m.input.Blur()
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/config"
+)
+
+func TestFocusKeysMoveFocusDirectly(t *testing.T) {
+	tests := []struct {
+		name string
+		key  func(config.KeyBindings) string
+		want focusArea
+	}{
+		{"sidebar", func(k config.KeyBindings) string { return k.FocusSidebar }, focusSidebar},
+		{"main", func(k config.KeyBindings) string { return k.FocusMain }, focusMain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewModel(&stubManager{}, testConfig())
+
+			updated, _ := model.Update(tea.KeyMsg{Type: tt.key(model.config.KeyBindings)})
+			model = updated.(Model)
+			if model.focus != tt.want {
+				t.Fatalf("expected focus %v, got %v", tt.want, model.focus)
+			}
+			updated, _ = model.Update(tea.KeyMsg{Type: "x"})
+			if value := updated.(Model).input.Value(); value != "" {
+				t.Fatalf("expected typing to skip the input, got %q", value)
+			}
+		})
+	}
+}
+
+func TestCycleFocusPreservesInputValue(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	for _, r := range "/new ec" {
+		updated, _ := model.Update(tea.KeyMsg{Type: string(r)})
+		model = updated.(Model)
+	}
+
+	// input -> sidebar -> main -> input
+	for i := 0; i < 3; i++ {
+		updated, _ := model.Update(tea.KeyMsg{Type: model.config.KeyBindings.CycleWindows})
+		model = updated.(Model)
+		if model.input.Value() != "/new ec" {
+			t.Fatalf("expected input to survive focus cycling, got %q after %d cycles", model.input.Value(), i+1)
+		}
+	}
+	if model.focus != focusInput {
+		t.Fatalf("expected focus back on input, got %v", model.focus)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "h"})
+	model = updated.(Model)
+	if model.input.Value() != "/new ech" {
+		t.Fatalf("expected typing to resume, got %q", model.input.Value())
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: "enter"})
+	model = updated.(Model)
+	if model.input.Value() != "" {
+		t.Fatalf("expected input to reset after submit, got %q", model.input.Value())
+	}
+}
+
+func TestCommandBindingRunsMappedCommand(t *testing.T) {
+	manager := &stubManager{}
+	cfg := testConfig()
+	cfg.CommandBindings = map[string]string{"ctrl+n": "/new bash"}
+	model := NewModel(manager, cfg)
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+n"})
+	model = updated.(Model)
+
+	if len(manager.created) != 1 || manager.created[0] != "bash" {
+		t.Fatalf("expected ctrl+n to run /new bash, got %v", manager.created)
+	}
+	if model.input.Value() != "" {
+		t.Fatalf("expected bound key not to reach the input, got %q", model.input.Value())
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+
+	"hiho/internal/events"
+	"hiho/internal/tmux"
+)
+
+// handleKill closes one session, given by name or label, or the current
+// session when ref is empty.
+func (m *Model) handleKill(ref string) error {
+	if ref == "" {
+		ref = m.currentSession
+	}
+	if ref == "" {
+		return fmt.Errorf("usage: /kill <session>")
+	}
+	name, ok := m.resolveSession(ref)
+	if !ok {
+		return fmt.Errorf("%w: %s", tmux.ErrSessionNotFound, ref)
+	}
+	if err := m.manager.Kill(name); err != nil {
+		return err
+	}
+	m.emit(events.SessionKilled, name)
+	delete(m.sessionStatus, name)
+	delete(m.sessionTags, name)
+	delete(m.sessionLabels, name)
+	delete(m.sessionCmds, name)
+	delete(m.sessionEnv, name)
+	delete(m.paneSizes, name)
+	m.dropConversations(name)
+	if name == m.currentSession {
+		m.currentSession = ""
+		m.sessionLog = ""
+		m.markChanged(changedCapture)
+	}
+	m.refreshSessions()
+	m.sessionIndex = min(m.sessionIndex, max(len(m.sessions)-1, 0))
+	m.appendMessage("info", fmt.Sprintf("Killed session %s", name))
+	return nil
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+func TestKillNamedSessionLeavesOthers(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "output"
+
+	if _, err := model.handleSubmit("/kill hiho-123-1"); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if !slices.Equal(manager.killed, []string{"hiho-123-1"}) {
+		t.Fatalf("unexpected kills %v", manager.killed)
+	}
+	if model.currentSession != "hiho-123-0" || model.sessionLog != "output" {
+		t.Fatalf("current session should be untouched, got %q", model.currentSession)
+	}
+	if len(model.sessions) != 1 {
+		t.Fatalf("expected session list refreshed, got %v", model.sessions)
+	}
+}
+
+func TestKillDefaultsToCurrentSession(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0"}}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "output"
+
+	if _, err := model.handleSubmit("/kill"); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if !slices.Equal(manager.killed, []string{"hiho-123-0"}) {
+		t.Fatalf("unexpected kills %v", manager.killed)
+	}
+	if model.currentSession != "" || model.sessionLog != "" {
+		t.Fatalf("expected current session cleared, got %q / %q", model.currentSession, model.sessionLog)
+	}
+}
+
+func TestKillUnknownSession(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0"}}
+	model := NewModel(manager, testConfig())
+
+	err := model.handleKill("hiho-999-0")
+	if !errors.Is(err, tmux.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if len(manager.killed) != 0 {
+		t.Fatalf("nothing should be killed, got %v", manager.killed)
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+
+	"hiho/internal/tmux"
+)
+
+// labelPattern keeps labels safe as part of a tmux session name, which
+// may not contain '.' or ':'.
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// labeledName is the tmux name for a labeled session. The hiho- prefix
+// keeps it visible to ListHiho.
+func labeledName(label string) string {
+	return "hiho-" + label
+}
+
+// validateLabel checks that label is well formed and that no live session
+// already has the tmux name it maps to.
+func (m *Model) validateLabel(label string) error {
+	if !labelPattern.MatchString(label) {
+		return fmt.Errorf("invalid session name %q: use letters, digits, - and _", label)
+	}
+	sessions, err := m.manager.List()
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.Name == labeledName(label) {
+			return fmt.Errorf("session name %q is already in use", label)
+		}
+	}
+	return nil
+}
+
+// labelSession renames a freshly created session after its label and
+// remembers the label for display. If the rename fails the session is
+// killed so /new either fully succeeds or leaves nothing behind.
+func (m *Model) labelSession(session tmux.Session, label string) (tmux.Session, error) {
+	name := labeledName(label)
+	if err := m.manager.Rename(session.Name, name); err != nil {
+		_ = m.manager.Kill(session.Name)
+		return tmux.Session{}, err
+	}
+	if m.sessionLabels == nil {
+		m.sessionLabels = make(map[string]string)
+	}
+	m.sessionLabels[name] = label
+	return tmux.Session{Name: name}, nil
+}
+
+// displayName is how a session is shown: its label if it has one,
+// otherwise the tmux name.
+func (m Model) displayName(name string) string {
+	if label, ok := m.sessionLabels[name]; ok {
+		return label
+	}
+	return name
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"hiho/internal/tmux"
+)
+
+// maxListedName caps how much of a session name /list and /sessions show.
+const maxListedName = 40
+
+// formatSessionList renders sessions as a count header followed by one
+// numbered, right-aligned entry per line. Long names are truncated.
+func formatSessionList(sessions []tmux.Session) string {
+	noun := "sessions"
+	if len(sessions) == 1 {
+		noun = "session"
+	}
+	width := len(fmt.Sprint(len(sessions)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s", len(sessions), noun)
+	for i, session := range sessions {
+		fmt.Fprintf(&b, "\n%*d. %s", width, i+1, truncateName(session.Name, maxListedName))
+	}
+	return b.String()
+}
+
+// truncateName shortens name to at most n runes, marking the cut with "…".
+func truncateName(name string, n int) string {
+	runes := []rune(name)
+	if len(runes) <= n {
+		return name
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+func TestFormatSessionListManySessions(t *testing.T) {
+	var sessions []tmux.Session
+	for i := range 12 {
+		sessions = append(sessions, tmux.Session{Name: fmt.Sprintf("hiho-123-%d", i)})
+	}
+	sessions[11].Name = "hiho-" + strings.Repeat("x", 60)
+
+	lines := strings.Split(formatSessionList(sessions), "\n")
+	if len(lines) != 13 {
+		t.Fatalf("expected header plus 12 lines, got %d", len(lines))
+	}
+	want := map[int]string{
+		0:  "12 sessions",
+		1:  " 1. hiho-123-0",
+		10: "10. hiho-123-9",
+		12: "12. hiho-" + strings.Repeat("x", 34) + "…",
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("line %d = %q, want %q", i, lines[i], line)
+		}
+	}
+}
+
+func TestFormatSessionListSingular(t *testing.T) {
+	got := formatSessionList([]tmux.Session{{Name: "main"}})
+	if got != "1 session\n1. main" {
+		t.Fatalf("unexpected list %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"errors"
+
+	"hiho/internal/history"
+	"hiho/internal/tmux"
+)
+
+func (m *Model) captureCurrentSession() error {
+	if m.currentSession == "" {
+		return tmux.ErrSessionNotFound
+	}
+	output, err := m.capture(m.currentSession, m.viewport.Width, m.viewport.Height)
+	if errors.Is(err, tmux.ErrSessionNotFound) {
+		return m.dropMissingSession(m.currentSession)
+	}
+	var sessionErr *tmux.SessionError
+	if errors.As(err, &sessionErr) && sessionErr.Op == tmux.OpCapture {
+		m.setSessionStatus(sessionErr.Name, statusFailed)
+	}
+	if err != nil {
+		return err
+	}
+	m.setSessionLog(output)
+	m.appendCapture(output)
+	return nil
+}
+
+// appendMessage adds a message to the current session's conversation, or
+// to the global one when no session is active.
+func (m *Model) appendMessage(role, content string) {
+	m.addMessage(Message{Role: role, Content: content})
+	if m.historyStore != nil {
+		// A failed write only costs persistence, not the message
+		_ = m.historyStore.Append(history.Entry{Session: m.currentSession, Role: role, Content: content})
+	}
+}
+
+// appendCapture adds a screen capture of the current session to its
+// conversation. Captures are not saved: they are large, and a fresh one is
+// taken whenever the session is shown again.
+func (m *Model) appendCapture(output string) {
+	m.addMessage(Message{Role: m.currentSession, Content: output, unsaved: true})
+}
+
+func (m *Model) addMessage(message Message) {
+	if m.messages == nil {
+		m.messages = make(map[string][]Message)
+	}
+	m.messageSeq++
+	message.seq = m.messageSeq
+	m.messages[m.currentSession] = append(m.messages[m.currentSession], message)
+	m.markChanged(changedMessages)
+}
+
+// appendNotice adds a message that belongs to no session, such as /help,
+// /list, config warnings and errors. Notices show in every conversation
+// and are not saved to the history.
+func (m *Model) appendNotice(role, content string) {
+	m.messageSeq++
+	m.notices = append(m.notices, Message{Role: role, Content: content, seq: m.messageSeq})
+	m.markChanged(changedMessages)
+}
+
+// conversation returns the messages shown on the conversation tab: the
+// current session's, or the global ones when no session is active, with
+// the notices in between in the order they were added.
+func (m Model) conversation() []Message {
+	messages := m.messages[m.currentSession]
+	if len(m.notices) == 0 {
+		return messages
+	}
+	merged := make([]Message, 0, len(messages)+len(m.notices))
+	i, j := 0, 0
+	for i < len(messages) || j < len(m.notices) {
+		if j == len(m.notices) || (i < len(messages) && messages[i].seq < m.notices[j].seq) {
+			merged = append(merged, messages[i])
+			i++
+		} else {
+			merged = append(merged, m.notices[j])
+			j++
+		}
+	}
+	return merged
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConversationRendersMessageSeparatorAndIndent(t *testing.T) {
+	cfg := testConfig()
+	cfg.Conversation.MessageSpacing = 1
+	cfg.Conversation.RoleIndent = map[string]int{"info": 2}
+	model := NewModel(&stubManager{}, cfg)
+
+	model.appendMessage("user", "first")
+	model.appendMessage("info", "second\nline")
+
+	body := model.renderBody()
+	if !strings.Contains(body, "first\n\n") {
+		t.Fatalf("expected a blank line between messages, got %q", body)
+	}
+	if !strings.Contains(body, "\n  ") || !strings.HasSuffix(body, "\n  line") {
+		t.Fatalf("expected info message to be indented, got %q", body)
+	}
+	if strings.HasPrefix(body, " ") {
+		t.Fatalf("expected user message not to be indented, got %q", body)
+	}
+}
+
+func TestConversationIsScopedToCurrentSession(t *testing.T) {
+	model := NewModel(&stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}, testConfig())
+	note := func(session, text string) {
+		t.Helper()
+		model.currentSession = session
+		if _, err := model.handleSubmit(text); err != nil {
+			t.Fatalf("handleSubmit(%q): %v", text, err)
+		}
+	}
+	note("", "global note")
+	note("hiho-123-0", "note for zero")
+	note("hiho-123-1", "note for one")
+
+	tests := []struct {
+		session string
+		want    string
+		notWant []string
+	}{
+		{"", "global note", []string{"note for zero", "note for one"}},
+		{"hiho-123-0", "note for zero", []string{"global note", "note for one"}},
+		{"hiho-123-1", "note for one", []string{"global note", "note for zero"}},
+	}
+	for _, tt := range tests {
+		model.currentSession = tt.session
+		body := model.renderBody()
+		if !strings.Contains(body, tt.want) {
+			t.Errorf("session %q: expected %q in conversation, got %q", tt.session, tt.want, body)
+		}
+		for _, other := range tt.notWant {
+			if strings.Contains(body, other) {
+				t.Errorf("session %q: expected %q not shown, got %q", tt.session, other, body)
+			}
+		}
+	}
+}
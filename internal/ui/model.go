@@ -1,14 +1,13 @@
 package ui
 
 import (
-	"fmt"
-	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 
+	"hiho/internal/clipboard"
 	"hiho/internal/config"
 	"hiho/internal/tmux"
 )
@@ -32,598 +31,211 @@ const (
 type Message struct {
 	Role    string
 	Content string
+	seq     int  // position among all messages and notices, for interleaving
+	unsaved bool // kept out of the saved history, like screen captures
 }
 
 // Model drives the TUI.
 type Model struct {
 	manager        tmux.SessionManager
 	config         config.Config
-	messages       []Message
+	messages       map[string][]Message // session name -> its conversation, "" for the global one
+	notices        []Message            // shown in every conversation, see appendNotice
+	messageSeq     int                  // last Message.seq handed out
 	currentSession string
 	sessionLog     string
+	tabs           []tab
 	activeTab      tabType
 	focus          focusArea
 	input          textinput.Model
 	viewport       viewport.Model
 	width          int
 	height         int
-	sessions       []tmux.Session // cached session list
-	sessionIndex   int            // selected session in sidebar
+	sessions       []tmux.Session           // cached session list
+	sessionIndex   int                      // selected session in sidebar
+	sessionTags    map[string]string        // session name -> group shown as a sidebar header
+	sessionLabels  map[string]string        // session name -> display label from /new --name
+	sessionCmds    map[string]string        // session name -> command it was started with
+	sessionEnv     map[string][]string      // session name -> KEY=VALUE pairs it was started with
+	capturedAt     time.Time                // when sessionLog was captured
+	sessionStatus  map[string]sessionStatus // status shown in the session bar
+	showEscapes    bool                     // render control bytes visibly (debug aid)
+	pendingConfirm *confirmation            // yes/no prompt awaiting an answer
+	changes        changeSet                // what the current update touched
+	selecting      bool                     // mouse drag selection in progress
+	refreshStates  map[string]*refreshState // auto-refresh backoff per session
+	paneSizes      map[string]paneSize      // tmux window size last applied per session
+	diff           diffView                 // /diff state
+	history        captureHistory           // output accumulated across captures
+	inputHistory   inputHistory             // submitted inputs for up/down recall
+	split          *splitView               // second main pane from /split, nil when closed
+	tail           tailView                 // /tailn: show only the last lines
+	paste          *pasteJob                // /paste still typing, nil when idle
+	queued         []tea.Cmd                // commands from handlers, run after this update
+	lastActivity   time.Time                // last key or mouse input, for idle_timeout
+	clock          time.Time                // time shown in the status bar, set by refresh ticks
+	idleWarned     bool                     // idle warning already shown
+	previewSeq     int                      // latest sidebar preview request
+	paused         bool                     // auto-refresh and previews suspended
+	scrolledBack   map[tabType]bool         // main panel tabs not following the newest output
+	filter         sidebarFilter            // narrows the sessions the sidebar lists
+	profile        string                   // active config profile, "" for config.yaml
+	loadProfile    func(name string) (config.Config, error)
+	loadConfig     func(path string) (config.Config, error)
+	listProfiles   func() ([]string, error)
+	profileFile    func(name string) string
+	configPath     string // file the resize keys save the layout to, "" to not save
+	saveLayout     func(path string, layout config.Layout) error
+	layoutResizes  int  // resize key presses so far; only the latest one's tick saves
+	layoutUnsaved  bool // the layout changed since it was last saved
+	clipboard      Clipboard
+	events         EventEmitter // nil unless --events is set
+	historyStore   HistoryStore // nil unless persist_history is on
+}
+
+// Clipboard receives text copied from the TUI.
+type Clipboard interface {
+	Copy(text string) (clipboard.Result, error)
 }
 
 // NewModel constructs the UI model.
-func NewModel(manager tmux.SessionManager, cfg config.Config) Model {
+func NewModel(manager tmux.SessionManager, cfg config.Config, opts ...Option) Model {
 	input := textinput.New()
 	input.Placeholder = "/new <cmd> or type a note"
 	input.Prompt = "> "
+	input.MultiLine = true
 	input.Focus()
 
 	vp := viewport.New(0, 0)
-	return Model{
-		manager:   manager,
-		config:    cfg,
-		activeTab: tabConversation,
-		focus:     focusInput,
-		input:     input,
-		viewport:  vp,
-	}
+	m := Model{
+		manager:      manager,
+		config:       cfg,
+		tabs:         defaultTabs(),
+		activeTab:    tabConversation,
+		focus:        focusInput,
+		input:        input,
+		viewport:     vp,
+		clipboard:    clipboard.NewSystem(),
+		tail:         tailView{lines: max(cfg.TailLines, 0)},
+		lastActivity: time.Now(),
+		clock:        time.Now(),
+		loadProfile:  config.LoadProfile,
+		loadConfig:   config.LoadConfigFrom,
+		listProfiles: config.Profiles,
+		profileFile:  config.ProfileFile,
+		saveLayout:   config.SaveLayout,
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
 }
 
 // Init implements tea.Model.
 func (m Model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, refreshTick(m.refreshInterval()))
 }
 
-// sidebarWidth calculates the sidebar width (1/3 of total).
+// sidebarWidth calculates the sidebar width from the layout ratio (a third
+// of the total by default).
 func (m Model) sidebarWidth() int {
-	return m.width / 3
+	ratio := validRatio(m.config.Layout.SidebarRatio, config.DefaultLayout().SidebarRatio)
+	return ratioColumns(ratio, m.width)
 }
 
-// mainWidth calculates the main panel width (2/3 of total).
+// mainWidth calculates the main panel width (the rest of the total).
 func (m Model) mainWidth() int {
 	return m.width - m.sidebarWidth()
 }
 
 // bodyHeight calculates the height for sidebar and main panels.
 func (m Model) bodyHeight() int {
-	return m.height - 4 // Reserve 4 rows for input panel
+	// Reserve rows for session bar, input panel (border, input and help
+	// line) and status bar
+	return m.height - 3 - m.inputHeight() - sessionBarHeight - statusBarHeight
 }
 
-// Update implements tea.Model.
+// Update implements tea.Model. Handlers only record what they changed; the
+// viewport is re-rendered once at the end.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		key := msg.String()
-
-		// Check configurable keybindings first
-		switch key {
-		case m.config.KeyBindings.Quit:
-			return m, tea.Quit
-		case m.config.KeyBindings.ToggleTab:
-			m.toggleTab()
-			m.refreshViewport()
-			return m, nil
-		case m.config.KeyBindings.NextSession:
-			if err := m.navigateSession(1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-			return m, nil
-		case m.config.KeyBindings.PrevSession:
-			if err := m.navigateSession(-1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-			return m, nil
-		case m.config.KeyBindings.CycleWindows:
-			// Cycle focus between sidebar, main, input
-			switch m.focus {
-			case focusSidebar:
-				m.focus = focusMain
-			case focusMain:
-				m.focus = focusInput
-				m.input.Focus()
-			case focusInput:
-				m.focus = focusSidebar
-				m.input.Blur()
-			}
-			return m, nil
-		}
-
-		// Handle focus-specific keys
-		switch m.focus {
-		case focusSidebar:
-			switch key {
-			case m.config.KeyBindings.SessionUp, "up", "k":
-				m.selectPrevSession()
-				return m, nil
-			case m.config.KeyBindings.SessionDown, "down", "j":
-				m.selectNextSession()
-				return m, nil
-			case "enter":
-				m.activateSelectedSession()
-				return m, nil
-			}
-		case focusInput:
-			switch key {
-			case "enter":
-				value := strings.TrimSpace(m.input.Value())
-				if value != "" {
-					if err := m.handleSubmit(value); err != nil {
-						m.appendMessage("error", err.Error())
-					}
-					m.input.Reset()
-					m.refreshViewport()
-				}
-				return m, nil
-			default:
-				var cmd tea.Cmd
-				m.input, cmd = m.input.Update(msg)
-				return m, cmd
-			}
-		}
-
-		// Legacy key handling for backward compatibility
-		switch key {
-		case "alt+h":
-			if err := m.navigateSession(-1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-		case "alt+l":
-			if err := m.navigateSession(1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-		case "alt+j":
-			if err := m.navigateSession(-1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-		case "alt+k":
-			if err := m.navigateSession(1); err != nil {
-				m.appendMessage("error", err.Error())
-			}
-		}
-
-	case tea.MouseMsg:
-		m.handleMouse(msg)
-
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		// Update viewport dimensions for the main panel
-		m.viewport.Width = m.mainWidth() - 4  // Account for borders
-		m.viewport.Height = m.bodyHeight() - 4 // Account for borders and tab bar
-		m.refreshSessions()
+	m.changes = 0
+	inputHeight := m.inputHeight()
+	m, cmd := m.update(msg)
+	if m.inputHeight() != inputHeight && m.height > 0 {
+		// The input box grew or shrank; give the body the rest
+		m.layoutViewports()
+	}
+	if len(m.queued) > 0 {
+		cmd = tea.Batch(append(m.queued, cmd)...)
+		m.queued = nil
+	}
+	if m.changes.needsRedraw() {
 		m.refreshViewport()
 	}
-
-	return m, nil
+	return m, cmd
 }
 
-func (m *Model) handleMouse(msg tea.MouseMsg) {
-	if msg.Type != tea.MouseLeft {
-		return
-	}
-
-	sidebarW := m.sidebarWidth()
-	bodyH := m.bodyHeight()
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case previewMsg:
+		m.handlePreview(msg)
+		return m, nil
 
-	// Click in sidebar?
-	if msg.X < sidebarW && msg.Y > 0 && msg.Y < bodyH {
-		// Header row is at Y=1 (inside border), sessions start at Y=2
-		sessionIdx := msg.Y - 2
-		if sessionIdx >= 0 && sessionIdx < len(m.sessions) {
-			m.sessionIndex = sessionIdx
-			m.activateSelectedSession()
-			m.focus = focusSidebar
-		}
-		return
-	}
+	case attachDoneMsg:
+		m.handleAttachDone(msg)
+		return m, nil
 
-	// Click in main panel tab bar?
-	if msg.X >= sidebarW && msg.Y >= 1 && msg.Y <= 1 {
-		// Rough detection: first half = Conversation, second half = Tmux
-		tabX := msg.X - sidebarW
-		mainW := m.mainWidth()
-		if tabX < mainW/2 {
-			m.activeTab = tabConversation
-		} else {
-			m.activeTab = tabTmux
+	case layoutSaveMsg:
+		if msg.resize == m.layoutResizes {
+			m.flushLayout()
 		}
-		m.refreshViewport()
-		return
-	}
-
-	// Click in input area?
-	if msg.Y >= bodyH {
-		m.focus = focusInput
-		m.input.Focus()
-		return
-	}
-
-	// Click in main content area
-	if msg.X >= sidebarW && msg.Y > 1 && msg.Y < bodyH {
-		m.focus = focusMain
-		m.input.Blur()
-	}
-}
-
-func (m *Model) refreshSessions() {
-	sessions, err := m.manager.ListHiho()
-	if err == nil {
-		m.sessions = sessions
-	}
-}
-
-func (m *Model) selectPrevSession() {
-	if len(m.sessions) == 0 {
-		m.refreshSessions()
-	}
-	if len(m.sessions) > 0 && m.sessionIndex > 0 {
-		m.sessionIndex--
-	}
-}
-
-func (m *Model) selectNextSession() {
-	if len(m.sessions) == 0 {
-		m.refreshSessions()
-	}
-	if len(m.sessions) > 0 && m.sessionIndex < len(m.sessions)-1 {
-		m.sessionIndex++
-	}
-}
-
-func (m *Model) activateSelectedSession() {
-	if len(m.sessions) == 0 {
-		m.refreshSessions()
-	}
-	if m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) {
-		m.currentSession = m.sessions[m.sessionIndex].Name
-		m.captureCurrentSession()
-		m.activeTab = tabTmux
-		m.refreshViewport()
-	}
-}
+		return m, nil
 
-func (m *Model) toggleTab() {
-	if m.activeTab == tabConversation {
-		m.activeTab = tabTmux
-	} else {
-		m.activeTab = tabConversation
-	}
-}
-
-func (m *Model) navigateSession(delta int) error {
-	m.refreshSessions()
-	if len(m.sessions) == 0 {
-		return fmt.Errorf("no hiho sessions available")
-	}
-
-	if m.currentSession == "" {
-		m.sessionIndex = 0
-		m.currentSession = m.sessions[0].Name
-		return m.captureCurrentSession()
-	}
-
-	// Find current session index
-	for i, s := range m.sessions {
-		if s.Name == m.currentSession {
-			m.sessionIndex = i
-			break
+	case pasteChunkMsg:
+		if err := m.sendPasteChunk(); err != nil {
+			m.appendNotice("error", err.Error())
 		}
-	}
-
-	// Navigate
-	newIndex := m.sessionIndex + delta
-	if newIndex < 0 {
-		newIndex = len(m.sessions) - 1
-	} else if newIndex >= len(m.sessions) {
-		newIndex = 0
-	}
-
-	m.sessionIndex = newIndex
-	m.currentSession = m.sessions[newIndex].Name
-	return m.captureCurrentSession()
-}
-
-// View renders the TUI with 3-panel layout.
-func (m Model) View() string {
-	if m.width == 0 || m.height == 0 {
-		return "Loading..."
-	}
-
-	// Render the three panels
-	sidebar := m.renderSidebar()
-	mainPanel := m.renderMainPanel()
-
-	// Join sidebar and main panel horizontally
-	topSection := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainPanel)
-
-	// Render input panel
-	inputPanel := m.renderInputPanel()
-
-	return lipgloss.JoinVertical(lipgloss.Left, topSection, inputPanel)
-}
-
-func (m Model) renderSidebar() string {
-	w := m.sidebarWidth() - 2 // Account for border
-	h := m.bodyHeight() - 2   // Account for border
-
-	var content strings.Builder
+		return m, nil
 
-	// Title
-	titleStyle := lipgloss.NewStyle().Bold(true)
-	content.WriteString(titleStyle.Render("Sessions"))
-	content.WriteString("\n")
-
-	// Session list
-	if len(m.sessions) == 0 {
-		content.WriteString("No sessions\n")
-		content.WriteString("Use /new <cmd>")
-	} else {
-		for i, session := range m.sessions {
-			var line string
-			isSelected := i == m.sessionIndex
-			isCurrent := session.Name == m.currentSession
-
-			prefix := "  "
-			if isCurrent {
-				prefix = "> "
-			}
-
-			name := session.Name
-			// Truncate if too long
-			maxLen := w - 4
-			if len(name) > maxLen && maxLen > 3 {
-				name = name[:maxLen-3] + "..."
-			}
-
-			line = prefix + name
-
-			if isSelected && m.focus == focusSidebar {
-				// Highlighted with inverted colors
-				line = lipgloss.NewStyle().Reverse(true).Render(line)
-			} else if isCurrent {
-				// Current session in bold
-				line = lipgloss.NewStyle().Bold(true).Render(line)
-			}
-
-			content.WriteString(line)
-			if i < len(m.sessions)-1 {
-				content.WriteString("\n")
-			}
+	case refreshTickMsg:
+		now := time.Time(msg)
+		m.clock = now
+		if cmd := m.checkIdle(now); cmd != nil {
+			return m, cmd
 		}
-	}
-
-	// Apply border and fixed dimensions
-	style := lipgloss.NewStyle().
-		Border(true).
-		Width(w).
-		Height(h)
-
-	return style.Render(content.String())
-}
-
-func (m Model) renderMainPanel() string {
-	w := m.mainWidth() - 2   // Account for border
-	h := m.bodyHeight() - 2  // Account for border
-
-	var content strings.Builder
-
-	// Tab bar
-	tabBar := m.renderTabBar()
-	content.WriteString(tabBar)
-	content.WriteString("\n")
-
-	// Main content (viewport)
-	body := m.viewport.View()
-	content.WriteString(body)
-
-	// Apply border and fixed dimensions
-	style := lipgloss.NewStyle().
-		Border(true).
-		Width(w).
-		Height(h)
-
-	return style.Render(content.String())
-}
-
-func (m Model) renderTabBar() string {
-	activeStyle := lipgloss.NewStyle().Bold(true).Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230")).Padding(0, 1)
-	inactiveStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250")).Padding(0, 1)
-
-	var convTab, tmuxTab string
-	if m.activeTab == tabConversation {
-		convTab = activeStyle.Render("Conversation")
-		tmuxTab = inactiveStyle.Render("Tmux Window")
-	} else {
-		convTab = inactiveStyle.Render("Conversation")
-		tmuxTab = activeStyle.Render("Tmux Window")
-	}
-
-	sessionInfo := ""
-	if m.currentSession != "" {
-		sessionInfo = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(
-			fmt.Sprintf(" • %s", m.currentSession),
-		)
-	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Top, convTab, " ", tmuxTab, sessionInfo)
-}
-
-func (m Model) renderInputPanel() string {
-	w := m.width - 2 // Account for border
-
-	var content strings.Builder
-
-	// Input line
-	content.WriteString(m.input.View())
-	content.WriteString("\n")
-
-	// Help line
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	helpText := fmt.Sprintf("Tab: toggle view • %s: cycle focus • ↑↓: navigate • Ctrl+C: quit",
-		m.config.KeyBindings.CycleWindows)
-	content.WriteString(helpStyle.Render(helpText))
-
-	// Apply border
-	style := lipgloss.NewStyle().
-		Border(true).
-		Width(w)
-
-	return style.Render(content.String())
-}
-
-func (m *Model) handleSubmit(input string) error {
-	if strings.HasPrefix(input, "/") {
-		if err := m.handleCommand(input); err != nil {
-			return err
+		// Only the Tmux tab shows captures, so the Conversation tab
+		// skips polling tmux.
+		if m.activeTab == tabTmux {
+			m.autoRefresh(now)
 		}
-	} else {
-		m.appendMessage("user", input)
-	}
-	return nil
-}
+		return m, refreshTick(m.refreshInterval())
 
-func (m *Model) handleCommand(input string) error {
-	parts := strings.SplitN(strings.TrimPrefix(input, "/"), " ", 2)
-	command := parts[0]
-	arg := ""
-	if len(parts) > 1 {
-		arg = strings.TrimSpace(parts[1])
-	}
-
-	switch command {
-	case "help":
-		m.appendMessage("info", commandHelp)
-	case "new":
-		if arg == "" {
-			return fmt.Errorf("usage: /new <command>")
-		}
-		session, err := m.manager.NewSession(arg)
-		if err != nil {
-			return err
-		}
-		m.currentSession = session.Name
-		m.activeTab = tabTmux
-		m.refreshSessions()
-		return m.captureCurrentSession()
-	case "next":
-		session, err := m.manager.Next(m.currentSession)
-		if err != nil {
-			return err
-		}
-		m.currentSession = session.Name
-		m.refreshSessions()
-		return m.captureCurrentSession()
-	case "prev":
-		session, err := m.manager.Prev(m.currentSession)
-		if err != nil {
-			return err
-		}
-		m.currentSession = session.Name
-		m.refreshSessions()
-		return m.captureCurrentSession()
-	case "switch":
-		if arg == "" {
-			if m.activeTab == tabTmux {
-				return m.navigateSession(1)
-			}
-			return fmt.Errorf("usage: /switch <session> (or use without arg in Tmux tab to cycle)")
-		}
-		session, err := m.manager.Switch(arg)
-		if err != nil {
-			return err
-		}
-		m.currentSession = session.Name
-		m.refreshSessions()
-		return m.captureCurrentSession()
-	case "list":
-		m.refreshSessions()
-		if len(m.sessions) == 0 {
-			m.appendMessage("info", "No hiho sessions found")
-			return nil
-		}
-		names := make([]string, 0, len(m.sessions))
-		for _, session := range m.sessions {
-			names = append(names, session.Name)
-		}
-		m.appendMessage("sessions", strings.Join(names, "\n"))
-	case "sessions":
-		sessions, err := m.manager.List()
-		if err != nil {
-			return err
-		}
-		names := make([]string, 0, len(sessions))
-		for _, session := range sessions {
-			names = append(names, session.Name)
-		}
-		m.appendMessage("sessions", strings.Join(names, ", "))
-	case "closeall":
-		if err := m.manager.KillAllHiho(); err != nil {
-			return err
-		}
-		if strings.HasPrefix(m.currentSession, "hiho-") {
-			m.currentSession = ""
-			m.sessionLog = ""
-		}
-		m.refreshSessions()
-		m.appendMessage("info", "All hiho sessions closed")
-	case "view":
-		switch arg {
-		case "session", "tmux":
-			m.activeTab = tabTmux
-		default:
-			m.activeTab = tabConversation
+	case tea.PasteMsg:
+		m.noteActivity(time.Now())
+		// A paste must not answer a pending y/n question
+		if m.pendingConfirm != nil {
+			return m, nil
 		}
-	default:
-		return fmt.Errorf("unknown command: %s", command)
-	}
-	return nil
-}
-
-func (m *Model) captureCurrentSession() error {
-	if m.currentSession == "" {
-		return tmux.ErrSessionNotFound
-	}
-	output, err := m.manager.Capture(m.currentSession)
-	if err != nil {
-		return err
-	}
-	m.sessionLog = output
-	m.appendMessage(m.currentSession, output)
-	m.refreshViewport()
-	return nil
-}
-
-func (m *Model) appendMessage(role, content string) {
-	m.messages = append(m.messages, Message{Role: role, Content: content})
-	m.refreshViewport()
-}
+		m.focus = focusInput
+		m.input.Focus()
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
 
-func (m *Model) refreshViewport() {
-	content := m.renderBody()
-	m.viewport.SetContent(content)
-}
+	case tea.KeyMsg:
+		return m.handleKey(msg)
 
-func (m *Model) renderBody() string {
-	if m.activeTab == tabTmux {
-		if m.currentSession == "" {
-			return "No active session. Use /new <command> to create one."
-		}
-		header := lipgloss.NewStyle().Bold(true).Render(m.currentSession)
-		return lipgloss.JoinVertical(lipgloss.Left, header, strings.TrimSpace(m.sessionLog))
-	}
+	case tea.MouseMsg:
+		m.noteActivity(time.Now())
+		m.handleMouse(msg)
 
-	// Conversation view
-	if len(m.messages) == 0 {
-		return "Welcome to hiho!\n" + commandHelp
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		// Update viewport dimensions for the main panel
+		m.layoutViewports()
+		m.refreshSessions()
 	}
 
-	var builder strings.Builder
-	for _, message := range m.messages {
-		role := lipgloss.NewStyle().Bold(true).Render(message.Role + ":")
-		builder.WriteString(role)
-		builder.WriteString(" ")
-		builder.WriteString(strings.TrimSpace(message.Content))
-		builder.WriteString("\n")
-	}
-	return strings.TrimSuffix(builder.String(), "\n")
+	return m, nil
 }
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	switch msg.Type {
+	case tea.MouseMotion:
+		if m.selecting {
+			m.viewport.ExtendSelection(m.viewportCell(msg))
+		}
+		return
+	case tea.MouseRelease:
+		if m.selecting {
+			m.selecting = false
+			m.copySelection()
+		}
+		return
+	case tea.MouseLeft:
+	default:
+		return
+	}
+	// Edge clicks can arrive with negative or out-of-range coordinates;
+	// they hit nothing.
+	if !m.onScreen(msg) {
+		return
+	}
+
+	sidebarW := m.sidebarWidth()
+	bodyH := m.bodyHeight()
+
+	// Click in sidebar? Rows start inside the top border at Y=1.
+	if msg.X < sidebarW && msg.Y > 0 && msg.Y < bodyH {
+		if sessionIdx, ok := m.sessionAtRow(msg.Y - 1); ok {
+			m.sessionIndex = sessionIdx
+			m.activateSelectedSession()
+			m.focus = focusSidebar
+		}
+		return
+	}
+
+	// Click on a tab label? The tab bar is the first row inside the border.
+	if msg.X >= sidebarW && msg.Y == 1 {
+		if tab, ok := m.tabAt(msg.X - sidebarW - 1); ok {
+			m.setTab(tab)
+			return
+		}
+	}
+
+	// Click on a block in the session bar below the body?
+	if msg.Y == bodyH {
+		if sessionIdx, ok := m.sessionAtBarColumn(msg.X); ok {
+			m.sessionIndex = sessionIdx
+			m.activateSelectedSession()
+		}
+		return
+	}
+
+	// Click in input area? The status bar below it ignores clicks.
+	if msg.Y > bodyH && msg.Y < m.height-statusBarHeight {
+		m.focus = focusInput
+		m.input.Focus()
+		return
+	}
+
+	// Click in main content area starts a text selection
+	if msg.X >= sidebarW && msg.Y > 1 && msg.Y < bodyH {
+		m.focus = focusMain
+		m.input.Blur()
+		m.viewport.StartSelection(m.viewportCell(msg))
+		m.selecting = true
+	}
+}
+
+// onScreen reports whether a mouse event lies within the terminal.
+func (m Model) onScreen(msg tea.MouseMsg) bool {
+	return msg.X >= 0 && msg.Y >= 0 && msg.X < m.width && msg.Y < m.height
+}
+
+// viewportCell converts screen coordinates to a cell within the viewport,
+// which starts inside the main panel border, below the tab bar. Drags that
+// leave the viewport are clamped to its nearest edge.
+func (m Model) viewportCell(msg tea.MouseMsg) (col, row int) {
+	col = clamp(msg.X-m.sidebarWidth()-1, 0, m.viewport.Width-1)
+	row = clamp(msg.Y-2, 0, m.viewport.Height-1)
+	return col, row
+}
+
+// clamp limits v to [lo, hi]; an empty range (hi < lo) yields lo.
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
+
+// copySelection finalizes the drag selection and copies it to the clipboard.
+func (m *Model) copySelection() {
+	text := m.viewport.EndSelection()
+	if text == "" {
+		return
+	}
+	if err := m.copyText(text); err != nil {
+		m.appendNotice("error", fmt.Sprintf("copy selection: %v", err))
+	}
+}
+
+// copyText sends text to the clipboard and reports where it went, including
+// the temp file used when no clipboard utility is installed.
+func (m *Model) copyText(text string) error {
+	result, err := m.clipboard.Copy(text)
+	if err != nil {
+		return err
+	}
+	if result.Path != "" {
+		m.appendMessage("info", fmt.Sprintf("No clipboard utility found; saved %d characters to %s",
+			len([]rune(text)), result.Path))
+		return nil
+	}
+	m.appendMessage("info", fmt.Sprintf("Copied %d characters", len([]rune(text))))
+	return nil
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/clipboard"
+)
+
+type stubClipboard struct {
+	copied   []string
+	fallback string
+}
+
+func (c *stubClipboard) Copy(text string) (clipboard.Result, error) {
+	c.copied = append(c.copied, text)
+	if c.fallback != "" {
+		return clipboard.Result{Path: c.fallback}, nil
+	}
+	return clipboard.Result{Tool: "stub"}, nil
+}
+
+func TestMouseDragSelectsAndCopiesViewportText(t *testing.T) {
+	manager := &stubManager{}
+	model := sizedModel(t, manager, 90, 30)
+	clip := &stubClipboard{}
+	model.clipboard = clip
+	model.activeTab = tabTmux
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "first line\nsecond line"
+	model.refreshViewport()
+
+	// Viewport rows: 0 = session header, 1 = "first line", 2 = "second line"
+	originX := model.sidebarWidth() + 1
+	events := []tea.MouseMsg{
+		{X: originX + 6, Y: 2 + 1, Type: tea.MouseLeft},
+		{X: originX + 3, Y: 2 + 2, Type: tea.MouseMotion},
+		{X: originX + 5, Y: 2 + 2, Type: tea.MouseMotion},
+		{X: originX + 5, Y: 2 + 2, Type: tea.MouseRelease},
+	}
+	for _, event := range events {
+		updated, _ := model.Update(event)
+		model = updated.(Model)
+	}
+
+	if len(clip.copied) != 1 {
+		t.Fatalf("expected one copy, got %v", clip.copied)
+	}
+	if want := "line\nsecond"; clip.copied[0] != want {
+		t.Fatalf("copied %q, want %q", clip.copied[0], want)
+	}
+	if model.selecting {
+		t.Fatalf("expected selection to be finished after release")
+	}
+	if model.focus != focusMain {
+		t.Fatalf("expected main panel focus after clicking content")
+	}
+}
+
+func TestMouseClickWithoutDragDoesNotCopy(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 90, 30)
+	clip := &stubClipboard{}
+	model.clipboard = clip
+
+	originX := model.sidebarWidth() + 1
+	for _, event := range []tea.MouseMsg{
+		{X: originX + 2, Y: 3, Type: tea.MouseLeft},
+		{X: originX + 2, Y: 3, Type: tea.MouseRelease},
+	} {
+		updated, _ := model.Update(event)
+		model = updated.(Model)
+	}
+	if len(clip.copied) != 0 {
+		t.Fatalf("expected no copy for a plain click, got %v", clip.copied)
+	}
+}
+
+func TestCopyTextReportsFallbackPath(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.clipboard = &stubClipboard{fallback: "/tmp/hiho-clipboard-1.txt"}
+
+	if err := model.copyText("output"); err != nil {
+		t.Fatalf("copyText error: %v", err)
+	}
+	last := lastMessage(model)
+	if !strings.Contains(last.Content, "/tmp/hiho-clipboard-1.txt") {
+		t.Fatalf("expected fallback path in message, got %q", last.Content)
+	}
+}
+
+func TestMouseBoundaryCoordinatesSelectNothing(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := sizedModel(t, manager, 90, 30)
+	model.refreshSessions()
+	model.sessionIndex = 1
+
+	for _, pos := range []struct{ x, y int }{
+		{-1, -1}, {-5, 2}, {1, -3}, {0, 0},
+		{90, 2}, {1, 30}, {200, 200}, {0, model.bodyHeight() - 1},
+	} {
+		updated, _ := model.Update(tea.MouseMsg{X: pos.x, Y: pos.y, Type: tea.MouseLeft})
+		model = updated.(Model)
+		if model.sessionIndex != 1 || model.currentSession != "" {
+			t.Fatalf("click at (%d,%d) selected session %d (%q)", pos.x, pos.y, model.sessionIndex, model.currentSession)
+		}
+		if model.selecting {
+			t.Fatalf("click at (%d,%d) started a selection", pos.x, pos.y)
+		}
+	}
+}
+
+func TestMouseDragOutsideViewportIsClamped(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 90, 30)
+	model.clipboard = &stubClipboard{}
+	model.activeTab = tabTmux
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "first line\nsecond line"
+	model.refreshViewport()
+
+	for _, event := range []tea.MouseMsg{
+		{X: model.sidebarWidth() + 2, Y: 3, Type: tea.MouseLeft},
+		{X: -10, Y: -10, Type: tea.MouseMotion},
+		{X: 500, Y: 500, Type: tea.MouseMotion},
+		{X: 500, Y: 500, Type: tea.MouseRelease},
+	} {
+		updated, _ := model.Update(event)
+		model = updated.(Model)
+	}
+	if model.selecting {
+		t.Fatal("expected selection to end on release")
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import "strings"
+
+// maxInputLines caps how many rows the input box grows to; a longer entry
+// scrolls to keep the cursor's row in view.
+const maxInputLines = 5
+
+// inputHeight returns how many rows the input line takes: one per line of
+// the entry (alt+enter starts a new one), up to maxInputLines.
+func (m Model) inputHeight() int {
+	return min(m.input.Lines(), maxInputLines)
+}
+
+// inputView renders the input line, cut to the inputHeight rows around the
+// cursor.
+func (m Model) inputView() string {
+	view := m.input.View()
+	rows := strings.Split(view, "\n")
+	if len(rows) <= maxInputLines {
+		return view
+	}
+	start := min(max(m.input.CursorLine()-maxInputLines+1, 0), len(rows)-maxInputLines)
+	return strings.Join(rows[start:start+maxInputLines], "\n")
+}
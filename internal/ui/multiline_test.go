@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestMultiLineInputGrowsInputPanel(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 100, 30)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, key := range keys {
+			updated, _ := model.Update(tea.KeyMsg{Type: key})
+			model = updated.(Model)
+		}
+	}
+	height := model.viewport.Height
+
+	press("l", "s", "alt+enter", "|", " ", "w", "c")
+	if got := model.input.Value(); got != "ls\n| wc" {
+		t.Fatalf("input = %q, want a line break from alt+enter", got)
+	}
+	if model.viewport.Height != height-1 {
+		t.Fatalf("viewport height = %d, want %d to make room for the second line", model.viewport.Height, height-1)
+	}
+	view := ansi.Strip(model.View())
+	if rows := strings.Count(view, "\n") + 1; rows != 30 {
+		t.Fatalf("View() has %d rows, want the terminal height 30", rows)
+	}
+	if !strings.Contains(view, "│> ls") || !strings.Contains(view, "│  | wc") {
+		t.Fatalf("expected both input lines in the input panel, got:\n%s", view)
+	}
+
+	for range 2 * maxInputLines {
+		press("alt+enter")
+	}
+	if model.viewport.Height != height-(maxInputLines-1) {
+		t.Fatalf("viewport height = %d, want the input capped at %d rows", model.viewport.Height, maxInputLines)
+	}
+
+	press("enter")
+	if model.input.Value() != "" || model.viewport.Height != height {
+		t.Fatalf("expected submitting to restore the layout, got input %q and height %d", model.input.Value(), model.viewport.Height)
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// stepIndex moves index by delta within the session list. With nav_wrap
+// it wraps around at either end; otherwise it stops at the first or last
+// session. An empty list always yields 0.
+func (m *Model) stepIndex(index, delta int) int {
+	return step(index, delta, len(m.sessions), m.config.NavWrap)
+}
+
+// step moves index by delta within n items, wrapping or stopping at the
+// ends. No items always yields 0.
+func step(index, delta, n int, wrap bool) int {
+	if n == 0 {
+		return 0
+	}
+	next := index + delta
+	if wrap {
+		return ((next % n) + n) % n
+	}
+	return min(max(next, 0), n-1)
+}
+
+func (m *Model) selectPrevSession() {
+	if len(m.sessions) == 0 {
+		m.refreshSessions()
+	}
+	m.sessionIndex = m.stepVisible(m.sessionIndex, -1)
+}
+
+func (m *Model) selectNextSession() {
+	if len(m.sessions) == 0 {
+		m.refreshSessions()
+	}
+	m.sessionIndex = m.stepVisible(m.sessionIndex, 1)
+}
+
+func (m *Model) activateSelectedSession() {
+	if len(m.sessions) == 0 {
+		m.refreshSessions()
+	}
+	if m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) && m.matches(m.sessionIndex) {
+		m.currentSession = m.sessions[m.sessionIndex].Name
+		m.captureCurrentSession()
+		m.switchToTmuxFor("activate")
+	}
+}
+
+// switchToTmuxFor jumps to the Tmux tab if the trigger is configured to do so.
+func (m *Model) switchToTmuxFor(trigger string) {
+	if slices.Contains(m.config.TabSwitchCommands, trigger) {
+		m.setTab(tabTmux)
+	}
+}
+
+func (m *Model) navigateSession(delta int) error {
+	m.refreshSessions()
+	if len(m.sessions) == 0 {
+		return fmt.Errorf("no hiho sessions available")
+	}
+
+	if m.currentSession == "" {
+		m.sessionIndex = 0
+		m.currentSession = m.sessions[0].Name
+		return m.captureCurrentSession()
+	}
+
+	// Find current session index
+	for i, s := range m.sessions {
+		if s.Name == m.currentSession {
+			m.sessionIndex = i
+			break
+		}
+	}
+
+	// Navigate
+	newIndex := m.stepIndex(m.sessionIndex, delta)
+
+	m.sessionIndex = newIndex
+	m.currentSession = m.sessions[newIndex].Name
+	return m.captureCurrentSession()
+}
@@ -0,0 +1,124 @@
+package ui
+
+import "testing"
+
+func navModel(wrap bool) *Model {
+	cfg := testConfig()
+	cfg.NavWrap = wrap
+	manager := &stubManager{
+		sessions:     []string{"hiho-1-0", "hiho-1-1", "hiho-1-2"},
+		outputByName: map[string]string{"hiho-1-0": "a", "hiho-1-1": "b", "hiho-1-2": "c"},
+	}
+	model := NewModel(manager, cfg)
+	model.refreshSessions()
+	return &model
+}
+
+func TestArrowNavigationAtBoundaries(t *testing.T) {
+	tests := []struct {
+		name  string
+		wrap  bool
+		start int
+		next  bool
+		want  int
+	}{
+		{"wrap past last", true, 2, true, 0},
+		{"wrap before first", true, 0, false, 2},
+		{"clamp at last", false, 2, true, 2},
+		{"clamp at first", false, 0, false, 0},
+		{"middle moves either way", false, 1, true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := navModel(tt.wrap)
+			model.sessionIndex = tt.start
+			if tt.next {
+				model.selectNextSession()
+			} else {
+				model.selectPrevSession()
+			}
+			if model.sessionIndex != tt.want {
+				t.Fatalf("expected index %d, got %d", tt.want, model.sessionIndex)
+			}
+		})
+	}
+}
+
+func TestNavigateSessionAtBoundaries(t *testing.T) {
+	tests := []struct {
+		name    string
+		wrap    bool
+		current string
+		delta   int
+		want    string
+	}{
+		{"wrap past last", true, "hiho-1-2", 1, "hiho-1-0"},
+		{"wrap before first", true, "hiho-1-0", -1, "hiho-1-2"},
+		{"clamp at last", false, "hiho-1-2", 1, "hiho-1-2"},
+		{"clamp at first", false, "hiho-1-0", -1, "hiho-1-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := navModel(tt.wrap)
+			model.currentSession = tt.current
+			if err := model.navigateSession(tt.delta); err != nil {
+				t.Fatalf("navigateSession error: %v", err)
+			}
+			if model.currentSession != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, model.currentSession)
+			}
+		})
+	}
+}
+
+func TestNavigateSessionCyclesForward(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"},
+		outputByName: map[string]string{"hiho-123-0": "out0", "hiho-123-1": "out1", "hiho-123-2": "out2"},
+	}
+
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+
+	if err := model.navigateSession(1); err != nil {
+		t.Fatalf("navigateSession error: %v", err)
+	}
+	if model.currentSession != "hiho-123-1" {
+		t.Fatalf("expected hiho-123-1, got %q", model.currentSession)
+	}
+}
+
+func TestNavigateSessionCyclesBackward(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"},
+		outputByName: map[string]string{"hiho-123-0": "out0", "hiho-123-1": "out1", "hiho-123-2": "out2"},
+		currentIndex: 1,
+	}
+
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-1"
+
+	if err := model.navigateSession(-1); err != nil {
+		t.Fatalf("navigateSession error: %v", err)
+	}
+	if model.currentSession != "hiho-123-0" {
+		t.Fatalf("expected hiho-123-0, got %q", model.currentSession)
+	}
+}
+
+func TestNavigateSessionSelectsFirstWhenNoCurrent(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1"},
+		outputByName: map[string]string{"hiho-123-0": "out0", "hiho-123-1": "out1"},
+	}
+
+	model := NewModel(manager, testConfig())
+	// No current session set
+
+	if err := model.navigateSession(1); err != nil {
+		t.Fatalf("navigateSession error: %v", err)
+	}
+	if model.currentSession != "hiho-123-0" {
+		t.Fatalf("expected hiho-123-0, got %q", model.currentSession)
+	}
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hiho/internal/envfile"
+)
+
+const newUsage = "usage: /new [--name <label>] [--env-file <path>] [-C <dir>] [--force] [KEY=VALUE...] <command>"
+
+// newOptions holds the flags accepted by /new ahead of the command.
+type newOptions struct {
+	name    string
+	envFile string
+	env     []string // from envFile, then KEY=VALUE prefixes
+	dir     string   // working directory, "" for hiho's own
+	command string
+	tag     string // sidebar group, set by /open
+	force   bool   // allow a command that launches hiho itself
+}
+
+// parseNewArgs splits leading /new flags from the command. The command
+// keeps its original spacing so it reaches the shell unchanged.
+func parseNewArgs(arg string) (newOptions, error) {
+	var opts newOptions
+	rest := strings.TrimSpace(arg)
+	for strings.HasPrefix(rest, "-") {
+		flag, remainder := nextToken(rest)
+		switch flag {
+		case "--name":
+			opts.name, remainder = nextToken(remainder)
+			if opts.name == "" {
+				return opts, fmt.Errorf("--name requires a label")
+			}
+		case "--env-file":
+			opts.envFile, remainder = nextToken(remainder)
+			if opts.envFile == "" {
+				return opts, fmt.Errorf("--env-file requires a path")
+			}
+		case "-C", "--dir":
+			opts.dir, remainder = nextToken(remainder)
+			if opts.dir == "" {
+				return opts, fmt.Errorf("%s requires a directory", flag)
+			}
+		case "--force":
+			opts.force = true
+		default:
+			return opts, fmt.Errorf("unknown /new flag: %s", flag)
+		}
+		rest = remainder
+	}
+	for {
+		assignment, remainder, ok := leadingAssignment(rest)
+		if !ok {
+			break
+		}
+		opts.env = append(opts.env, assignment)
+		rest = remainder
+	}
+	opts.command = rest
+	return opts, nil
+}
+
+// leadingAssignment splits a KEY=VALUE word off the front of s. The value
+// may be wrapped in single or double quotes to include spaces.
+func leadingAssignment(s string) (string, string, bool) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || !envfile.ValidKey(key) {
+		return "", s, false
+	}
+	end := strings.IndexByte(value, ' ')
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		closing := strings.IndexByte(value[1:], value[0])
+		if closing < 0 {
+			return "", s, false
+		}
+		end = closing + 2
+		if end < len(value) && value[end] != ' ' {
+			return "", s, false
+		}
+	}
+	if end < 0 {
+		end = len(value)
+	}
+	return key + "=" + envfile.Unquote(value[:end]), strings.TrimSpace(value[end:]), true
+}
+
+// handleNew implements /new [flags] <command>.
+func (m *Model) handleNew(arg string) error {
+	opts, err := parseNewArgs(arg)
+	if err != nil {
+		return err
+	}
+	if opts.command == "" {
+		return fmt.Errorf(newUsage)
+	}
+	if !opts.force && launchesHiho(opts.command) {
+		return fmt.Errorf("refusing to run hiho inside hiho; use /new --force to do it anyway")
+	}
+	if opts.name != "" {
+		if err := m.validateLabel(opts.name); err != nil {
+			return err
+		}
+	}
+	if opts.envFile != "" {
+		fileEnv, err := envfile.Load(opts.envFile)
+		if err != nil {
+			return fmt.Errorf("env file: %w", err)
+		}
+		// Inline assignments come last so they override the file
+		opts.env = append(fileEnv, opts.env...)
+	}
+	if opts.dir != "" {
+		if opts.dir, err = resolveDir(opts.dir); err != nil {
+			return err
+		}
+	}
+	if m.needsConfirm(opts.command) {
+		m.requestConfirm(fmt.Sprintf("Run %q?", opts.command), func(m *Model) error {
+			return m.createSession(opts)
+		})
+		return nil
+	}
+	return m.createSession(opts)
+}
+
+// resolveDir expands a leading ~ in dir, makes it absolute and checks that
+// it is an existing directory.
+func resolveDir(dir string) (string, error) {
+	if rest, ok := strings.CutPrefix(dir, "~"); ok && (rest == "" || rest[0] == '/') {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("working directory: %w", err)
+		}
+		dir = home + rest
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("working directory: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("working directory %s does not exist", dir)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("working directory %s is not a directory", dir)
+	}
+	return abs, nil
+}
+
+// nextToken returns the first whitespace-delimited token and the trimmed rest.
+func nextToken(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	token, rest, _ := strings.Cut(s, " ")
+	return token, strings.TrimSpace(rest)
+}
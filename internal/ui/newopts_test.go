@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseNewArgs(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    newOptions
+		wantErr bool
+	}{
+		{input: "npm start", want: newOptions{command: "npm start"}},
+		{input: "--env-file .env npm  run dev", want: newOptions{envFile: ".env", command: "npm  run dev"}},
+		{input: "--name web --env-file .env npm start", want: newOptions{name: "web", envFile: ".env", command: "npm start"}},
+		{input: "-C /src/app make test", want: newOptions{dir: "/src/app", command: "make test"}},
+		{input: "--dir ~/proj --name web npm start", want: newOptions{dir: "~/proj", name: "web", command: "npm start"}},
+		{input: "NODE_ENV=dev npm start", want: newOptions{env: []string{"NODE_ENV=dev"}, command: "npm start"}},
+		{input: "--name web A=1 B='x y' C=\"\" make", want: newOptions{name: "web", env: []string{"A=1", "B=x y", "C="}, command: "make"}},
+		{input: "A=1 echo B=2", want: newOptions{env: []string{"A=1"}, command: "echo B=2"}},
+		{input: "A='open make", want: newOptions{command: "A='open make"}},
+		{input: "1A=x make", want: newOptions{command: "1A=x make"}},
+		{input: "-C", wantErr: true},
+		{input: "--env-file", wantErr: true},
+		{input: "--bogus ls", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseNewArgs(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseNewArgs(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("parseNewArgs(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNewWithEnvFileExportsVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.env")
+	content := "# comment\nNODE_ENV=production\n\nGREETING=it's me\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write env file: %v", err)
+	}
+
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/new --env-file " + path + " npm start"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	if len(manager.created) != 1 {
+		t.Fatalf("expected one session, got %v", manager.created)
+	}
+	if manager.created[0] != "npm start" {
+		t.Fatalf("expected the bare command, got %q", manager.created[0])
+	}
+	want := []string{"NODE_ENV=production", "GREETING=it's me"}
+	if got := manager.envs[manager.sessions[0]]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected env:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestNewWithInlineEnvOverridesEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.env")
+	if err := os.WriteFile(path, []byte("NODE_ENV=production\n"), 0644); err != nil {
+		t.Fatalf("write env file: %v", err)
+	}
+
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/new --env-file " + path + " NODE_ENV=dev API_KEY=\"a b\" npm start"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	if len(manager.created) != 1 || manager.created[0] != "npm start" {
+		t.Fatalf("unexpected commands: %q", manager.created)
+	}
+	want := []string{"NODE_ENV=production", "NODE_ENV=dev", "API_KEY=a b"}
+	if got := manager.envs[manager.sessions[0]]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected env:\n got %q\nwant %q", got, want)
+	}
+	if label := model.sessionCmds[manager.sessions[0]]; label != "npm start" {
+		t.Fatalf("expected the sidebar to show the bare command, got %q", label)
+	}
+}
+
+func TestNewWithMissingEnvFileErrors(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	_, err := model.handleSubmit("/new --env-file /does/not/exist.env npm start")
+	if err == nil || !strings.Contains(err.Error(), "env file") {
+		t.Fatalf("expected env file error, got %v", err)
+	}
+	if len(manager.created) != 0 {
+		t.Fatalf("expected no session to be created, got %v", manager.created)
+	}
+}
+
+func TestNewWithNameLabelsSession(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/new --name web npm start"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	if len(manager.created) != 1 || manager.created[0] != "npm start" {
+		t.Fatalf("expected npm start to run, got %v", manager.created)
+	}
+	if model.currentSession != "hiho-web" {
+		t.Fatalf("expected session renamed to hiho-web, got %q", model.currentSession)
+	}
+	if got := model.displayName(model.currentSession); got != "web" {
+		t.Fatalf("expected display label web, got %q", got)
+	}
+	if len(model.sessions) != 1 || model.sessions[0].Name != "hiho-web" {
+		t.Fatalf("expected hiho-prefixed session in the list, got %v", model.sessions)
+	}
+}
+
+func TestNewWithNameRejectsClashAndInvalidLabels(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-web"}}
+	model := NewModel(manager, testConfig())
+
+	for _, input := range []string{"/new --name web bash", "/new --name a.b bash", "/new --name bash"} {
+		if _, err := model.handleSubmit(input); err == nil {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+	if len(manager.created) != 0 {
+		t.Fatalf("expected no sessions created, got %v", manager.created)
+	}
+}
+
+func TestNewInWorkingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/new -C " + dir + " make test"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if got := manager.dirs["hiho-123-0"]; got != dir {
+		t.Fatalf("working directory = %q, want %q", got, dir)
+	}
+	if len(manager.created) != 1 || manager.created[0] != "make test" {
+		t.Fatalf("unexpected commands %v", manager.created)
+	}
+}
+
+func TestNewInMissingDirectoryErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	for _, dir := range []string{"/does/not/exist", file} {
+		manager := &stubManager{}
+		model := NewModel(manager, testConfig())
+		_, err := model.handleSubmit("/new -C " + dir + " ls")
+		if err == nil || !strings.Contains(err.Error(), "working directory") {
+			t.Fatalf("-C %s: expected a working directory error, got %v", dir, err)
+		}
+		if len(manager.created) != 0 {
+			t.Fatalf("-C %s: expected no session, got %v", dir, manager.created)
+		}
+	}
+}
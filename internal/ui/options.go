@@ -0,0 +1,50 @@
+package ui
+
+import "hiho/internal/history"
+
+// Option configures a Model at construction.
+type Option func(*Model)
+
+// WithProfile records the config profile the model was started with, so
+// /profile can show it as active.
+func WithProfile(name string) Option {
+	return func(m *Model) { m.profile = name }
+}
+
+// WithEvents sends session created/killed/finished and capture updates to
+// e. Without it no events are produced.
+func WithEvents(e EventEmitter) Option {
+	return func(m *Model) { m.events = e }
+}
+
+// WithConfigPath names the config file the resize keys save the layout to.
+func WithConfigPath(path string) Option {
+	return func(m *Model) { m.configPath = path }
+}
+
+// WithHistory restores the conversations saved in h, each to its session,
+// and appends every new message to it. A history that cannot be read
+// starts empty.
+func WithHistory(h HistoryStore) Option {
+	return func(m *Model) {
+		m.historyStore = h
+		entries, _ := h.Load(history.DefaultLimit)
+		if len(entries) > 0 && m.messages == nil {
+			m.messages = make(map[string][]Message)
+		}
+		for _, e := range entries {
+			m.messages[e.Session] = append(m.messages[e.Session], Message{Role: e.Role, Content: e.Content})
+		}
+	}
+}
+
+// WithWarnings starts hiho with a warning notice per entry, such as
+// problems found in the config file. Like every notice they are not saved
+// to the history, so a fixed config stops showing them.
+func WithWarnings(warnings ...string) Option {
+	return func(m *Model) {
+		for _, w := range warnings {
+			m.appendNotice("warning", w)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package ui
+
+// paneSize is a tmux window size in cells.
+type paneSize struct {
+	width, height int
+}
+
+// capture resizes the session's tmux window to the panel showing it, so
+// the command wraps at the panel width, then captures its output. Resizing
+// is best effort: a failure still captures at the old size.
+func (m *Model) capture(name string, width, height int) (string, error) {
+	m.fitPane(name, paneSize{width, height})
+	return m.manager.Capture(name)
+}
+
+// fitPane resizes the window only when the panel size has changed since it
+// was last applied, so captures do not resize on every tick.
+func (m *Model) fitPane(name string, size paneSize) {
+	if size.width <= 0 || size.height <= 0 || m.paneSizes[name] == size {
+		return
+	}
+	if err := m.manager.Resize(name, size.width, size.height); err != nil {
+		return
+	}
+	if m.paneSizes == nil {
+		m.paneSizes = make(map[string]paneSize)
+	}
+	m.paneSizes[name] = size
+}
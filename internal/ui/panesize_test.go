@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCaptureResizesWindowToPanel(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "out"},
+	}
+	model := sizedModel(t, manager, 120, 40)
+	model.currentSession = "hiho-123-0"
+
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	want := []paneSize{{model.viewport.Width, model.viewport.Height}}
+	if got := manager.resized["hiho-123-0"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("resized = %v, want %v (once, at the panel size)", got, want)
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	model = updated.(Model)
+	want = append(want, paneSize{model.viewport.Width, model.viewport.Height})
+	if got := manager.resized["hiho-123-0"]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after window resize: resized = %v, want %v", got, want)
+	}
+}
+
+func TestUnsizedModelDoesNotResize(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "out"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if len(manager.resized) != 0 {
+		t.Fatalf("expected no resize before the window size is known, got %v", manager.resized)
+	}
+}
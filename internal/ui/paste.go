@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	pasteUsage = "usage: /paste [--delay <ms>] <file>"
+	// pasteChunkLines is how many lines are typed per chunk.
+	pasteChunkLines = 50
+	// maxPasteBytes refuses files too large to sensibly type into a pane.
+	maxPasteBytes = 1 << 20
+)
+
+// pasteJob is a file being typed into a session a chunk at a time.
+type pasteJob struct {
+	session string
+	lines   []string
+	delay   time.Duration // pause between chunks
+}
+
+// pasteChunkMsg asks for the next chunk of the active paste.
+type pasteChunkMsg struct{}
+
+// handlePaste implements /paste: it types a file into the current session
+// line by line, a chunk at a time so the UI stays responsive. With --delay
+// the chunks are spaced that many milliseconds apart so slow REPLs can keep
+// up.
+func (m *Model) handlePaste(arg string) error {
+	var delay time.Duration
+	flag, rest := nextToken(arg)
+	if flag == "--delay" {
+		value, remainder := nextToken(rest)
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 {
+			return fmt.Errorf(pasteUsage)
+		}
+		delay = time.Duration(ms) * time.Millisecond
+		arg = remainder
+	}
+	if arg == "" {
+		return fmt.Errorf(pasteUsage)
+	}
+	if m.currentSession == "" {
+		return fmt.Errorf("no active session to paste into")
+	}
+	if m.paste != nil {
+		return fmt.Errorf("a paste into %s is still running", m.paste.session)
+	}
+
+	info, err := os.Stat(arg)
+	if err != nil {
+		return fmt.Errorf("paste: %w", err)
+	}
+	if info.IsDir() || info.Size() > maxPasteBytes {
+		return fmt.Errorf("paste: %s is not a file under %d KB", arg, maxPasteBytes>>10)
+	}
+	data, err := os.ReadFile(arg)
+	if err != nil {
+		return fmt.Errorf("paste: %w", err)
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	m.paste = &pasteJob{session: m.currentSession, lines: strings.Split(text, "\n"), delay: delay}
+	m.appendMessage("info", fmt.Sprintf("Pasting %d lines from %s into %s", len(m.paste.lines), arg, m.displayName(m.currentSession)))
+	return m.sendPasteChunk()
+}
+
+// sendPasteChunk types the next chunk of the active paste and schedules the
+// following one. Even without a delay the rest goes through a tick, so a
+// large file never holds up the event loop.
+func (m *Model) sendPasteChunk() error {
+	job := m.paste
+	if job == nil {
+		return nil
+	}
+	n := min(len(job.lines), pasteChunkLines)
+	for _, line := range job.lines[:n] {
+		if err := m.manager.SendKeys(job.session, line); err != nil {
+			m.paste = nil
+			return fmt.Errorf("paste stopped: %w", err)
+		}
+	}
+	job.lines = job.lines[n:]
+	if len(job.lines) == 0 {
+		m.paste = nil
+		return nil
+	}
+	m.queue(tea.Tick(job.delay, func(time.Time) tea.Msg { return pasteChunkMsg{} }))
+	return nil
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writePasteFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script.py")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestPasteSendsFileLines(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0"}}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	path := writePasteFile(t, "x = 1\r\n\nprint(x)\n")
+
+	if _, err := model.handleSubmit("/paste " + path); err != nil {
+		t.Fatalf("paste: %v", err)
+	}
+	want := []string{"x = 1", "", "print(x)"}
+	if got := manager.sent["hiho-123-0"]; !slices.Equal(got, want) {
+		t.Fatalf("sent %q, want %q", got, want)
+	}
+}
+
+func TestPasteSendsInChunks(t *testing.T) {
+	lines := make([]string, pasteChunkLines+5)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	path := writePasteFile(t, strings.Join(lines, "\n"))
+
+	for _, flags := range []string{"", "--delay 10 "} {
+		t.Run("flags="+flags, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-123-0"}}
+			model := NewModel(manager, testConfig())
+			model.currentSession = "hiho-123-0"
+
+			if _, err := model.handleSubmit("/paste " + flags + path); err != nil {
+				t.Fatalf("paste: %v", err)
+			}
+			if got := len(manager.sent["hiho-123-0"]); got != pasteChunkLines {
+				t.Fatalf("expected first chunk of %d lines, got %d", pasteChunkLines, got)
+			}
+			if len(model.queued) != 1 {
+				t.Fatalf("expected the next chunk to be scheduled")
+			}
+
+			updated, _ := model.Update(pasteChunkMsg{})
+			model = updated.(Model)
+			if got := len(manager.sent["hiho-123-0"]); got != len(lines) {
+				t.Fatalf("expected all %d lines after second chunk, got %d", len(lines), got)
+			}
+			if model.paste != nil {
+				t.Fatal("expected paste to be finished")
+			}
+		})
+	}
+}
+
+func TestPasteMissingFile(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.currentSession = "hiho-123-0"
+	if _, err := model.handleSubmit("/paste /does/not/exist"); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestPasteFillsInputWithoutSubmitting(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	model.focus = focusMain
+	model.input.Blur()
+
+	updated, _ := model.Update(tea.PasteMsg{Text: "/new make\nmake test"})
+	model = updated.(Model)
+
+	if model.focus != focusInput {
+		t.Fatalf("expected focus on the input after a paste, got %v", model.focus)
+	}
+	if got := model.input.Value(); got != "/new make\nmake test" {
+		t.Fatalf("input = %q", got)
+	}
+	if len(manager.created) != 0 || len(model.conversation()) != 0 {
+		t.Fatalf("expected nothing submitted, got sessions %v, messages %v", manager.created, model.conversation())
+	}
+}
+
+func TestPasteDoesNotAnswerConfirmation(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-1-0"}}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/closeall"); err != nil {
+		t.Fatalf("closeall: %v", err)
+	}
+
+	updated, _ := model.Update(tea.PasteMsg{Text: "y"})
+	model = updated.(Model)
+
+	if model.pendingConfirm == nil || len(manager.killed) != 0 {
+		t.Fatalf("expected the confirmation still pending, killed %v", manager.killed)
+	}
+}
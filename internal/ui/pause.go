@@ -0,0 +1,27 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// pausedIndicator is appended to the session bar while refreshing is paused.
+var pausedIndicator = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render("⏸ paused")
+
+// setPaused freezes or resumes automatic tmux activity (auto-refresh and
+// sidebar previews). Explicit captures such as /refresh still run.
+func (m *Model) setPaused(paused bool) {
+	if m.paused == paused {
+		return
+	}
+	m.paused = paused
+	if paused {
+		m.appendMessage("info", "Auto-refresh paused; /resume to continue")
+	} else {
+		m.resetRefreshBackoff()
+		m.appendMessage("info", "Auto-refresh resumed")
+	}
+}
+
+// handleRefresh implements /refresh: capture the current session now.
+func (m *Model) handleRefresh() error {
+	m.refreshSessions()
+	return m.captureCurrentSession()
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPausedTickIssuesNoCaptures(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "out"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.activeTab = tabTmux
+	if _, err := model.handleSubmit("/pause"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+
+	updated, cmd := model.Update(refreshTickMsg(time.Now().Add(time.Minute)))
+	model = updated.(Model)
+	if len(manager.captured) != 0 {
+		t.Fatalf("expected no captures while paused, got %v", manager.captured)
+	}
+	if cmd == nil {
+		t.Fatalf("expected the timer to keep ticking for idle_timeout")
+	}
+
+	if _, err := model.handleSubmit("/refresh"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if len(manager.captured) != 1 {
+		t.Fatalf("expected /refresh to capture while paused, got %v", manager.captured)
+	}
+
+	if _, err := model.handleSubmit("/resume"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	model.Update(refreshTickMsg(time.Now().Add(time.Minute)))
+	if len(manager.captured) != 2 {
+		t.Fatalf("expected auto-refresh to capture after resume, got %v", manager.captured)
+	}
+}
+
+func TestPauseKeyShowsIndicator(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+p"})
+	model = updated.(Model)
+	if !model.paused || !strings.Contains(model.renderSessionBar(), "paused") {
+		t.Fatalf("expected pause key to pause and show the indicator, got %q", model.renderSessionBar())
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: "ctrl+p"})
+	model = updated.(Model)
+	if model.paused || strings.Contains(model.renderSessionBar(), "paused") {
+		t.Fatalf("expected second press to resume")
+	}
+}
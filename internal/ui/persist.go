@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+
+	"hiho/internal/history"
+)
+
+// HistoryStore saves conversation messages across restarts.
+type HistoryStore interface {
+	Load(limit int) ([]history.Entry, error)
+	Append(e history.Entry) error
+	Clear() error
+}
+
+// dropConversations forgets the conversations of sessions that are gone,
+// in the saved history too, so they are not restored under a name tmux may
+// reuse.
+func (m *Model) dropConversations(names ...string) {
+	dropped := false
+	for _, name := range names {
+		if _, ok := m.messages[name]; ok {
+			delete(m.messages, name)
+			dropped = true
+		}
+	}
+	if dropped {
+		m.syncHistory()
+	}
+}
+
+// moveConversation re-files a renamed session's conversation under its new
+// name, in the saved history too.
+func (m *Model) moveConversation(from, to string) {
+	if _, ok := m.messages[from]; !ok {
+		return
+	}
+	moveKey(m.messages, from, to)
+	m.syncHistory()
+}
+
+// syncHistory rewrites the saved history after conversations were dropped
+// or moved. A failure only costs persistence, so it is reported, not
+// returned.
+func (m *Model) syncHistory() {
+	if err := m.rewriteHistory(); err != nil {
+		m.appendNotice("error", fmt.Sprintf("update saved history: %v", err))
+	}
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"hiho/internal/history"
+)
+
+type stubHistory struct {
+	saved    []history.Entry
+	appended []history.Entry
+	cleared  bool
+}
+
+func (h *stubHistory) Load(limit int) ([]history.Entry, error) {
+	return h.saved, nil
+}
+
+func (h *stubHistory) Append(e history.Entry) error {
+	h.appended = append(h.appended, e)
+	return nil
+}
+
+func (h *stubHistory) Clear() error {
+	h.saved, h.appended = nil, nil
+	h.cleared = true
+	return nil
+}
+
+func TestHistoryRestoresAndRecordsMessages(t *testing.T) {
+	store := &stubHistory{saved: []history.Entry{{Role: "user", Content: "from last time"}}}
+	model := NewModel(&stubManager{}, testConfig(), WithHistory(store))
+
+	if len(model.conversation()) != 1 || model.conversation()[0].Content != "from last time" {
+		t.Fatalf("expected saved message restored, got %v", model.conversation())
+	}
+	if _, err := model.handleSubmit("a new note"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if len(store.appended) != 1 || store.appended[0] != (history.Entry{Role: "user", Content: "a new note"}) {
+		t.Fatalf("expected the new note appended, got %v", store.appended)
+	}
+}
+
+func TestHistoryKeepsSessionsApart(t *testing.T) {
+	store := &stubHistory{saved: []history.Entry{
+		{Role: "user", Content: "global"},
+		{Session: "hiho-123-0", Role: "user", Content: "for zero"},
+	}}
+	model := NewModel(&stubManager{}, testConfig(), WithHistory(store))
+
+	if got := model.conversation(); len(got) != 1 || got[0].Content != "global" {
+		t.Fatalf("expected only the global message, got %v", got)
+	}
+	model.currentSession = "hiho-123-0"
+	if got := model.conversation(); len(got) != 1 || got[0].Content != "for zero" {
+		t.Fatalf("expected the session's message restored, got %v", got)
+	}
+	if _, err := model.handleSubmit("another"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	want := history.Entry{Session: "hiho-123-0", Role: "user", Content: "another"}
+	if len(store.appended) != 1 || store.appended[0] != want {
+		t.Fatalf("expected %v appended, got %v", want, store.appended)
+	}
+}
+
+func TestStartupWarningsAreShownButNotSaved(t *testing.T) {
+	store := &stubHistory{}
+	model := NewModel(&stubManager{}, testConfig(), WithHistory(store), WithWarnings("config.yaml: field qiut not found"))
+
+	if last := lastMessage(model); last.Role != "warning" || last.Content != "config.yaml: field qiut not found" {
+		t.Fatalf("expected the warning in the conversation, got %v", last)
+	}
+	if len(store.appended) != 0 {
+		t.Fatalf("expected warnings kept out of the history, got %v", store.appended)
+	}
+}
+
+func TestNoticesShowInEveryConversationButAreNotSaved(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		role  string
+	}{
+		{"help", "/help", "info"},
+		{"list", "/list", "sessions"},
+		{"error", "/bogus", "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &stubHistory{}
+			model := NewModel(&stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}, testConfig(), WithHistory(store))
+			model.currentSession = "hiho-123-0"
+			model.appendMessage("user", "before")
+
+			if _, err := model.handleSubmit(tt.input); err != nil {
+				model.appendNotice("error", err.Error())
+			}
+			if got := model.conversation(); len(got) != 2 || got[0].Content != "before" || got[1].Role != tt.role {
+				t.Fatalf("expected the note then the notice, got %v", got)
+			}
+			model.currentSession = "hiho-123-1"
+			if got := model.conversation(); len(got) != 1 || got[0].Role != tt.role {
+				t.Fatalf("expected the notice in another session too, got %v", got)
+			}
+			want := []history.Entry{{Session: "hiho-123-0", Role: "user", Content: "before"}}
+			if !reflect.DeepEqual(store.appended, want) {
+				t.Fatalf("expected only the note saved, got %v", store.appended)
+			}
+		})
+	}
+}
+
+func TestSavedHistoryFollowsKillAndRename(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantSession string // where the note is saved afterwards, "" for nowhere
+	}{
+		{"/kill hiho-123-0", ""},
+		{"/rename tests", "hiho-tests"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			store := &stubHistory{saved: []history.Entry{{Session: "hiho-123-0", Role: "user", Content: "note"}}}
+			model := NewModel(&stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}, testConfig(), WithHistory(store))
+			model.currentSession = "hiho-123-0"
+
+			if _, err := model.handleSubmit(tt.input); err != nil {
+				t.Fatalf("%s: %v", tt.input, err)
+			}
+			if !store.cleared {
+				t.Fatal("expected the saved history rewritten")
+			}
+			var saved []string
+			for _, e := range store.appended {
+				if e.Content == "note" {
+					saved = append(saved, e.Session)
+				}
+				if e.Session == "hiho-123-0" {
+					t.Fatalf("expected nothing left under the old name, got %v", store.appended)
+				}
+			}
+			if tt.wantSession == "" && len(saved) != 0 || tt.wantSession != "" && !reflect.DeepEqual(saved, []string{tt.wantSession}) {
+				t.Fatalf("expected the note saved under %q, got %v", tt.wantSession, saved)
+			}
+		})
+	}
+}
+
+func TestCapturesAreShownButNotSaved(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string // command run after the capture, "" for none
+	}{
+		{"on capture", ""},
+		{"on rewrite", "/rename tests"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &stubHistory{}
+			manager := &stubManager{
+				sessions:     []string{"hiho-123-0"},
+				outputByName: map[string]string{"hiho-123-0": "$ make\nok\n"},
+			}
+			model := NewModel(manager, testConfig(), WithHistory(store))
+			model.currentSession = "hiho-123-0"
+
+			if err := model.captureCurrentSession(); err != nil {
+				t.Fatalf("capture: %v", err)
+			}
+			if last := lastMessage(model); last.Content != "$ make\nok\n" {
+				t.Fatalf("expected the capture in the conversation, got %v", last)
+			}
+			if tt.input != "" {
+				if _, err := model.handleSubmit(tt.input); err != nil {
+					t.Fatalf("%s: %v", tt.input, err)
+				}
+			}
+			for _, e := range store.appended {
+				if e.Content == "$ make\nok\n" {
+					t.Fatalf("expected the capture kept out of the history, got %v", store.appended)
+				}
+			}
+		})
+	}
+}
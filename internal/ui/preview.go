@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/tmux"
+)
+
+// previewDelay debounces sidebar previews so holding an arrow key does not
+// capture every session it passes.
+const previewDelay = 150 * time.Millisecond
+
+// previewMsg asks to preview the sidebar selection. Only the most recent
+// request (matching seq) is honored.
+type previewMsg struct {
+	seq int
+}
+
+// schedulePreview starts the debounce timer for previewing the selected
+// session when sidebar_preview is enabled.
+func (m *Model) schedulePreview() tea.Cmd {
+	if !m.config.SidebarPreview || m.paused {
+		return nil
+	}
+	m.previewSeq++
+	seq := m.previewSeq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewMsg{seq: seq}
+	})
+}
+
+// handlePreview shows the selected session once navigation has settled.
+// Unlike Enter it does not add the capture to the conversation. A session
+// that has gone away is dropped as auto-refresh does; other failures are
+// reported.
+func (m *Model) handlePreview(msg previewMsg) {
+	if msg.seq != m.previewSeq {
+		return // superseded by later navigation
+	}
+	if m.sessionIndex < 0 || m.sessionIndex >= len(m.sessions) {
+		return
+	}
+	name := m.sessions[m.sessionIndex].Name
+	output, err := m.capture(name, m.viewport.Width, m.viewport.Height)
+	if errors.Is(err, tmux.ErrSessionNotFound) {
+		if err := m.dropMissingSession(name); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return
+	}
+	if err != nil {
+		m.appendNotice("error", "preview: "+sessionFailure(name, err))
+		return
+	}
+	m.currentSession = name
+	m.setSessionLog(output)
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/tmux"
+)
+
+func TestSidebarPreviewCapturesSelectionAfterDebounce(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"},
+		outputByName: map[string]string{"hiho-123-2": "third"},
+	}
+	cfg := testConfig()
+	cfg.SidebarPreview = true
+	model := NewModel(manager, cfg)
+	model.refreshSessions()
+	model.focus = focusSidebar
+
+	updated, first := model.Update(tea.KeyMsg{Type: "down"})
+	model = updated.(Model)
+	updated, second := model.Update(tea.KeyMsg{Type: "down"})
+	model = updated.(Model)
+	if first == nil || second == nil {
+		t.Fatalf("expected each move to schedule a preview")
+	}
+	if len(manager.captured) != 0 {
+		t.Fatalf("expected no capture before the debounce fires, got %v", manager.captured)
+	}
+
+	// The first timer is stale by the time it fires.
+	updated, _ = model.Update(previewMsg{seq: 1})
+	model = updated.(Model)
+	if len(manager.captured) != 0 {
+		t.Fatalf("expected superseded preview to be skipped, got %v", manager.captured)
+	}
+
+	updated, _ = model.Update(second())
+	model = updated.(Model)
+	if len(manager.captured) != 1 || model.currentSession != "hiho-123-2" || model.sessionLog != "third" {
+		t.Fatalf("expected one capture of hiho-123-2, got %v (current %q)", manager.captured, model.currentSession)
+	}
+	if len(model.conversation()) != 0 {
+		t.Fatalf("expected preview to stay out of the conversation, got %v", model.conversation())
+	}
+}
+
+func TestSidebarPreviewOffByDefault(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := NewModel(manager, testConfig())
+	model.refreshSessions()
+	model.focus = focusSidebar
+
+	if _, cmd := model.Update(tea.KeyMsg{Type: "down"}); cmd != nil {
+		t.Fatalf("expected no preview to be scheduled")
+	}
+}
+
+func TestSidebarPreviewReportsCaptureFailures(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCurrent string
+		wantNotice  string
+	}{
+		{"session gone", tmux.ErrSessionNotFound, "hiho-123-0", "Session hiho-123-1 no longer exists"},
+		{"other error", errors.New("tmux crashed"), "", "preview: hiho-123-1: tmux crashed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{
+				sessions:   []string{"hiho-123-0", "hiho-123-1"},
+				captureErr: map[string]error{"hiho-123-1": tt.err},
+			}
+			cfg := testConfig()
+			cfg.SidebarPreview = true
+			model := NewModel(manager, cfg)
+			model.refreshSessions()
+			model.focus = focusSidebar
+
+			updated, preview := model.Update(tea.KeyMsg{Type: "down"})
+			model = updated.(Model)
+			if tt.err == tmux.ErrSessionNotFound {
+				manager.sessions = manager.sessions[:1]
+			}
+			updated, _ = model.Update(preview())
+			model = updated.(Model)
+
+			if model.currentSession != tt.wantCurrent {
+				t.Fatalf("currentSession = %q, want %q", model.currentSession, tt.wantCurrent)
+			}
+			reported := slices.ContainsFunc(model.conversation(), func(m Message) bool {
+				return strings.Contains(m.Content, tt.wantNotice)
+			})
+			if !reported {
+				t.Fatalf("expected %q reported, got %v", tt.wantNotice, model.conversation())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"hiho/internal/config"
+)
+
+// handleProfile implements /profile (list) and /profile <name> (switch).
+func (m *Model) handleProfile(name string) error {
+	if name == "" {
+		return m.listProfilesMessage()
+	}
+	cfg, err := m.loadProfile(name)
+	if err != nil {
+		return fmt.Errorf("profile %s: %w", name, err)
+	}
+	m.flushLayout()
+	m.applyConfig(cfg)
+	m.profile = name
+	m.configPath = m.profileFile(name)
+	m.appendNotice("info", fmt.Sprintf("Switched to profile %s", name))
+	return nil
+}
+
+func (m *Model) listProfilesMessage() error {
+	names, err := m.listProfiles()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		m.appendNotice("info", "No profiles found; add <name>.yaml next to config.yaml")
+		return nil
+	}
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		marker := "  "
+		if name == m.profile {
+			marker = "* "
+		}
+		lines = append(lines, marker+name)
+	}
+	m.appendNotice("profiles", strings.Join(lines, "\n"))
+	return nil
+}
+
+// applyConfig swaps in a freshly loaded config, warning about keybindings
+// that cannot fire. Everything reads m.config on demand, so a redraw is
+// all that is needed for it to take effect.
+func (m *Model) applyConfig(cfg config.Config) {
+	m.config = cfg
+	m.applyWrap()
+	m.markChanged(changedView)
+	for _, warning := range config.Validate(cfg) {
+		m.appendNotice("warning", warning)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"hiho/internal/config"
+)
+
+func TestProfileCommandSwapsConfig(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithProfile("personal"))
+	model.loadProfile = func(name string) (config.Config, error) {
+		if name != "work" {
+			return config.Config{}, errors.New("not found")
+		}
+		cfg := testConfig()
+		cfg.KeyBindings.Quit = "ctrl+q"
+		return cfg, nil
+	}
+	model.profileFile = func(name string) string { return "/cfg/" + name + ".yaml" }
+
+	if _, err := model.handleSubmit("/profile work"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.profile != "work" || model.config.KeyBindings.Quit != "ctrl+q" {
+		t.Fatalf("expected work profile to be applied, got %q with quit %q", model.profile, model.config.KeyBindings.Quit)
+	}
+	if model.configPath != "/cfg/work.yaml" {
+		t.Fatalf("expected the layout to save to the work profile, got %q", model.configPath)
+	}
+
+	if _, err := model.handleSubmit("/profile missing"); err == nil {
+		t.Fatalf("expected an error for a profile that fails to load")
+	}
+	if model.profile != "work" || model.config.KeyBindings.Quit != "ctrl+q" {
+		t.Fatalf("expected failed swap to keep the current config")
+	}
+}
+
+func TestProfileCommandListsProfilesMarkingActive(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithProfile("work"))
+	model.listProfiles = func() ([]string, error) { return []string{"personal", "work"}, nil }
+
+	if _, err := model.handleSubmit("/profile"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	last := lastMessage(model)
+	if last.Content != "  personal\n* work" {
+		t.Fatalf("unexpected profile list %q", last.Content)
+	}
+}
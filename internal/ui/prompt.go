@@ -0,0 +1,26 @@
+package ui
+
+import "strings"
+
+// promptSymbol returns the configured symbol for the current input state:
+// confirm while a yes/no question is pending, command once the input starts
+// with "/", normal otherwise. Unset symbols fall back to the defaults.
+func (m Model) promptSymbol() string {
+	symbols := m.config.Prompt
+	switch {
+	case m.pendingConfirm != nil:
+		return orDefault(symbols.Confirm, "?")
+	case strings.HasPrefix(m.input.Value(), "/"):
+		return orDefault(symbols.Command, "/")
+	default:
+		return orDefault(symbols.Normal, ">")
+	}
+}
+
+// orDefault returns value, or fallback when value is empty.
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
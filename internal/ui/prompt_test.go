@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptFollowsInputState(t *testing.T) {
+	cfg := testConfig()
+	cfg.Prompt.Normal = "»"
+	cfg.Prompt.Command = "::"
+	cfg.Prompt.Confirm = "??"
+	model := NewModel(&stubManager{}, cfg)
+	model.width = 120
+
+	tests := []struct {
+		name    string
+		value   string
+		confirm bool
+		want    string
+	}{
+		{"note", "hello", false, "» hello"},
+		{"command", "/new bash", false, ":: /new bash"},
+		{"confirm", "", true, "?? "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model
+			m.input.ValueStr = tt.value
+			if tt.confirm {
+				m.pendingConfirm = &confirmation{prompt: "Run it?"}
+			}
+			if panel := m.renderInputPanel(); !strings.Contains(panel, tt.want) {
+				t.Fatalf("expected %q in input panel, got %q", tt.want, panel)
+			}
+		})
+	}
+}
+
+func TestPromptFallsBackToDefaults(t *testing.T) {
+	cfg := testConfig()
+	cfg.Prompt.Command = ""
+	model := NewModel(&stubManager{}, cfg)
+	model.input.ValueStr = "/list"
+	if got := model.promptSymbol(); got != "/" {
+		t.Fatalf("promptSymbol() = %q, want %q", got, "/")
+	}
+}
@@ -0,0 +1,8 @@
+package ui
+
+// KillOnQuit reports whether the hiho sessions should be killed once the
+// program has exited, following the config in effect at that point, so a
+// /reload before quitting counts.
+func (m Model) KillOnQuit() bool {
+	return m.config.KillOnQuit
+}
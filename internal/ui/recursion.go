@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// selfName is the executable name that identifies hiho in a command.
+const selfName = "hiho"
+
+// commandSeparators split a shell line into simple commands.
+var commandSeparators = regexp.MustCompile(`&&|\|\||[;|&\n]`)
+
+// commandWrappers run the following word as the real command.
+var commandWrappers = map[string]bool{"exec": true, "env": true, "sudo": true, "nohup": true, "command": true}
+
+// launchesHiho reports whether any simple command in a shell line runs
+// hiho itself, looking past variable assignments and wrappers such as exec
+// or sudo.
+func launchesHiho(command string) bool {
+	for _, part := range commandSeparators.Split(command, -1) {
+		for _, word := range strings.Fields(part) {
+			if strings.Contains(word, "=") || commandWrappers[word] || strings.HasPrefix(word, "-") {
+				continue
+			}
+			if filepath.Base(strings.Trim(word, `'"`)) == selfName {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
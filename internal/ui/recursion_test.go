@@ -0,0 +1,41 @@
+package ui
+
+import "testing"
+
+func TestLaunchesHiho(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"hiho", true},
+		{"/usr/local/bin/hiho --profile work", true},
+		{"cd ~/src && FOO=1 exec hiho", true},
+		{"echo hiho", false},
+		{"hihoctl status", false},
+		{"vim hiho.go", false},
+	}
+	for _, tt := range tests {
+		if got := launchesHiho(tt.command); got != tt.want {
+			t.Errorf("launchesHiho(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestNewRefusesHihoWithoutForce(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/new hiho"); err == nil {
+		t.Fatal("expected /new hiho to be refused")
+	}
+	if len(manager.created) != 0 {
+		t.Fatalf("expected no session, got %v", manager.created)
+	}
+
+	if _, err := model.handleSubmit("/new --force hiho"); err != nil {
+		t.Fatalf("expected --force to allow it: %v", err)
+	}
+	if len(manager.created) != 1 || manager.created[0] != "hiho" {
+		t.Fatalf("unexpected sessions %v", manager.created)
+	}
+}
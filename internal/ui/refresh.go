@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"errors"
+	"hash/fnv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/tmux"
+)
+
+const (
+	// refreshBaseInterval is how often the current session is polled while
+	// its output keeps changing, unless refresh_interval overrides it.
+	refreshBaseInterval = 2 * time.Second
+	// refreshMaxInterval caps the backoff for sessions that sit idle.
+	refreshMaxInterval = 30 * time.Second
+)
+
+// refreshTickMsg drives the auto-refresh timer.
+type refreshTickMsg time.Time
+
+// refreshState tracks the polling backoff for one session.
+type refreshState struct {
+	interval time.Duration
+	hash     uint64
+	next     time.Time
+}
+
+// refreshInterval returns the configured polling interval, or
+// refreshBaseInterval when none is set.
+func (m Model) refreshInterval() time.Duration {
+	if m.config.RefreshInterval > 0 {
+		return m.config.RefreshInterval
+	}
+	return refreshBaseInterval
+}
+
+// refreshTick schedules the next auto-refresh check. Ticks always run at the
+// base interval; each session's backoff decides whether a tick captures.
+func refreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return refreshTickMsg(t)
+	})
+}
+
+// refreshStateFor returns the backoff state for a session, creating it on
+// first use.
+func (m *Model) refreshStateFor(name string) *refreshState {
+	if m.refreshStates == nil {
+		m.refreshStates = make(map[string]*refreshState)
+	}
+	st, ok := m.refreshStates[name]
+	if !ok {
+		st = &refreshState{interval: m.refreshInterval()}
+		m.refreshStates[name] = st
+	}
+	return st
+}
+
+// recordCapture updates a session's backoff after a capture at now: identical
+// output doubles the interval up to refreshMaxInterval (or the base interval,
+// if that is longer), changed output resets it. It reports whether the output
+// changed.
+func (m *Model) recordCapture(name, output string, now time.Time) bool {
+	st := m.refreshStateFor(name)
+	h := fnv.New64a()
+	h.Write([]byte(output))
+	sum := h.Sum64()
+
+	base := m.refreshInterval()
+	changed := sum != st.hash
+	if changed {
+		st.interval = base
+		st.hash = sum
+	} else {
+		st.interval = min(st.interval*2, max(refreshMaxInterval, base))
+	}
+	st.next = now.Add(st.interval)
+	return changed
+}
+
+// resetRefreshBackoff returns every session to the base interval; the user
+// is active, so output is likely to change soon.
+func (m *Model) resetRefreshBackoff() {
+	for _, st := range m.refreshStates {
+		st.interval = m.refreshInterval()
+		st.next = time.Time{}
+	}
+}
+
+// autoRefresh quietly re-captures the current session when its backoff
+// allows, updating the Tmux view without adding conversation messages. The
+// session list and an open split pane are refreshed on every tick. Nothing
+// is captured while paused.
+func (m *Model) autoRefresh(now time.Time) {
+	if m.paused {
+		return
+	}
+	// Keeps sidebar status and exit codes current
+	m.refreshSessions()
+	if err := m.captureSplit(); err != nil {
+		m.appendNotice("error", err.Error())
+	}
+	if m.currentSession == "" {
+		return
+	}
+	st := m.refreshStateFor(m.currentSession)
+	if now.Before(st.next) {
+		return
+	}
+	output, err := m.capture(m.currentSession, m.viewport.Width, m.viewport.Height)
+	if errors.Is(err, tmux.ErrSessionNotFound) {
+		if err := m.dropMissingSession(m.currentSession); err != nil {
+			m.appendNotice("error", err.Error())
+		}
+		return
+	}
+	if err != nil {
+		return
+	}
+	if m.recordCapture(m.currentSession, output, now) {
+		m.setSessionLog(output)
+	}
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecordCaptureBacksOffOnIdenticalOutput(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	now := time.Unix(0, 0)
+
+	model.recordCapture("hiho-123-0", "same", now)
+	want := []time.Duration{4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, w := range want {
+		model.recordCapture("hiho-123-0", "same", now)
+		if got := model.refreshStateFor("hiho-123-0").interval; got != w {
+			t.Fatalf("identical capture %d: interval = %v, want %v", i+1, got, w)
+		}
+	}
+
+	if !model.recordCapture("hiho-123-0", "changed", now) {
+		t.Fatalf("expected changed output to be reported")
+	}
+	if got := model.refreshStateFor("hiho-123-0").interval; got != refreshBaseInterval {
+		t.Fatalf("expected changed output to reset interval, got %v", got)
+	}
+}
+
+func TestUserInputResetsRefreshBackoff(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	now := time.Unix(0, 0)
+	for range 3 {
+		model.recordCapture("hiho-123-0", "same", now)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "a"})
+	model = updated.(Model)
+
+	st := model.refreshStateFor("hiho-123-0")
+	if st.interval != refreshBaseInterval || !st.next.IsZero() {
+		t.Fatalf("expected key press to reset backoff, got %+v", st)
+	}
+}
+
+func TestAutoRefreshSkipsUntilDueAndAddsNoMessages(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "v1"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	now := time.Unix(0, 0)
+
+	model.autoRefresh(now)
+	if model.sessionLog != "v1" {
+		t.Fatalf("expected first tick to capture, got %q", model.sessionLog)
+	}
+
+	manager.outputByName["hiho-123-0"] = "v2"
+	model.autoRefresh(now.Add(time.Second))
+	if model.sessionLog != "v1" {
+		t.Fatalf("expected tick before the interval to skip capture, got %q", model.sessionLog)
+	}
+
+	model.autoRefresh(now.Add(refreshBaseInterval))
+	if model.sessionLog != "v2" {
+		t.Fatalf("expected due tick to capture new output, got %q", model.sessionLog)
+	}
+	if len(model.conversation()) != 0 {
+		t.Fatalf("expected auto-refresh to stay out of the conversation, got %v", model.conversation())
+	}
+}
+
+func TestTickPollsOnlyOnTmuxTab(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "out"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+
+	updated, cmd := model.Update(refreshTickMsg(time.Now()))
+	model = updated.(Model)
+	if len(manager.captured) != 0 {
+		t.Fatalf("expected no captures on the Conversation tab, got %v", manager.captured)
+	}
+	if cmd == nil {
+		t.Fatalf("expected the timer to keep ticking")
+	}
+
+	model.activeTab = tabTmux
+	model.Update(refreshTickMsg(time.Now()))
+	if len(manager.captured) != 1 {
+		t.Fatalf("expected a capture on the Tmux tab, got %v", manager.captured)
+	}
+}
+
+func TestRefreshIntervalFromConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.RefreshInterval = 45 * time.Second
+	model := NewModel(&stubManager{}, cfg)
+	now := time.Unix(0, 0)
+
+	model.recordCapture("hiho-123-0", "same", now)
+	if got := model.refreshStateFor("hiho-123-0").interval; got != 45*time.Second {
+		t.Fatalf("interval = %v, want 45s", got)
+	}
+	model.recordCapture("hiho-123-0", "same", now)
+	if got := model.refreshStateFor("hiho-123-0").interval; got != 45*time.Second {
+		t.Fatalf("backoff should not drop below the configured interval, got %v", got)
+	}
+}
+
+func TestRefreshOrdersSessionsAndKeepsSelection(t *testing.T) {
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{"newest", []string{"hiho-new", "hiho-mid", "hiho-old"}},
+		{"oldest", []string{"hiho-old", "hiho-mid", "hiho-new"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			start := time.Unix(1000, 0)
+			manager := &stubManager{
+				sessions:  []string{"hiho-old", "hiho-mid"},
+				createdAt: map[string]time.Time{"hiho-old": start, "hiho-mid": start.Add(time.Minute)},
+			}
+			model := NewModel(manager, testConfig())
+			model.refreshSessions()
+			model.sessionIndex = 1
+			selected := model.sessions[1].Name
+
+			// The order follows the config in effect, as after a /reload
+			model.config.SessionOrder = tt.order
+			manager.sessions = append(manager.sessions, "hiho-new")
+			manager.createdAt["hiho-new"] = start.Add(2 * time.Minute)
+			model.refreshSessions()
+
+			var got []string
+			for _, s := range model.sessions {
+				got = append(got, s.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("sessions = %v, want %v", got, tt.want)
+			}
+			if name := model.sessions[model.sessionIndex].Name; name != selected {
+				t.Fatalf("expected %s still selected, got %s", selected, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"hiho/internal/config"
+)
+
+// handleReload implements /reload: it reads the active profile, or the
+// config file hiho was started with, again and applies it. A file that no
+// longer loads leaves the current config in place.
+func (m *Model) handleReload() error {
+	// The file is read back, so a pending layout must reach it first
+	m.flushLayout()
+	var cfg config.Config
+	var err error
+	source := m.configPath
+	switch {
+	case m.profile != "":
+		source = "profile " + m.profile
+		cfg, err = m.loadProfile(m.profile)
+	case m.configPath != "":
+		cfg, err = m.loadConfig(m.configPath)
+		// As at startup, a missing config.yaml means the defaults
+		if errors.Is(err, fs.ErrNotExist) {
+			cfg, err = config.DefaultConfig(), nil
+		}
+	default:
+		return fmt.Errorf("no config file to reload")
+	}
+	if err != nil {
+		return fmt.Errorf("reload %s: %w; keeping the current config", source, err)
+	}
+	m.applyConfig(cfg)
+	m.appendNotice("info", fmt.Sprintf("Reloaded %s", source))
+	return nil
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/config"
+)
+
+func TestReloadAppliesConfigFile(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithConfigPath("/home/me/.config/hiho/config.yaml"))
+	model.loadConfig = func(path string) (config.Config, error) {
+		if path != "/home/me/.config/hiho/config.yaml" {
+			return config.Config{}, fmt.Errorf("unexpected path %s", path)
+		}
+		cfg := testConfig()
+		cfg.KeyBindings.Quit = "ctrl+q"
+		return cfg, nil
+	}
+
+	if _, err := model.handleSubmit("/reload"); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if model.config.KeyBindings.Quit != "ctrl+q" {
+		t.Fatalf("expected the new keybindings applied, got quit %q", model.config.KeyBindings.Quit)
+	}
+	if last := lastMessage(model); !strings.HasPrefix(last.Content, "Reloaded") {
+		t.Fatalf("expected a reload message, got %q", last.Content)
+	}
+}
+
+func TestReloadUsesActiveProfile(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithProfile("work"), WithConfigPath("config.yaml"))
+	model.loadConfig = func(string) (config.Config, error) {
+		return config.Config{}, errors.New("read the profile instead")
+	}
+	var loaded string
+	model.loadProfile = func(name string) (config.Config, error) {
+		loaded = name
+		return testConfig(), nil
+	}
+
+	if _, err := model.handleSubmit("/reload"); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if loaded != "work" {
+		t.Fatalf("expected profile work reloaded, got %q", loaded)
+	}
+}
+
+func TestReloadKeepsConfigOnError(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithConfigPath("config.yaml"))
+	model.config.KeyBindings.Quit = "ctrl+x"
+	model.loadConfig = func(string) (config.Config, error) {
+		return config.DefaultConfig(), errors.New("parse config: bad indentation")
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+r"})
+	model = updated.(Model)
+	if model.config.KeyBindings.Quit != "ctrl+x" {
+		t.Fatalf("expected the current config kept, got quit %q", model.config.KeyBindings.Quit)
+	}
+	last := lastMessage(model)
+	if last.Role != "error" || !strings.Contains(last.Content, "bad indentation") {
+		t.Fatalf("expected the load error shown, got %v", last)
+	}
+}
+
+func TestReloadMissingFileFallsBackToDefaults(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithConfigPath("config.yaml"))
+	model.config.KeyBindings.Quit = "ctrl+x"
+	model.loadConfig = func(string) (config.Config, error) {
+		return config.DefaultConfig(), fmt.Errorf("read config: %w", fs.ErrNotExist)
+	}
+
+	if _, err := model.handleSubmit("/reload"); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if model.config.KeyBindings.Quit != config.DefaultConfig().KeyBindings.Quit {
+		t.Fatalf("expected the defaults applied, got quit %q", model.config.KeyBindings.Quit)
+	}
+}
+
+func TestReloadWarnsAboutUnusableKeys(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig(), WithConfigPath("config.yaml"))
+	model.loadConfig = func(string) (config.Config, error) {
+		cfg := testConfig()
+		cfg.KeyBindings.ToggleTab = cfg.KeyBindings.Quit
+		return cfg, nil
+	}
+
+	if _, err := model.handleSubmit("/reload"); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	var warnings []string
+	for _, message := range model.conversation() {
+		if message.Role == "warning" {
+			warnings = append(warnings, message.Content)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "keybindings.toggle_tab") {
+		t.Fatalf("expected a warning about toggle_tab, got %q", warnings)
+	}
+}
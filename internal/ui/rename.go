@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// handleRename implements /rename: it renames the current session to
+// hiho-<name> (a leading hiho- in name is accepted) and carries its
+// label, status and other per-session state over to the new name.
+func (m *Model) handleRename(arg string) error {
+	label := strings.TrimPrefix(arg, "hiho-")
+	if label == "" {
+		return fmt.Errorf("usage: /rename <name>")
+	}
+	if m.currentSession == "" {
+		return fmt.Errorf("no active session to rename")
+	}
+	if err := m.validateLabel(label); err != nil {
+		return err
+	}
+	oldName, newName := m.currentSession, labeledName(label)
+	if err := m.manager.Rename(oldName, newName); err != nil {
+		return err
+	}
+
+	if m.sessionLabels == nil {
+		m.sessionLabels = make(map[string]string)
+	}
+	delete(m.sessionLabels, oldName)
+	m.sessionLabels[newName] = label
+	moveKey(m.sessionTags, oldName, newName)
+	moveKey(m.sessionCmds, oldName, newName)
+	moveKey(m.sessionEnv, oldName, newName)
+	moveKey(m.sessionStatus, oldName, newName)
+	moveKey(m.refreshStates, oldName, newName)
+	moveKey(m.paneSizes, oldName, newName)
+	m.moveConversation(oldName, newName)
+	if m.history.session == oldName {
+		m.history.session = newName
+	}
+	if m.diff.session == oldName {
+		m.diff.session = newName
+	}
+	if m.split != nil && m.split.session == oldName {
+		m.split.session = newName
+	}
+	m.currentSession = newName
+	m.refreshSessions()
+	m.markChanged(changedSessions | changedCapture)
+	m.appendMessage("info", fmt.Sprintf("Renamed %s to %s", oldName, newName))
+	return nil
+}
+
+// moveKey re-files a map entry under a new key, if there is one.
+func moveKey[V any](values map[string]V, from, to string) {
+	if value, ok := values[from]; ok {
+		delete(values, from)
+		values[to] = value
+	}
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRenameCurrentSession(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.setSessionCommand("hiho-123-0", "npm test")
+	model.setSessionStatus("hiho-123-0", statusRunning)
+	model.appendMessage("user", "note before the rename")
+
+	if _, err := model.handleSubmit("/rename tests"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if model.currentSession != "hiho-tests" {
+		t.Fatalf("currentSession = %q, want hiho-tests", model.currentSession)
+	}
+	if !slices.Equal(manager.sessions, []string{"hiho-tests", "hiho-123-1"}) {
+		t.Fatalf("unexpected tmux sessions %v", manager.sessions)
+	}
+	if len(model.sessions) != 2 || model.sessions[0].Name != "hiho-tests" {
+		t.Fatalf("expected cached sessions refreshed, got %v", model.sessions)
+	}
+	if got := model.displayName("hiho-tests"); got != "tests" {
+		t.Fatalf("displayName = %q, want tests", got)
+	}
+	if model.sessionCmds["hiho-tests"] != "npm test" || model.sessionStatus["hiho-tests"] != statusRunning {
+		t.Fatalf("expected session state to follow the rename")
+	}
+	if _, ok := model.sessionCmds["hiho-123-0"]; ok {
+		t.Fatalf("expected old name dropped from session state")
+	}
+	if got := model.conversation(); len(got) != 2 || got[0].Content != "note before the rename" {
+		t.Fatalf("expected the conversation to follow the rename, got %v", got)
+	}
+}
+
+func TestRenameRejectsBadNames(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+	}{
+		{"collision", "hiho-123-1"},
+		{"invalid characters", "my.session"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+			model := NewModel(manager, testConfig())
+			model.currentSession = "hiho-123-0"
+
+			if err := model.handleRename(tt.arg); err == nil {
+				t.Fatalf("expected /rename %q to fail", tt.arg)
+			}
+			if model.currentSession != "hiho-123-0" || manager.sessions[0] != "hiho-123-0" {
+				t.Fatalf("session should not be renamed")
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/config"
+)
+
+const (
+	// resizeStep is how many columns a resize key moves a divider.
+	resizeStep = 2
+	// minPanelWidth keeps both sides of a divider usable.
+	minPanelWidth = 12
+	// layoutSaveDelay is how long the resize keys must rest before the
+	// layout is written, so holding one down saves once.
+	layoutSaveDelay = 500 * time.Millisecond
+)
+
+// layoutSaveMsg fires layoutSaveDelay after a resize. resize is the
+// Model.layoutResizes it was scheduled for; a later key press supersedes it.
+type layoutSaveMsg struct{ resize int }
+
+// adjustRatio moves a divider at ratio of total columns by delta columns,
+// keeping at least minWidth columns on either side. When total is too
+// narrow for that the ratio is left alone.
+func adjustRatio(ratio float64, delta, total, minWidth int) float64 {
+	if total < 2*minWidth {
+		return ratio
+	}
+	cols := ratioColumns(ratio, total) + delta
+	cols = clamp(cols, minWidth, total-minWidth)
+	return float64(cols) / float64(total)
+}
+
+// ratioColumns converts a share of total into whole columns. The small
+// epsilon keeps ratios such as 1/3 from rounding down a column.
+func ratioColumns(ratio float64, total int) int {
+	return int(math.Floor(float64(total)*ratio + 1e-9))
+}
+
+// validRatio returns ratio, or fallback if it is not strictly between 0
+// and 1.
+func validRatio(ratio, fallback float64) float64 {
+	if ratio <= 0 || ratio >= 1 {
+		return fallback
+	}
+	return ratio
+}
+
+// handleResize moves a divider by delta columns: the split divider while
+// the main panel has focus and a split is open, otherwise the sidebar
+// divider. The new layout is saved to the config file once the keys rest,
+// or on quit.
+func (m *Model) handleResize(delta int) {
+	layout := &m.config.Layout
+	defaults := config.DefaultLayout()
+	if m.focus == focusMain && m.split != nil {
+		ratio := validRatio(layout.SplitRatio, defaults.SplitRatio)
+		layout.SplitRatio = adjustRatio(ratio, delta, m.mainWidth()-4, minPanelWidth)
+	} else {
+		ratio := validRatio(layout.SidebarRatio, defaults.SidebarRatio)
+		layout.SidebarRatio = adjustRatio(ratio, delta, m.width, minPanelWidth)
+	}
+	m.layoutViewports()
+	m.markChanged(changedSessions)
+
+	if m.configPath == "" {
+		return
+	}
+	m.layoutUnsaved = true
+	m.layoutResizes++
+	resize := m.layoutResizes
+	m.queue(tea.Tick(layoutSaveDelay, func(time.Time) tea.Msg { return layoutSaveMsg{resize} }))
+}
+
+// flushLayout writes a layout changed by the resize keys to the config
+// file, if there is one waiting.
+func (m *Model) flushLayout() {
+	if !m.layoutUnsaved || m.configPath == "" {
+		return
+	}
+	m.layoutUnsaved = false
+	if err := m.saveLayout(m.configPath, m.config.Layout); err != nil {
+		m.appendNotice("error", err.Error())
+	}
+}
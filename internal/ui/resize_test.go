@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/config"
+)
+
+func TestAdjustRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+		delta int
+		total int
+		want  float64
+	}{
+		{"grow", 0.5, 10, 100, 0.6},
+		{"shrink", 0.5, -10, 100, 0.4},
+		{"clamped at left", 0.15, -10, 100, 0.12},
+		{"clamped at right", 0.85, 10, 100, 0.88},
+		{"too narrow to resize", 0.5, 4, 20, 0.5},
+	}
+	for _, tt := range tests {
+		if got := adjustRatio(tt.ratio, tt.delta, tt.total, minPanelWidth); got != tt.want {
+			t.Errorf("%s: adjustRatio = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResizeKeysMoveSidebarAndSave(t *testing.T) {
+	var saved []config.Layout
+	model := sizedModel(t, &stubManager{}, 90, 30)
+	model.configPath = "/tmp/hiho-test.yaml"
+	model.saveLayout = func(path string, layout config.Layout) error {
+		saved = append(saved, layout)
+		return nil
+	}
+	model.focus = focusSidebar
+
+	before := model.sidebarWidth()
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+right"})
+	model = updated.(Model)
+	if got := model.sidebarWidth(); got != before+resizeStep {
+		t.Fatalf("expected sidebar %d, got %d", before+resizeStep, got)
+	}
+	if model.viewport.Width != model.mainWidth()-4 {
+		t.Fatalf("viewport not re-laid out: %d", model.viewport.Width)
+	}
+	if len(saved) != 0 {
+		t.Fatalf("expected the save to wait for the keys to rest, got %+v", saved)
+	}
+
+	for range 50 {
+		updated, _ = model.Update(tea.KeyMsg{Type: "ctrl+left"})
+		model = updated.(Model)
+	}
+	if got := model.sidebarWidth(); got != minPanelWidth {
+		t.Fatalf("expected sidebar clamped to %d, got %d", minPanelWidth, got)
+	}
+
+	// Only the tick of the last key press saves
+	updated, _ = model.Update(layoutSaveMsg{resize: 1})
+	model = updated.(Model)
+	if len(saved) != 0 {
+		t.Fatalf("expected a superseded tick not to save, got %+v", saved)
+	}
+	updated, _ = model.Update(layoutSaveMsg{resize: model.layoutResizes})
+	model = updated.(Model)
+	if len(saved) != 1 || saved[0].SidebarRatio != model.config.Layout.SidebarRatio {
+		t.Fatalf("expected layout to be saved once, got %+v", saved)
+	}
+}
+
+func TestResizeSavesPendingLayoutOnQuit(t *testing.T) {
+	var saved []config.Layout
+	model := sizedModel(t, &stubManager{}, 90, 30)
+	model.configPath = "/tmp/hiho-test.yaml"
+	model.saveLayout = func(path string, layout config.Layout) error {
+		saved = append(saved, layout)
+		return nil
+	}
+	model.focus = focusSidebar
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+right"})
+	model = updated.(Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: model.config.KeyBindings.Quit})
+	model = updated.(Model)
+	if len(saved) != 1 {
+		t.Fatalf("expected the pending layout saved on quit, got %+v", saved)
+	}
+
+	// Nothing is left to save after that
+	model.Update(layoutSaveMsg{resize: model.layoutResizes})
+	if len(saved) != 1 {
+		t.Fatalf("expected no second save, got %+v", saved)
+	}
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"hiho/internal/ansi"
+)
+
+const screenshotUsage = "usage: /screenshot [--plain] <path>"
+
+// handleScreenshot writes the currently rendered frame to a file, keeping
+// ANSI styling unless --plain is given.
+func (m *Model) handleScreenshot(arg string) error {
+	plain := false
+	var path string
+	for _, field := range strings.Fields(arg) {
+		if field == "--plain" {
+			plain = true
+			continue
+		}
+		if path != "" {
+			return fmt.Errorf(screenshotUsage)
+		}
+		path = field
+	}
+	if path == "" {
+		return fmt.Errorf(screenshotUsage)
+	}
+
+	frame := m.View()
+	if plain {
+		frame = ansi.Strip(frame)
+	}
+	if err := os.WriteFile(path, []byte(frame), 0644); err != nil {
+		return fmt.Errorf("write screenshot: %w", err)
+	}
+	m.appendMessage("info", fmt.Sprintf("Screenshot saved to %s", path))
+	return nil
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func screenshotModel(t *testing.T) Model {
+	t.Helper()
+	manager := &stubManager{sessions: []string{"hiho-123-0"}}
+	model := NewModel(manager, testConfig())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	return updated.(Model)
+}
+
+func TestScreenshotWritesRenderedView(t *testing.T) {
+	model := screenshotModel(t)
+	path := filepath.Join(t.TempDir(), "frame.ans")
+	want := model.View()
+
+	if _, err := model.handleSubmit("/screenshot " + path); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read screenshot: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("screenshot does not match View():\n got %q\nwant %q", got, want)
+	}
+	if !strings.Contains(string(got), "\033[") {
+		t.Fatalf("expected ANSI styling to be kept")
+	}
+}
+
+func TestScreenshotPlainStripsColors(t *testing.T) {
+	model := screenshotModel(t)
+	path := filepath.Join(t.TempDir(), "frame.txt")
+	want := ansi.Strip(model.View())
+
+	if _, err := model.handleSubmit("/screenshot --plain " + path); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read screenshot: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("plain screenshot mismatch:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestScreenshotWithoutPathErrors(t *testing.T) {
+	model := screenshotModel(t)
+	if _, err := model.handleSubmit("/screenshot --plain"); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
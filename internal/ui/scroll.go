@@ -0,0 +1,36 @@
+package ui
+
+import "github.com/charmbracelet/bubbles/viewport"
+
+// focusedViewport returns the viewport the main panel keys act on: the split
+// pane when it has focus, the main viewport otherwise.
+func (m *Model) focusedViewport() *viewport.Model {
+	if m.split != nil && m.split.focused {
+		return &m.split.viewport
+	}
+	return &m.viewport
+}
+
+// handleScrollKey scrolls the focused viewport for the main panel's
+// navigation keys. It reports whether key was one of them.
+func (m *Model) handleScrollKey(key string) bool {
+	vp := m.focusedViewport()
+	switch key {
+	case "up", "k":
+		vp.LineUp(1)
+	case "down", "j":
+		vp.LineDown(1)
+	case "pgup":
+		vp.HalfViewUp()
+	case "pgdown":
+		vp.HalfViewDown()
+	case "home", m.config.KeyBindings.ScrollTop:
+		vp.GotoTop()
+	case "end", m.config.KeyBindings.ScrollBottom:
+		vp.GotoBottom()
+	default:
+		return false
+	}
+	m.syncFollow()
+	return true
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func scrollModel(t *testing.T) Model {
+	t.Helper()
+	model := sizedModel(t, &stubManager{}, 120, 30)
+	for i := range 100 {
+		model.appendMessage("info", fmt.Sprintf("message %d", i))
+	}
+	model.refreshViewport()
+	model.viewport.GotoTop()
+	model.focus = focusMain
+	return model
+}
+
+func TestScrollKeysMoveMainViewport(t *testing.T) {
+	model := scrollModel(t)
+	half := model.viewport.Height / 2
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"j", 1},
+		{"down", 2},
+		{"k", 1},
+		{"pgdown", 1 + half},
+		{"pgup", 1},
+		{"end", model.viewport.TotalLineCount() - model.viewport.Height},
+		{"home", 0},
+	}
+	for _, tt := range tests {
+		updated, _ := model.Update(tea.KeyMsg{Type: tt.key})
+		model = updated.(Model)
+		if model.viewport.YOffset != tt.want {
+			t.Fatalf("after %q: YOffset = %d, want %d", tt.key, model.viewport.YOffset, tt.want)
+		}
+	}
+}
+
+func TestScrollKeysIgnoredOutsideMainFocus(t *testing.T) {
+	model := scrollModel(t)
+	model.focus = focusInput
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "j"})
+	model = updated.(Model)
+	if model.viewport.YOffset != 0 {
+		t.Fatalf("expected no scroll from the input, got YOffset %d", model.viewport.YOffset)
+	}
+	if !strings.HasSuffix(model.input.Value(), "j") {
+		t.Fatalf("expected j typed into the input, got %q", model.input.Value())
+	}
+}
+
+func TestMainPanelShowsScrollPosition(t *testing.T) {
+	model := scrollModel(t)
+	total := model.viewport.TotalLineCount()
+
+	tabRow := func() string {
+		return strings.Split(ansi.Strip(model.renderMainPanel()), "\n")[1]
+	}
+	want := fmt.Sprintf("%d/%d 0%%", model.viewport.Height, total)
+	if row := tabRow(); !strings.HasSuffix(row, want+"│") {
+		t.Fatalf("expected %q at the right of the tab bar, got %q", want, row)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "end"})
+	model = updated.(Model)
+	want = fmt.Sprintf("%d/%d 100%%", total, total)
+	if row := tabRow(); !strings.HasSuffix(row, want+"│") {
+		t.Fatalf("expected %q after scrolling to the end, got %q", want, row)
+	}
+
+	model.viewport.SetContent("a short capture")
+	if row := tabRow(); strings.Contains(row, "%") {
+		t.Fatalf("expected no indicator when everything fits, got %q", row)
+	}
+}
+
+func TestScrollTopBottomKeysAreConfigurable(t *testing.T) {
+	model := scrollModel(t)
+	model.config.KeyBindings.ScrollTop = "t"
+	model.config.KeyBindings.ScrollBottom = "b"
+	bottom := model.viewport.TotalLineCount() - model.viewport.Height
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"b", bottom},
+		{"t", 0},
+		{"G", 0}, // the default is replaced
+		{"end", bottom},
+		{"home", 0},
+	}
+	for _, tt := range tests {
+		updated, _ := model.Update(tea.KeyMsg{Type: tt.key})
+		model = updated.(Model)
+		if model.viewport.YOffset != tt.want {
+			t.Fatalf("after %q: YOffset = %d, want %d", tt.key, model.viewport.YOffset, tt.want)
+		}
+	}
+}
+
+func TestRefreshFollowsOutputAtBottom(t *testing.T) {
+	tests := []struct {
+		name   string
+		follow bool
+		scroll string
+		want   func(y, bottom int) bool
+	}{
+		{"follows at the bottom", true, "G", func(y, bottom int) bool { return y == bottom }},
+		{"stays put when scrolled up", true, "k", func(y, bottom int) bool { return y < bottom-1 }},
+		{"follow_output off", false, "G", func(y, bottom int) bool { return y < bottom }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := scrollModel(t)
+			model.config.FollowOutput = tt.follow
+			updated, _ := model.Update(tea.KeyMsg{Type: "G"})
+			model = updated.(Model)
+			updated, _ = model.Update(tea.KeyMsg{Type: tt.scroll})
+			model = updated.(Model)
+
+			model.appendMessage("info", "newest")
+			model.refreshViewport()
+			bottom := model.viewport.TotalLineCount() - model.viewport.Height
+			if !tt.want(model.viewport.YOffset, bottom) {
+				t.Fatalf("YOffset = %d with bottom at %d", model.viewport.YOffset, bottom)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// handleSecret implements /secret: the next entry is masked as it is
+// typed and kept out of input history, e.g. for /send <password>.
+func (m *Model) handleSecret(arg string) error {
+	if arg != "" {
+		return fmt.Errorf("usage: /secret (then type the entry; it is masked)")
+	}
+	m.input.EchoMode = textinput.EchoPassword
+	m.appendMessage("info", "Next entry is masked and not remembered")
+	return nil
+}
+
+// submitInput runs an entry from the input box. An entry typed while
+// masked (see /secret) ends masking and is not remembered for up/down
+// recall; plain text shows masked in the conversation, so it is not saved
+// either.
+func (m *Model) submitInput(value string) error {
+	if m.input.EchoMode == textinput.EchoNormal {
+		_, err := m.handleSubmit(value)
+		return err
+	}
+	m.input.EchoMode = textinput.EchoNormal
+	m.inputHistory.record("")
+	if strings.HasPrefix(value, "/") {
+		return m.handleCommand(value)
+	}
+	m.appendMessage("user", strings.Repeat("*", utf8.RuneCountInString(value)))
+	return nil
+}
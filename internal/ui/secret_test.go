@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSecretMasksNextEntry(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-1-0"}}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-1-0"
+	model.focus = focusInput
+	model.input.Focus()
+	submit := func(value string) {
+		t.Helper()
+		model.input.SetValue(value)
+		updated, _ := model.Update(tea.KeyMsg{Type: "enter"})
+		model = updated.(Model)
+	}
+
+	submit("/secret")
+	if model.input.EchoMode != textinput.EchoPassword {
+		t.Fatal("expected /secret to mask the input")
+	}
+	model.input.SetValue("/send hunter2")
+	if view := model.input.View(); strings.Contains(view, "hunter2") {
+		t.Fatalf("expected the entry masked while typing, got %q", view)
+	}
+	submit("/send hunter2")
+	if got := manager.sent["hiho-1-0"]; !slices.Equal(got, []string{"hunter2"}) {
+		t.Fatalf("expected the masked command to run, sent %q", got)
+	}
+	if model.input.EchoMode != textinput.EchoNormal {
+		t.Fatal("expected masking to end after one entry")
+	}
+
+	submit("/secret")
+	submit("hunter2")
+	if got := lastMessage(model).Content; got != "*******" {
+		t.Fatalf("expected plain text masked in the conversation, got %q", got)
+	}
+	if want := []string{"/secret"}; !slices.Equal(model.inputHistory.entries, want) {
+		t.Fatalf("input history = %q, want %q", model.inputHistory.entries, want)
+	}
+
+	if err := model.handleSecret("now"); err == nil {
+		t.Fatal("expected /secret with an argument to be rejected")
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+const sendUsage = "usage: /send <text> | /send --keys <key>..."
+
+// handleSend implements /send: it types text plus Enter into the current
+// session, presses Enter alone when there is no text, and with --keys
+// presses tmux key names (C-c, Escape, Up, ...) without a trailing Enter.
+func (m *Model) handleSend(arg string) error {
+	if m.currentSession == "" {
+		return fmt.Errorf("no active session to send to")
+	}
+	flag, rest := nextToken(arg)
+	var err error
+	switch {
+	case flag == "--keys":
+		keys := strings.Fields(rest)
+		if len(keys) == 0 {
+			return fmt.Errorf(sendUsage)
+		}
+		err = m.manager.PressKeys(m.currentSession, keys...)
+	case arg == "":
+		err = m.manager.PressKeys(m.currentSession, "Enter")
+	default:
+		err = m.manager.SendKeys(m.currentSession, arg)
+	}
+	if err != nil {
+		return err
+	}
+	// The session is about to change; capture on the next tick.
+	m.resetRefreshBackoff()
+	return nil
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSendTypesIntoCurrentSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		sent    []string
+		pressed []string
+	}{
+		{"text", "/send print(1 + 1)", []string{"print(1 + 1)"}, nil},
+		{"bare enter", "/send", nil, []string{"Enter"}},
+		{"control keys", "/send --keys C-c C-d", nil, []string{"C-c", "C-d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{sessions: []string{"hiho-123-0"}}
+			model := NewModel(manager, testConfig())
+			model.currentSession = "hiho-123-0"
+
+			if _, err := model.handleSubmit(tt.input); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if got := manager.sent["hiho-123-0"]; !slices.Equal(got, tt.sent) {
+				t.Fatalf("sent %q, want %q", got, tt.sent)
+			}
+			if got := manager.pressed["hiho-123-0"]; !slices.Equal(got, tt.pressed) {
+				t.Fatalf("pressed %q, want %q", got, tt.pressed)
+			}
+		})
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	if err := model.handleSend("ls"); err == nil {
+		t.Fatal("expected an error without a current session")
+	}
+	model.currentSession = "hiho-123-0"
+	if err := model.handleSend("--keys"); err == nil {
+		t.Fatal("expected usage error for --keys without keys")
+	}
+}
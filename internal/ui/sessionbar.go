@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sessionStatus is the at-a-glance state shown in the session bar.
+type sessionStatus int
+
+const (
+	statusIdle sessionStatus = iota
+	statusRunning
+	statusFailed
+)
+
+func (s sessionStatus) String() string {
+	switch s {
+	case statusRunning:
+		return "running"
+	case statusFailed:
+		return "failed"
+	default:
+		return "idle"
+	}
+}
+
+const (
+	sessionBarHeight = 1
+	// sessionBlockWidth is the block glyph plus the gap after it.
+	sessionBlockWidth = 2
+)
+
+var statusColors = map[sessionStatus]lipgloss.Color{
+	statusIdle:    "240",
+	statusRunning: "42",
+	statusFailed:  "196",
+}
+
+func (m *Model) setSessionStatus(name string, status sessionStatus) {
+	if m.sessionStatus == nil {
+		m.sessionStatus = make(map[string]sessionStatus)
+	}
+	m.sessionStatus[name] = status
+}
+
+// noteExits moves sessions whose command has exited from running to idle
+// (exit status 0) or failed (anything else), and reports the exit as a
+// session_finished event. The Tmux tab header shows the status itself.
+func (m *Model) noteExits() {
+	for _, session := range m.sessions {
+		if !session.Exited || m.sessionStatus[session.Name] != statusRunning {
+			continue
+		}
+		status := statusIdle
+		if session.ExitCode != 0 {
+			status = statusFailed
+		}
+		m.setSessionStatus(session.Name, status)
+		m.emitExit(session.Name, session.ExitCode)
+		if session.Name == m.currentSession {
+			m.markChanged(changedCapture)
+		}
+	}
+}
+
+// renderSessionBar draws one colored block per session: green for running,
+// red for failed and gray for idle. The current session uses a solid block.
+// A paused indicator follows the blocks while auto-refresh is paused.
+func (m Model) renderSessionBar() string {
+	bar := m.renderSessionBlocks()
+	if m.paused {
+		bar += "  " + pausedIndicator
+	}
+	return bar
+}
+
+func (m Model) renderSessionBlocks() string {
+	if len(m.sessions) == 0 {
+		return lipgloss.NewStyle().Foreground(statusColors[statusIdle]).Render("no sessions")
+	}
+	blocks := make([]string, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		glyph := "■"
+		if session.Name == m.currentSession {
+			glyph = "█"
+		}
+		color := statusColors[m.sessionStatus[session.Name]]
+		blocks = append(blocks, lipgloss.NewStyle().Foreground(color).Render(glyph))
+	}
+	return strings.Join(blocks, strings.Repeat(" ", sessionBlockWidth-1))
+}
+
+// sessionAtBarColumn maps a column in the session bar to a session index.
+func (m Model) sessionAtBarColumn(x int) (int, bool) {
+	if x < 0 {
+		return 0, false
+	}
+	idx := x / sessionBlockWidth
+	if idx >= len(m.sessions) {
+		return 0, false
+	}
+	return idx, true
+}
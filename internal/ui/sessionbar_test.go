@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderSessionBarColorsByStatus(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"}}
+	model := sizedModel(t, manager, 90, 30)
+	model.currentSession = "hiho-123-2"
+	model.setSessionStatus("hiho-123-0", statusRunning)
+	model.setSessionStatus("hiho-123-1", statusFailed)
+
+	bar := model.renderSessionBar()
+	blocks := strings.Split(bar, " ")
+	if len(blocks) != 3 {
+		t.Fatalf("expected three blocks, got %q", bar)
+	}
+	wants := []string{"\033[38;5;42m■", "\033[38;5;196m■", "\033[38;5;240m█"}
+	for i, want := range wants {
+		if !strings.HasPrefix(blocks[i], want) {
+			t.Errorf("block %d = %q, want prefix %q", i, blocks[i], want)
+		}
+	}
+}
+
+func TestClickSessionBarSwitchesSession(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1"},
+		outputByName: map[string]string{"hiho-123-1": "out1"},
+	}
+	model := sizedModel(t, manager, 90, 30)
+
+	updated, _ := model.Update(tea.MouseMsg{X: 2, Y: model.bodyHeight(), Type: tea.MouseLeft})
+	model = updated.(Model)
+	if model.currentSession != "hiho-123-1" {
+		t.Fatalf("expected click on second block to switch to hiho-123-1, got %q", model.currentSession)
+	}
+
+	updated, _ = model.Update(tea.MouseMsg{X: 40, Y: model.bodyHeight(), Type: tea.MouseLeft})
+	model = updated.(Model)
+	if model.currentSession != "hiho-123-1" {
+		t.Fatalf("expected click past the blocks to do nothing, got %q", model.currentSession)
+	}
+}
+
+func TestNewSessionIsMarkedRunning(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/new sleep 10"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.sessionStatus[model.currentSession] != statusRunning {
+		t.Fatalf("expected new session to be marked running")
+	}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"hiho/internal/events"
+	"hiho/internal/tmux"
+)
+
+// logSeparator goes between header fields.
+const logSeparator = " · "
+
+// setSessionLog stores a new capture of the current session, remembering
+// the one it replaces for /diff. Only output new since the last capture is
+// appended to the log (see captureHistory). Switching sessions starts over
+// without a diff, following the new session's output.
+func (m *Model) setSessionLog(output string) {
+	output = m.history.apply(m.currentSession, output)
+	if m.diff.session != m.currentSession {
+		m.diff.session = m.currentSession
+		m.diff.previous = output
+		delete(m.scrolledBack, tabTmux)
+		m.emit(events.CaptureUpdated, m.currentSession)
+	} else if output != m.sessionLog {
+		m.diff.previous = m.sessionLog
+		m.emit(events.CaptureUpdated, m.currentSession)
+	}
+	m.sessionLog = output
+	m.capturedAt = time.Now()
+	m.markChanged(changedCapture)
+}
+
+// setSessionCommand remembers the command a session was started with.
+func (m *Model) setSessionCommand(name, command string) {
+	if m.sessionCmds == nil {
+		m.sessionCmds = make(map[string]string)
+	}
+	m.sessionCmds[name] = command
+}
+
+// sessionByName looks a session up in the cached session list.
+func (m Model) sessionByName(name string) (tmux.Session, bool) {
+	for _, session := range m.sessions {
+		if session.Name == name {
+			return session, true
+		}
+	}
+	return tmux.Session{}, false
+}
+
+// wrapSessionLog frames the current session's log with a header (name,
+// command, capture time) and a footer (status). Metadata hiho does not
+// know, such as the command of a session it did not start, is left out.
+func (m Model) wrapSessionLog(log string) string {
+	theme := m.config.Theme
+	parts := []string{lipgloss.NewStyle().Bold(true).Render(m.displayName(m.currentSession))}
+	if command := m.sessionCmds[m.currentSession]; command != "" {
+		parts = append(parts, command)
+	}
+	if !m.capturedAt.IsZero() {
+		parts = append(parts, "captured "+m.capturedAt.Format("15:04:05"))
+	}
+	if session, ok := m.sessionByName(m.currentSession); ok && session.Exited {
+		parts = append(parts, fmt.Sprintf("exited: %d", session.ExitCode))
+	}
+	header := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.LogHeader)).Render(strings.Join(parts, logSeparator))
+
+	lines := []string{header, log}
+	if status, ok := m.sessionStatus[m.currentSession]; ok {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(theme.LogFooter)).Render("status: "+status.String()))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
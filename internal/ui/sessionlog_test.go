@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestSessionLogHeaderShowsNameAndCommand(t *testing.T) {
+	manager := &stubManager{outputByName: map[string]string{"hiho-123-0": "listening on :3000\n"}}
+	model := NewModel(manager, testConfig())
+	if _, err := model.handleSubmit("/new npm start"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	model.activeTab = tabTmux
+
+	lines := strings.Split(ansi.Strip(model.renderBody()), "\n")
+	header := lines[0]
+	for _, want := range []string{"hiho-123-0", "npm start", "captured "} {
+		if !strings.Contains(header, want) {
+			t.Fatalf("expected header to contain %q, got %q", want, header)
+		}
+	}
+	if footer := strings.TrimSpace(lines[len(lines)-1]); footer != "status: running" {
+		t.Fatalf("expected status footer, got %q", footer)
+	}
+}
+
+func TestSessionLogWithoutMetadataShowsOnlyName(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	model.currentSession = "other"
+	model.activeTab = tabTmux
+
+	body := ansi.Strip(model.renderBody())
+	if strings.TrimSpace(body) != "other" {
+		t.Fatalf("expected only the session name for a session hiho did not start, got %q", body)
+	}
+}
+
+func TestColoredCaptureKeepsEscapesWhole(t *testing.T) {
+	red := "\x1b[31m" + strings.Repeat("error ", 40) + "\x1b[0m"
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": red + "\n\x1b[1;32mok\x1b[0m\n"},
+	}
+	model := sizedModel(t, manager, 80, 20)
+	if _, err := model.handleSubmit("/switch hiho-123-0"); err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	model.setTab(tabTmux)
+	model.refreshViewport()
+
+	view := model.View()
+	if !strings.Contains(view, "\x1b[31merror") {
+		t.Fatalf("expected the capture's colors in the view, got %q", view)
+	}
+	for i, line := range strings.Split(view, "\n") {
+		if got := len([]rune(ansi.Strip(line))); got > 80 {
+			t.Fatalf("line %d: visible width %d exceeds 80: %q", i, got, line)
+		}
+		if strings.Contains(ansi.Strip(line), "\x1b") {
+			t.Fatalf("line %d: broken escape sequence in %q", i, line)
+		}
+	}
+}
+
+func TestExitedSessionShowsStatusInHeader(t *testing.T) {
+	tests := []struct {
+		code   int
+		want   string
+		status sessionStatus
+	}{
+		{0, "exited: 0", statusIdle},
+		{1, "exited: 1", statusFailed},
+	}
+	for _, tt := range tests {
+		manager := &stubManager{outputByName: map[string]string{"hiho-123-0": "done\n"}}
+		model := NewModel(manager, testConfig())
+		if _, err := model.handleSubmit("/new go test ./..."); err != nil {
+			t.Fatalf("handleSubmit error: %v", err)
+		}
+		if strings.Contains(model.renderBody(), "exited:") {
+			t.Fatalf("did not expect an exit status while running")
+		}
+
+		manager.exitCodes = map[string]int{"hiho-123-0": tt.code}
+		model.refreshSessions()
+		if body := model.renderBody(); !strings.Contains(body, tt.want) {
+			t.Fatalf("expected %q in header, got %q", tt.want, body)
+		}
+		if got := model.sessionStatus["hiho-123-0"]; got != tt.status {
+			t.Fatalf("exit %d: status = %v, want %v", tt.code, got, tt.status)
+		}
+	}
+}
+
+func TestLongLinesWrapInMainPanel(t *testing.T) {
+	long := "error: " + strings.Repeat("x", 150) + " END"
+	tests := []struct {
+		name     string
+		wrap     bool
+		wantTail bool
+	}{
+		{"wrap_lines on", true, true},
+		{"wrap_lines off", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.WrapLines = tt.wrap
+			manager := &stubManager{}
+			model := NewModel(manager, cfg)
+			updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+			model = updated.(Model)
+			model.activeTab = tabTmux
+			model.currentSession = "hiho-123-0"
+			model.sessionLog = long
+			model.refreshViewport()
+
+			view := ansi.Strip(model.View())
+			if got := strings.Contains(view, "END"); got != tt.wantTail {
+				t.Fatalf("end of the long line shown = %v, want %v", got, tt.wantTail)
+			}
+		})
+	}
+}
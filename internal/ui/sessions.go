@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"hiho/internal/events"
+	"hiho/internal/tmux"
+)
+
+// dropMissingSession forgets a session that disappeared outside hiho (for
+// example killed from another terminal) and moves on to a neighboring
+// session so the UI is not left pointing at a ghost.
+func (m *Model) dropMissingSession(name string) error {
+	idx := slices.IndexFunc(m.sessions, func(s tmux.Session) bool { return s.Name == name })
+
+	m.emit(events.SessionFinished, name)
+	m.refreshSessions()
+	m.sessions = slices.DeleteFunc(m.sessions, func(s tmux.Session) bool { return s.Name == name })
+	delete(m.sessionStatus, name)
+	delete(m.sessionTags, name)
+	delete(m.sessionLabels, name)
+	delete(m.sessionCmds, name)
+	delete(m.sessionEnv, name)
+	delete(m.paneSizes, name)
+	m.dropConversations(name)
+	m.currentSession = ""
+	m.sessionLog = ""
+	m.markChanged(changedSessions | changedCapture)
+	m.appendNotice("info", fmt.Sprintf("Session %s no longer exists", name))
+
+	if len(m.sessions) == 0 {
+		m.sessionIndex = 0
+		return nil
+	}
+	m.sessionIndex = min(max(idx, 0), len(m.sessions)-1)
+	m.currentSession = m.sessions[m.sessionIndex].Name
+	return m.captureCurrentSession()
+}
+
+// sessionFailure describes err for the named session without repeating the
+// name when err is a tmux.SessionError that already carries it.
+func sessionFailure(name string, err error) string {
+	var sessionErr *tmux.SessionError
+	if errors.As(err, &sessionErr) && sessionErr.Name == name {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s: %v", name, err)
+}
+
+func (m *Model) refreshSessions() {
+	sessions, err := m.manager.ListHiho()
+	if err == nil {
+		// Ordered here rather than by the manager so /reload applies it
+		tmux.SortByCreation(sessions, m.config.SessionOrder == "oldest")
+		if !slices.Equal(sessions, m.sessions) {
+			m.markChanged(changedSessions)
+		}
+		// A new session lands at the top when newest first, so keep the
+		// selection on the same session rather than the same row
+		if m.sessionIndex >= 0 && m.sessionIndex < len(m.sessions) {
+			selected := m.sessions[m.sessionIndex].Name
+			if i := slices.IndexFunc(sessions, func(s tmux.Session) bool { return s.Name == selected }); i >= 0 {
+				m.sessionIndex = i
+			}
+		}
+		m.sessions = sessions
+		m.adoptTags()
+		m.clampToFilter()
+		m.noteExits()
+	}
+}
+
+// createSession starts a new tmux session for the parsed /new options and
+// makes it current. Env file values are exported ahead of the command.
+func (m *Model) createSession(opts newOptions) error {
+	session, err := m.manager.NewSessionWithEnv(opts.dir, opts.env, opts.command)
+	if err != nil {
+		return err
+	}
+	if opts.name != "" {
+		if session, err = m.labelSession(session, opts.name); err != nil {
+			return err
+		}
+	}
+	m.setSessionCommand(session.Name, opts.command)
+	if len(opts.env) > 0 {
+		// Kept here rather than in tmux, for /dup to replay
+		if m.sessionEnv == nil {
+			m.sessionEnv = make(map[string][]string)
+		}
+		m.sessionEnv[session.Name] = opts.env
+	}
+	if opts.tag != "" {
+		if err := m.tagSession(session.Name, opts.tag); err != nil {
+			return err
+		}
+	}
+	m.emit(events.SessionCreated, session.Name)
+	m.currentSession = session.Name
+	m.setSessionStatus(session.Name, statusRunning)
+	m.switchToTmuxFor("new")
+	m.refreshSessions()
+	return m.captureCurrentSession()
+}
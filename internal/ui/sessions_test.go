@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+func TestCaptureOfMissingSessionSelectsNeighbor(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"},
+		outputByName: map[string]string{"hiho-123-2": "out2"},
+		captureErr: map[string]error{
+			"hiho-123-1": fmt.Errorf("capture output: %w", tmux.ErrSessionNotFound),
+		},
+	}
+	model := NewModel(manager, testConfig())
+	model.refreshSessions()
+	model.sessionIndex = 1
+	model.currentSession = "hiho-123-1"
+	model.sessionLog = "stale"
+
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("expected recovery without error, got %v", err)
+	}
+
+	for _, s := range model.sessions {
+		if s.Name == "hiho-123-1" {
+			t.Fatalf("expected ghost session to be dropped, got %v", model.sessions)
+		}
+	}
+	if model.currentSession != "hiho-123-2" || model.sessionIndex != 1 {
+		t.Fatalf("expected neighbor hiho-123-2 at index 1, got %q at %d", model.currentSession, model.sessionIndex)
+	}
+	if model.sessionLog != "out2" {
+		t.Fatalf("expected neighbor output to be captured, got %q", model.sessionLog)
+	}
+}
+
+func TestCaptureOfLastMissingSessionClearsView(t *testing.T) {
+	manager := &stubManager{
+		captureErr: map[string]error{"hiho-123-0": tmux.ErrSessionNotFound},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.sessionLog = "stale"
+
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("expected recovery without error, got %v", err)
+	}
+	if model.currentSession != "" || model.sessionLog != "" {
+		t.Fatalf("expected current session cleared, got %q / %q", model.currentSession, model.sessionLog)
+	}
+	if last := lastMessage(model); last.Role != "info" {
+		t.Fatalf("expected an info message about the missing session, got %+v", last)
+	}
+}
+
+func TestCaptureFailureMarksSessionFailed(t *testing.T) {
+	manager := &stubManager{
+		sessions: []string{"hiho-123-0"},
+		captureErr: map[string]error{
+			"hiho-123-0": &tmux.SessionError{Name: "hiho-123-0", Op: tmux.OpCapture, Err: fmt.Errorf("exit status 1")},
+		},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+
+	err := model.captureCurrentSession()
+	if err == nil || err.Error() != "session hiho-123-0: capture failed: exit status 1" {
+		t.Fatalf("expected session capture error, got %v", err)
+	}
+	if model.sessionStatus["hiho-123-0"] != statusFailed {
+		t.Fatalf("expected session to be marked failed")
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sidebarRow is one row of sidebar content inside the border. Session rows
+// also carry their command, drawn dim after text, and a status glyph
+// right-aligned against the border.
+type sidebarRow struct {
+	text    string
+	session int    // index into m.sessions, or -1 for titles, headers and hints
+	glyph   string // status dot, session rows only
+	detail  string // command, drawn dim after text
+}
+
+// Sidebar status dots and their colors.
+const (
+	statusGlyph        = "●"
+	runningGlyphColor  = "42"
+	finishedGlyphColor = "240"
+)
+
+// sidebarLayout returns the rows drawn inside the sidebar border. The title
+// stays pinned while the session list scrolls to keep the selection in view,
+// and a header row is inserted wherever the session group changes.
+func (m Model) sidebarLayout() []sidebarRow {
+	w := m.sidebarWidth() - 2 // Account for border
+	h := m.bodyHeight() - 2   // Account for border
+
+	rows := []sidebarRow{{text: "Sessions", session: -1}}
+	if m.filter.active() {
+		rows = append(rows, sidebarRow{text: m.filterPrompt(), session: -1})
+	}
+	if len(m.sessions) == 0 {
+		return append(rows,
+			sidebarRow{text: "No sessions", session: -1},
+			sidebarRow{text: "Use /new <cmd>", session: -1},
+		)
+	}
+
+	var list []sidebarRow
+	selectedRow := 0
+	group := ""
+	for i, session := range m.sessions {
+		if !m.matches(i) {
+			continue
+		}
+		if tag := m.sessionTags[session.Name]; tag != group {
+			group = tag
+			if tag != "" {
+				list = append(list, sidebarRow{text: "[" + tag + "]", session: -1})
+			}
+		}
+		if i == m.sessionIndex {
+			selectedRow = len(list)
+		}
+
+		prefix := "  "
+		if session.Name == m.currentSession {
+			prefix = "> "
+		}
+		name := m.displayName(session.Name)
+		// Truncate if too long (leaving room for the status dot)
+		maxLen := w - 6
+		if maxLen > 3 {
+			name = truncateCells(name, maxLen)
+		}
+		// Show the command only if it fits after the name
+		detail := ""
+		if room := maxLen - lipgloss.Width(name) - 1; session.Command != "" && room >= 3 {
+			detail = truncateCells(session.Command, room)
+		}
+		glyphColor := finishedGlyphColor
+		if session.Running {
+			glyphColor = runningGlyphColor
+		}
+		list = append(list, sidebarRow{
+			text:    prefix + name,
+			session: i,
+			glyph:   lipgloss.NewStyle().Foreground(lipgloss.Color(glyphColor)).Render(statusGlyph),
+			detail:  detail,
+		})
+	}
+
+	if len(list) == 0 {
+		return append(rows, sidebarRow{text: "No matches", session: -1})
+	}
+
+	visible := h - len(rows)
+	offset := 0
+	if visible > 0 && selectedRow >= visible {
+		offset = selectedRow - visible + 1
+	}
+	end := len(list)
+	if visible > 0 && offset+visible < end {
+		end = offset + visible
+	}
+	return append(rows, list[offset:end]...)
+}
+
+// sessionAtRow maps a row inside the sidebar border to a session index.
+func (m Model) sessionAtRow(row int) (int, bool) {
+	rows := m.sidebarLayout()
+	if row < 0 || row >= len(rows) || rows[row].session < 0 {
+		return 0, false
+	}
+	return rows[row].session, true
+}
+
+func (m Model) renderSidebar() string {
+	w := m.sidebarWidth() - 2 // Account for border
+	h := m.bodyHeight() - 2   // Account for border
+
+	theme := m.config.Theme
+	rows := m.sidebarLayout()
+	lines := make([]string, 0, len(rows))
+	for i, row := range rows {
+		line := row.text
+		selected := row.session == m.sessionIndex
+		switch {
+		case i == 0:
+			line = lipgloss.NewStyle().Bold(true).Width(w).Align(lipgloss.Center).Render(line)
+		case row.session < 0:
+			// Group headers and hints are left unstyled
+		case selected && m.focus == focusSidebar:
+			line = styleFromSpec(theme.SelectedFocused).Render(line)
+		case selected && !isNoStyle(theme.SelectedUnfocused):
+			line = styleFromSpec(theme.SelectedUnfocused).Render(line)
+		case m.sessions[row.session].Name == m.currentSession:
+			line = styleFromSpec(theme.Current).Render(line)
+		}
+		if row.session >= 0 {
+			if row.detail != "" {
+				line += " " + lipgloss.NewStyle().Faint(true).Render(row.detail)
+			}
+			// The status dot sits against the right border
+			gap := w - lipgloss.Width(line)
+			line += lipgloss.NewStyle().Width(gap).Align(lipgloss.Right).Render(row.glyph)
+		}
+		lines = append(lines, line)
+	}
+
+	// Apply border and fixed dimensions
+	style := m.panelStyle(focusSidebar).
+		Width(w).
+		Height(h)
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// truncateCells shortens s to at most width terminal cells, marking the cut
+// with "...". Wide characters count as two cells and are never split.
+func truncateCells(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := lipgloss.Width(string(r))
+		if used+w > width-3 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "..."
+}
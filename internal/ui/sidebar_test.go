@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"hiho/internal/ansi"
+	"hiho/internal/tmux"
+)
+
+func sizedModel(t *testing.T, manager *stubManager, width, height int) Model {
+	t.Helper()
+	model := NewModel(manager, testConfig())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return updated.(Model)
+}
+
+func clickSidebarRow(model Model, row int) Model {
+	// Rows start inside the top border
+	updated, _ := model.Update(tea.MouseMsg{X: 1, Y: row + 1, Type: tea.MouseLeft})
+	return updated.(Model)
+}
+
+func TestSidebarClickMappingWithScrollOffset(t *testing.T) {
+	manager := &stubManager{}
+	for i := 0; i < 12; i++ {
+		manager.sessions = append(manager.sessions, fmt.Sprintf("hiho-123-%02d", i))
+	}
+	// Body height 10 leaves 8 rows inside the border: title + 7 sessions
+	model := sizedModel(t, manager, 90, 10+4+sessionBarHeight+statusBarHeight)
+	model.sessionIndex = 10
+
+	rows := model.sidebarLayout()
+	if rows[1].session != 4 {
+		t.Fatalf("expected list scrolled to session 4, got row %+v", rows[1])
+	}
+
+	model = clickSidebarRow(model, 1)
+	if model.currentSession != "hiho-123-04" {
+		t.Fatalf("expected click on first list row to pick hiho-123-04, got %q", model.currentSession)
+	}
+
+	model.sessionIndex = 10
+	model = clickSidebarRow(model, 7)
+	if model.currentSession != "hiho-123-10" {
+		t.Fatalf("expected click on last row to pick hiho-123-10, got %q", model.currentSession)
+	}
+}
+
+func TestSidebarClickMappingWithGroupHeader(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1", "hiho-123-2"}}
+	model := sizedModel(t, manager, 90, 30)
+	model.sessionTags = map[string]string{"hiho-123-1": "web", "hiho-123-2": "web"}
+
+	// Rows: title, hiho-123-0, [web], hiho-123-1, hiho-123-2
+	model = clickSidebarRow(model, 2)
+	if model.currentSession != "" {
+		t.Fatalf("expected click on group header to select nothing, got %q", model.currentSession)
+	}
+
+	model = clickSidebarRow(model, 3)
+	if model.currentSession != "hiho-123-1" {
+		t.Fatalf("expected click below header to pick hiho-123-1, got %q", model.currentSession)
+	}
+	if model.sessionIndex != 1 {
+		t.Fatalf("expected sessionIndex 1, got %d", model.sessionIndex)
+	}
+}
+
+func TestSidebarSelectionThemeStates(t *testing.T) {
+	cfg := testConfig()
+	cfg.Theme.SelectedFocused = "reverse"
+	cfg.Theme.SelectedUnfocused = "faint"
+	cfg.Theme.Current = "fg:75"
+
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := NewModel(manager, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+	model = updated.(Model)
+	model.currentSession = "hiho-123-0"
+	model.sessionIndex = 1
+
+	tests := []struct {
+		name  string
+		focus focusArea
+		want  string
+	}{
+		{"selected focused", focusSidebar, "\x1b[7m  hiho-123-1"},
+		{"selected unfocused", focusInput, "\x1b[2m  hiho-123-1"},
+		{"current", focusInput, "\x1b[38;5;75m> hiho-123-0"},
+	}
+	for _, tt := range tests {
+		model.focus = tt.focus
+		if got := model.renderSidebar(); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: expected %q in sidebar:\n%q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSidebarShowsStatusAndCommand(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 120, 20)
+	model.sessions = []tmux.Session{
+		{Name: "hiho-123-0", Command: "npm", Running: true},
+		{Name: "hiho-123-1", Command: "bash"},
+	}
+
+	lines := strings.Split(model.renderSidebar(), "\n")
+	running, finished := lines[2], lines[3]
+	if !strings.Contains(running, "\x1b[38;5;42m●") || !strings.Contains(running, "npm") {
+		t.Fatalf("expected green dot and command, got %q", running)
+	}
+	if !strings.Contains(finished, "\x1b[38;5;240m●") || !strings.Contains(finished, "bash") {
+		t.Fatalf("expected gray dot and command, got %q", finished)
+	}
+}
+
+func TestSidebarCentersTitleAndRightAlignsDots(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 120, 20)
+	model.sessions = []tmux.Session{{Name: "hiho-123-0", Command: "npm", Running: true}}
+
+	lines := strings.Split(model.renderSidebar(), "\n")
+	title := ansi.Strip(lines[1])
+	left := strings.Index(title, "Sessions") - len("│")
+	right := len(title) - strings.Index(title, "Sessions") - len("Sessions") - len("│")
+	if left < 2 || left-right < -1 || left-right > 1 {
+		t.Fatalf("expected title centered, got %q", title)
+	}
+	if row := ansi.Strip(lines[2]); !strings.HasSuffix(row, "●│") {
+		t.Fatalf("expected status dot against the right border, got %q", row)
+	}
+}
+
+func TestFocusedPanelHasBrightBorder(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 120, 30)
+	render := func(m Model, area focusArea) string {
+		switch area {
+		case focusSidebar:
+			return m.renderSidebar()
+		case focusMain:
+			return m.renderMainPanel()
+		default:
+			return m.renderInputPanel()
+		}
+	}
+
+	areas := []focusArea{focusSidebar, focusMain, focusInput}
+	for _, focus := range areas {
+		model.focus = focus
+		for _, area := range areas {
+			want := "\x1b[38;5;240m┌"
+			if area == focus {
+				want = "\x1b[38;5;51m┌"
+			}
+			if out := render(model, area); !strings.HasPrefix(out, want) {
+				t.Errorf("focus %d, panel %d: expected border %q, got %q", focus, area, want, out[:min(len(out), 20)])
+			}
+		}
+	}
+}
+
+func TestTruncateCells(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"hiho-build-server", 10, "hiho-bu..."},
+		{"日本語のセッション", 10, "日本語..."},
+		{"café-ünïcode-name", 8, "café-..."},
+	}
+	for _, tt := range tests {
+		if got := truncateCells(tt.in, tt.width); got != tt.want {
+			t.Errorf("truncateCells(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if got := lipgloss.Width(truncateCells(tt.in, tt.width)); got > tt.width {
+			t.Errorf("truncateCells(%q, %d) is %d cells wide", tt.in, tt.width, got)
+		}
+	}
+}
+
+func TestSidebarFitsWideNamesAndCommands(t *testing.T) {
+	model := sizedModel(t, &stubManager{}, 60, 20)
+	model.sessions = []tmux.Session{{Name: "hiho-0", Command: "ビルドサーバーを起動する", Running: true}}
+	model.sessionLabels = map[string]string{"hiho-0": "日本語のとても長いセッション名"}
+	model.currentSession = "hiho-0"
+
+	lines := strings.Split(model.renderSidebar(), "\n")
+	width := lipgloss.Width(lines[0])
+	row := lines[2]
+	if got := lipgloss.Width(row); got != width {
+		t.Fatalf("expected the row %d cells like the border, got %d: %q", width, got, ansi.Strip(row))
+	}
+	if !utf8.ValidString(row) || strings.ContainsRune(row, utf8.RuneError) {
+		t.Fatalf("expected whole characters only, got %q", row)
+	}
+	if !strings.Contains(row, "> 日本語の...") || !strings.Contains(row, "...") || !strings.HasSuffix(ansi.Strip(row), "●│") {
+		t.Fatalf("expected a truncated name and the dot against the border, got %q", ansi.Strip(row))
+	}
+}
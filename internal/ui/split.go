@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+
+	"hiho/internal/config"
+	"hiho/internal/tmux"
+)
+
+// splitDivider separates the two panes of a split main panel.
+const splitDivider = "│"
+
+// splitView is the second pane opened by /split. It captures its session
+// independently of the current one.
+type splitView struct {
+	session  string
+	viewport viewport.Model
+	history  captureHistory
+	focused  bool // the split pane, not the main one, has focus
+	// scrolledBack stops the pane following its session's newest output
+	scrolledBack bool
+}
+
+// splitWidths divides the main panel's inner width between the two panes,
+// leaving one column for the divider. ratio is the left pane's share; the
+// right pane takes any rounding remainder.
+func splitWidths(total int, ratio float64) (left, right int) {
+	if total <= 1 {
+		return max(total, 0), 0
+	}
+	left = ratioColumns(ratio, total-1)
+	return left, total - 1 - left
+}
+
+// layoutViewports sizes the main viewport, and the split pane if open, to
+// the current window, and resizes the tmux windows shown in them to match.
+func (m *Model) layoutViewports() {
+	width := m.mainWidth() - 4   // Account for borders
+	height := m.bodyHeight() - 4 // Account for borders and tab bar
+	m.viewport.Height = height
+	m.applyWrap()
+	if m.split != nil {
+		ratio := validRatio(m.config.Layout.SplitRatio, config.DefaultLayout().SplitRatio)
+		left, right := splitWidths(width, ratio)
+		m.viewport.SetWidth(left)
+		m.split.viewport.SetWidth(right)
+		m.split.viewport.Height = height
+	} else {
+		m.viewport.SetWidth(width)
+	}
+	if m.currentSession != "" {
+		m.fitPane(m.currentSession, paneSize{m.viewport.Width, m.viewport.Height})
+	}
+	if m.split != nil {
+		m.fitPane(m.split.session, paneSize{m.split.viewport.Width, m.split.viewport.Height})
+	}
+	m.markChanged(changedView)
+}
+
+// applyWrap turns soft wrapping of long lines in the main panel, and the
+// split pane if open, on or off to follow wrap_lines.
+func (m *Model) applyWrap() {
+	m.viewport.SetWrap(m.config.WrapLines)
+	if m.split != nil {
+		m.split.viewport.SetWrap(m.config.WrapLines)
+	}
+}
+
+// handleSplit implements /split <session> and /split off.
+func (m *Model) handleSplit(arg string) error {
+	switch arg {
+	case "":
+		return fmt.Errorf("usage: /split <session>|off")
+	case "off":
+		if m.split == nil {
+			return fmt.Errorf("no split is open")
+		}
+		m.closeSplit()
+		return nil
+	}
+
+	name, ok := m.resolveSession(arg)
+	if !ok {
+		return fmt.Errorf("no session named %q", arg)
+	}
+	m.split = &splitView{session: name}
+	m.layoutViewports()
+	return m.captureSplit()
+}
+
+// resolveSession finds a session by tmux name or display label.
+func (m *Model) resolveSession(ref string) (string, bool) {
+	m.refreshSessions()
+	for _, session := range m.sessions {
+		if session.Name == ref || m.displayName(session.Name) == ref {
+			return session.Name, true
+		}
+	}
+	return "", false
+}
+
+// captureSplit refreshes the split pane from its own session. A session
+// that has gone away closes the split.
+func (m *Model) captureSplit() error {
+	if m.split == nil {
+		return nil
+	}
+	output, err := m.capture(m.split.session, m.split.viewport.Width, m.split.viewport.Height)
+	if errors.Is(err, tmux.ErrSessionNotFound) {
+		name := m.split.session
+		m.closeSplit()
+		return fmt.Errorf("split session %s no longer exists", name)
+	}
+	if err != nil {
+		return err
+	}
+	m.split.viewport.SetContent(m.split.history.apply(m.split.session, output))
+	if !m.split.scrolledBack && m.config.FollowOutput {
+		m.split.viewport.GotoBottom()
+	}
+	m.markChanged(changedView)
+	return nil
+}
+
+func (m *Model) closeSplit() {
+	m.split = nil
+	m.layoutViewports()
+}
+
+// renderSplitBody places the main viewport and the split pane side by side.
+func (m Model) renderSplitBody() string {
+	height := m.viewport.Height
+	left := lipgloss.NewStyle().Width(m.viewport.Width).Height(height).Render(m.viewport.View())
+	right := lipgloss.NewStyle().Width(m.split.viewport.Width).Height(height).Render(m.split.viewport.View())
+	divider := strings.TrimSuffix(strings.Repeat(splitDivider+"\n", max(height, 1)), "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, divider, right)
+}
+
+// splitFocusHint names the focused pane of a split for the footer.
+func (m Model) splitFocusHint() string {
+	if m.split.focused {
+		return "split: " + m.displayName(m.split.session) + " focused"
+	}
+	return "split: left pane focused"
+}
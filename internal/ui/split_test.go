@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitWidths(t *testing.T) {
+	tests := []struct {
+		total, left, right int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{2, 0, 1},
+		{11, 5, 5},
+		{12, 5, 6},
+	}
+	for _, tt := range tests {
+		left, right := splitWidths(tt.total, 0.5)
+		if left != tt.left || right != tt.right {
+			t.Errorf("splitWidths(%d) = %d, %d; want %d, %d", tt.total, left, right, tt.left, tt.right)
+		}
+		if tt.total > 1 && left+right+1 != tt.total {
+			t.Errorf("splitWidths(%d) does not leave one divider column", tt.total)
+		}
+	}
+}
+
+func TestSplitCapturesEachPaneIndependently(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0", "hiho-123-1"},
+		outputByName: map[string]string{"hiho-123-0": "left output", "hiho-123-1": "right output"},
+	}
+	model := sizedModel(t, manager, 120, 30)
+	model.currentSession = "hiho-123-0"
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+
+	if _, err := model.handleSubmit("/split hiho-123-1"); err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	full := model.mainWidth() - 4
+	if model.viewport.Width+model.split.viewport.Width+1 != full {
+		t.Fatalf("pane widths %d + %d do not fill %d", model.viewport.Width, model.split.viewport.Width, full)
+	}
+
+	manager.outputByName["hiho-123-1"] = "right output\nmore"
+	manager.captured = nil
+	model.autoRefresh(model.lastActivity)
+	if !slices.Contains(manager.captured, "hiho-123-1") {
+		t.Fatalf("expected split session to be captured, got %v", manager.captured)
+	}
+	if !strings.Contains(model.split.viewport.View(), "more") {
+		t.Fatalf("split pane not updated: %q", model.split.viewport.View())
+	}
+	if strings.Contains(model.sessionLog, "right") {
+		t.Fatalf("main pane picked up split output: %q", model.sessionLog)
+	}
+
+	if _, err := model.handleSubmit("/split off"); err != nil {
+		t.Fatalf("split off: %v", err)
+	}
+	if model.split != nil || model.viewport.Width != full {
+		t.Fatalf("expected main viewport back to full width %d, got %d", full, model.viewport.Width)
+	}
+}
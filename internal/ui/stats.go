@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"hiho/internal/tmux"
+)
+
+// handleStats implements /stats: CPU and memory of the current session's
+// foreground process.
+func (m *Model) handleStats() error {
+	if m.currentSession == "" {
+		return fmt.Errorf("no active session")
+	}
+	stats, err := m.manager.Stats(m.currentSession)
+	if errors.Is(err, tmux.ErrProcessExited) {
+		m.appendMessage("info", fmt.Sprintf("%s: process has exited", m.displayName(m.currentSession)))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m.appendMessage("info", fmt.Sprintf("%s: %s (pid %d) CPU %.1f%% RSS %s",
+		m.displayName(m.currentSession), stats.Command, stats.PID, stats.CPU, formatKB(stats.RSSKB)))
+	return nil
+}
+
+// formatKB renders a KiB count with a readable unit.
+func formatKB(kb int) string {
+	switch {
+	case kb >= 1024*1024:
+		return fmt.Sprintf("%.1f GiB", float64(kb)/(1024*1024))
+	case kb >= 1024:
+		return fmt.Sprintf("%.1f MiB", float64(kb)/1024)
+	default:
+		return fmt.Sprintf("%d KiB", kb)
+	}
+}
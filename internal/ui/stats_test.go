@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"testing"
+
+	"hiho/internal/tmux"
+)
+
+func TestStatsCommandReportsProcess(t *testing.T) {
+	manager := &stubManager{
+		sessions: []string{"hiho-123-0", "hiho-123-1"},
+		stats: map[string]tmux.ProcessStats{
+			"hiho-123-0": {PID: 4242, Command: "node", CPU: 12.5, RSSKB: 204800},
+		},
+	}
+	model := NewModel(manager, testConfig())
+
+	model.currentSession = "hiho-123-0"
+	if _, err := model.handleSubmit("/stats"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if got := lastMessage(model).Content; got != "hiho-123-0: node (pid 4242) CPU 12.5% RSS 200.0 MiB" {
+		t.Fatalf("unexpected stats message %q", got)
+	}
+
+	model.currentSession = "hiho-123-1"
+	if _, err := model.handleSubmit("/stats"); err != nil {
+		t.Fatalf("expected exited process to be reported, not fail: %v", err)
+	}
+	if got := lastMessage(model).Content; got != "hiho-123-1: process has exited" {
+		t.Fatalf("unexpected exited message %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const statusBarHeight = 1
+
+// renderStatusBar draws the bottom row: the number of hiho sessions and
+// the one in the main panel on the left, a clock advanced by the refresh
+// tick on the right.
+func (m Model) renderStatusBar() string {
+	left := fmt.Sprintf("%d sessions", len(m.sessions))
+	if len(m.sessions) == 1 {
+		left = "1 session"
+	}
+	if m.currentSession != "" {
+		left += " • " + m.displayName(m.currentSession)
+	}
+	clock := m.clock.Format("15:04")
+	gap := max(m.width-lipgloss.Width(left)-lipgloss.Width(clock)-2, 1)
+	line := " " + left + strings.Repeat(" ", gap) + clock + " "
+	return lipgloss.NewStyle().Faint(true).Width(max(m.width, 0)).Render(line)
+}
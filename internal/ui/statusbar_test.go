@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hiho/internal/ansi"
+)
+
+func TestStatusBarShowsCountCurrentSessionAndClock(t *testing.T) {
+	manager := &stubManager{sessions: []string{"hiho-123-0", "hiho-123-1"}}
+	model := sizedModel(t, manager, 100, 30)
+	model.currentSession = "hiho-123-1"
+	model.sessionLabels = map[string]string{"hiho-123-1": "web"}
+
+	tick := time.Date(2026, 10, 16, 14, 5, 0, 0, time.Local)
+	updated, _ := model.Update(refreshTickMsg(tick))
+	model = updated.(Model)
+
+	lines := strings.Split(model.View(), "\n")
+	if len(lines) != model.height {
+		t.Fatalf("expected the frame to fill %d rows, got %d", model.height, len(lines))
+	}
+	bar := ansi.Strip(lines[len(lines)-1])
+	if len([]rune(bar)) != model.width {
+		t.Fatalf("expected the status bar to span %d columns, got %q", model.width, bar)
+	}
+	if !strings.HasPrefix(bar, " 2 sessions • web") || !strings.HasSuffix(bar, "14:05 ") {
+		t.Fatalf("unexpected status bar %q", bar)
+	}
+}
+
+func TestStatusBarSingularSession(t *testing.T) {
+	model := sizedModel(t, &stubManager{sessions: []string{"hiho-123-0"}}, 80, 24)
+	if bar := ansi.Strip(model.renderStatusBar()); !strings.HasPrefix(bar, " 1 session ") {
+		t.Fatalf("unexpected status bar %q", bar)
+	}
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"hiho/internal/tmux"
+)
+
+type stubManager struct {
+	created      []string
+	sessions     []string
+	outputByName map[string]string
+	currentIndex int
+	killed       []string
+	sent         map[string][]string
+	pressed      map[string][]string
+	sendErr      map[string]error
+	captureErr   map[string]error
+	listErr      error
+	captured     []string
+	stats        map[string]tmux.ProcessStats
+	exitCodes    map[string]int      // sessions whose command has exited
+	dirs         map[string]string   // session name -> working directory
+	commands     map[string]string   // session name -> command it was started with
+	envs         map[string][]string // session name -> KEY=VALUE pairs exported for it
+	createdAt    map[string]time.Time
+	tags         map[string]string // session name -> workspace recorded with it
+	resized      map[string][]paneSize
+}
+
+// session describes name as List reports it.
+func (s *stubManager) session(name string) tmux.Session {
+	code, exited := s.exitCodes[name]
+	return tmux.Session{Name: name, Exited: exited, ExitCode: code, StartCommand: s.commands[name], Dir: s.dirs[name], Tag: s.tags[name], CreatedAt: s.createdAt[name]}
+}
+
+func (s *stubManager) NewSession(cmd string) (tmux.Session, error) {
+	s.created = append(s.created, cmd)
+	name := s.nextName()
+	s.sessions = append(s.sessions, name)
+	if s.commands == nil {
+		s.commands = make(map[string]string)
+	}
+	s.commands[name] = cmd
+	return tmux.Session{Name: name, StartCommand: cmd, Dir: s.dirs[name]}, nil
+}
+
+func (s *stubManager) NewSessionWithEnv(dir string, env []string, cmd string) (tmux.Session, error) {
+	if dir != "" {
+		if s.dirs == nil {
+			s.dirs = make(map[string]string)
+		}
+		s.dirs[s.nextName()] = dir
+	}
+	if len(env) > 0 {
+		if s.envs == nil {
+			s.envs = make(map[string][]string)
+		}
+		s.envs[s.nextName()] = env
+	}
+	return s.NewSession(cmd)
+}
+
+func (s *stubManager) Capture(name string) (string, error) {
+	s.captured = append(s.captured, name)
+	if err := s.captureErr[name]; err != nil {
+		return "", err
+	}
+	return s.outputByName[name], nil
+}
+
+func (s *stubManager) List() ([]tmux.Session, error) {
+	var result []tmux.Session
+	for _, name := range s.sessions {
+		result = append(result, s.session(name))
+	}
+	return result, nil
+}
+
+func (s *stubManager) ListHiho() ([]tmux.Session, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var result []tmux.Session
+	for _, name := range s.sessions {
+		if strings.HasPrefix(name, "hiho-") {
+			result = append(result, s.session(name))
+		}
+	}
+	return result, nil
+}
+
+func (s *stubManager) Switch(name string) (tmux.Session, error) {
+	for i, session := range s.sessions {
+		if session == name {
+			s.currentIndex = i
+			return tmux.Session{Name: name}, nil
+		}
+	}
+	return tmux.Session{}, tmux.ErrSessionNotFound
+}
+
+func (s *stubManager) Next(current string) (tmux.Session, error) {
+	if len(s.sessions) == 0 {
+		return tmux.Session{}, tmux.ErrSessionNotFound
+	}
+	s.currentIndex = (s.currentIndex + 1) % len(s.sessions)
+	return tmux.Session{Name: s.sessions[s.currentIndex]}, nil
+}
+
+func (s *stubManager) Prev(current string) (tmux.Session, error) {
+	if len(s.sessions) == 0 {
+		return tmux.Session{}, tmux.ErrSessionNotFound
+	}
+	s.currentIndex = (s.currentIndex - 1 + len(s.sessions)) % len(s.sessions)
+	return tmux.Session{Name: s.sessions[s.currentIndex]}, nil
+}
+
+func (s *stubManager) Kill(name string) error {
+	s.killed = append(s.killed, name)
+	// Remove from sessions
+	for i, session := range s.sessions {
+		if session == name {
+			s.sessions = append(s.sessions[:i], s.sessions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *stubManager) KillAllHiho() error {
+	var remaining []string
+	for _, name := range s.sessions {
+		if strings.HasPrefix(name, "hiho-") {
+			s.killed = append(s.killed, name)
+		} else {
+			remaining = append(remaining, name)
+		}
+	}
+	s.sessions = remaining
+	return nil
+}
+
+func (s *stubManager) SendKeys(name, keys string) error {
+	if err := s.sendErr[name]; err != nil {
+		return err
+	}
+	if s.sent == nil {
+		s.sent = make(map[string][]string)
+	}
+	s.sent[name] = append(s.sent[name], keys)
+	return nil
+}
+
+func (s *stubManager) PressKeys(name string, keys ...string) error {
+	if err := s.sendErr[name]; err != nil {
+		return err
+	}
+	if s.pressed == nil {
+		s.pressed = make(map[string][]string)
+	}
+	s.pressed[name] = append(s.pressed[name], keys...)
+	return nil
+}
+
+func (s *stubManager) Rename(name, newName string) error {
+	for i, session := range s.sessions {
+		if session == name {
+			s.sessions[i] = newName
+			if tag, ok := s.tags[name]; ok {
+				delete(s.tags, name)
+				s.tags[newName] = tag
+			}
+			return nil
+		}
+	}
+	return tmux.ErrSessionNotFound
+}
+
+func (s *stubManager) Tag(name, tag string) error {
+	if s.tags == nil {
+		s.tags = make(map[string]string)
+	}
+	s.tags[name] = tag
+	return nil
+}
+
+func (s *stubManager) Resize(name string, width, height int) error {
+	if s.resized == nil {
+		s.resized = make(map[string][]paneSize)
+	}
+	s.resized[name] = append(s.resized[name], paneSize{width, height})
+	return nil
+}
+
+func (s *stubManager) Stats(name string) (tmux.ProcessStats, error) {
+	stats, ok := s.stats[name]
+	if !ok {
+		return tmux.ProcessStats{}, tmux.ErrProcessExited
+	}
+	return stats, nil
+}
+
+func (s *stubManager) nextName() string {
+	return "hiho-123-" + string('0'+rune(len(s.sessions)))
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tabLabel is a rendered tab title along with the tab it selects.
+type tabLabel struct {
+	tab      tabType
+	rendered string
+}
+
+// tabRange is the column extent of a tab label within the tab bar.
+type tabRange struct {
+	tab   tabType
+	start int
+	end   int // exclusive
+}
+
+const tabGap = " "
+
+// tab is an entry in the tab bar.
+type tab struct {
+	kind  tabType
+	title string
+}
+
+// defaultTabs is the tab bar order; cycling follows this order.
+func defaultTabs() []tab {
+	return []tab{
+		{tabConversation, "Conversation"},
+		{tabTmux, "Tmux Window"},
+	}
+}
+
+// cycleTab moves the active tab by delta positions, wrapping at either end.
+func (m *Model) cycleTab(delta int) {
+	if len(m.tabs) == 0 {
+		return
+	}
+	idx := 0
+	for i, t := range m.tabs {
+		if t.kind == m.activeTab {
+			idx = i
+			break
+		}
+	}
+	n := len(m.tabs)
+	m.setTab(m.tabs[((idx+delta)%n+n)%n].kind)
+}
+
+func (m *Model) nextTab() { m.cycleTab(1) }
+
+func (m *Model) prevTab() { m.cycleTab(-1) }
+
+// toggleTab flips between the two tabs; with more tabs it advances.
+func (m *Model) toggleTab() { m.nextTab() }
+
+// handleTab implements /tab next|prev.
+func (m *Model) handleTab(arg string) error {
+	switch arg {
+	case "next", "":
+		m.nextTab()
+	case "prev":
+		m.prevTab()
+	default:
+		return fmt.Errorf("usage: /tab next|prev")
+	}
+	return nil
+}
+
+func (m Model) tabLabels() []tabLabel {
+	theme := m.config.Theme
+	activeStyle := lipgloss.NewStyle().Bold(true).
+		Background(lipgloss.Color(theme.ActiveTabBg)).
+		Foreground(lipgloss.Color(theme.ActiveTabFg)).
+		Padding(0, 1)
+	inactiveStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.InactiveTab)).Padding(0, 1)
+
+	labels := make([]tabLabel, 0, len(m.tabs))
+	for _, t := range m.tabs {
+		style := inactiveStyle
+		if t.kind == m.activeTab {
+			style = activeStyle
+		}
+		labels = append(labels, tabLabel{tab: t.kind, rendered: style.Render(t.title)})
+	}
+	return labels
+}
+
+func (m Model) renderTabBar() string {
+	var parts []string
+	for i, label := range m.tabLabels() {
+		if i > 0 {
+			parts = append(parts, tabGap)
+		}
+		parts = append(parts, label.rendered)
+	}
+
+	if m.currentSession != "" {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.CurrentSession)).Render(
+			fmt.Sprintf(" • %s", m.displayName(m.currentSession)),
+		))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// tabBarLayout returns the column range of every tab label, measured from
+// the same rendered labels that renderTabBar draws.
+func (m Model) tabBarLayout() []tabRange {
+	var ranges []tabRange
+	x := 0
+	for i, label := range m.tabLabels() {
+		if i > 0 {
+			x += lipgloss.Width(tabGap)
+		}
+		w := lipgloss.Width(label.rendered)
+		ranges = append(ranges, tabRange{tab: label.tab, start: x, end: x + w})
+		x += w
+	}
+	return ranges
+}
+
+// tabAt maps a column within the tab bar to the tab drawn there.
+func (m Model) tabAt(x int) (tabType, bool) {
+	for _, r := range m.tabBarLayout() {
+		if x >= r.start && x < r.end {
+			return r.tab, true
+		}
+	}
+	return 0, false
+}
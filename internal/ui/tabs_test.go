@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTabAtMapsColumnsToLabels(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+
+	// " Conversation " spans columns 0-13, the gap is 14,
+	// " Tmux Window " spans columns 15-27.
+	tests := []struct {
+		x      int
+		want   tabType
+		wantOK bool
+	}{
+		{0, tabConversation, true},
+		{13, tabConversation, true},
+		{14, 0, false},
+		{15, tabTmux, true},
+		{27, tabTmux, true},
+		{28, 0, false},
+		{-1, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := model.tabAt(tt.x)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("tabAt(%d) = %v, %v; want %v, %v", tt.x, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMouseClickOnTabLabelSwitchesTab(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+	model = updated.(Model)
+
+	origin := model.sidebarWidth() + 1 // left border of the main panel
+	updated, _ = model.Update(tea.MouseMsg{X: origin + 20, Y: 1, Type: tea.MouseLeft})
+	model = updated.(Model)
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected click on Tmux label to select tmux tab")
+	}
+
+	// Past the labels, even in the "second half" of the bar, nothing changes
+	updated, _ = model.Update(tea.MouseMsg{X: origin + 5, Y: 1, Type: tea.MouseLeft})
+	model = updated.(Model)
+	updated, _ = model.Update(tea.MouseMsg{X: origin + 50, Y: 1, Type: tea.MouseLeft})
+	model = updated.(Model)
+	if model.activeTab != tabConversation {
+		t.Fatalf("expected click past the labels to leave the conversation tab active")
+	}
+}
+
+func TestTabCyclingWrapsAcrossThreeTabs(t *testing.T) {
+	const tabDashboard = tabTmux + 1
+	model := NewModel(&stubManager{}, testConfig())
+	model.tabs = append(model.tabs, tab{tabDashboard, "Dashboard"})
+
+	forward := []tabType{tabTmux, tabDashboard, tabConversation, tabTmux}
+	for i, want := range forward {
+		model.nextTab()
+		if model.activeTab != want {
+			t.Fatalf("nextTab step %d: got %v, want %v", i, model.activeTab, want)
+		}
+	}
+
+	backward := []tabType{tabConversation, tabDashboard, tabTmux, tabConversation}
+	for i, want := range backward {
+		model.prevTab()
+		if model.activeTab != want {
+			t.Fatalf("prevTab step %d: got %v, want %v", i, model.activeTab, want)
+		}
+	}
+}
+
+func TestTabKeysAndCommandCycleTabs(t *testing.T) {
+	model := NewModel(&stubManager{}, testConfig())
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "shift+right"})
+	model = updated.(Model)
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected next_tab key to select tmux, got %v", model.activeTab)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: "shift+left"})
+	model = updated.(Model)
+	if model.activeTab != tabConversation {
+		t.Fatalf("expected prev_tab key to select conversation, got %v", model.activeTab)
+	}
+
+	if _, err := model.handleSubmit("/tab prev"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected /tab prev to wrap to tmux, got %v", model.activeTab)
+	}
+	if _, err := model.handleSubmit("/tab sideways"); err == nil {
+		t.Fatalf("expected usage error for unknown direction")
+	}
+}
+
+func TestTabBarAndHelpUseThemeColors(t *testing.T) {
+	cfg := testConfig()
+	cfg.Theme.ActiveTabBg = "17"
+	cfg.Theme.ActiveTabFg = "226"
+	cfg.Theme.InactiveTab = "245"
+	cfg.Theme.CurrentSession = "99"
+	cfg.Theme.HelpText = "28"
+	model := sizedModel(t, &stubManager{}, 100, 30)
+	model.config = cfg
+	model.currentSession = "hiho-123-0"
+
+	bar := model.renderTabBar()
+	for _, code := range []string{"1;38;5;226;48;5;17m", "\x1b[38;5;245m", "\x1b[38;5;99m"} {
+		if !strings.Contains(bar, code) {
+			t.Fatalf("expected %q in tab bar %q", code, bar)
+		}
+	}
+	if panel := model.renderInputPanel(); !strings.Contains(panel, "\x1b[38;5;28m") {
+		t.Fatalf("expected help text color in input panel %q", panel)
+	}
+}
+
+func TestTabToggle(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	if model.activeTab != tabConversation {
+		t.Fatalf("expected initial tab to be conversation")
+	}
+
+	model.toggleTab()
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected tab to be tmux after toggle")
+	}
+
+	model.toggleTab()
+	if model.activeTab != tabConversation {
+		t.Fatalf("expected tab to be conversation after second toggle")
+	}
+}
+
+func TestViewCommandSwitchesTabs(t *testing.T) {
+	manager := &stubManager{}
+	model := NewModel(manager, testConfig())
+
+	if _, err := model.handleSubmit("/view tmux"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected tabTmux after /view tmux")
+	}
+
+	if _, err := model.handleSubmit("/view conversation"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.activeTab != tabConversation {
+		t.Fatalf("expected tabConversation after /view conversation")
+	}
+
+	if _, err := model.handleSubmit("/view session"); err != nil {
+		t.Fatalf("handleSubmit error: %v", err)
+	}
+	if model.activeTab != tabTmux {
+		t.Fatalf("expected tabTmux after /view session")
+	}
+}
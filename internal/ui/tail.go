@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// tailView limits the Tmux tab to the last few non-empty lines of a capture
+// for a quick glance. expanded temporarily shows the full capture again.
+type tailView struct {
+	lines    int // 0 shows everything
+	expanded bool
+}
+
+// active reports whether the view is currently trimmed.
+func (t tailView) active() bool {
+	return t.lines > 0 && !t.expanded
+}
+
+// handleTailn implements /tailn <n> and /tailn off.
+func (m *Model) handleTailn(arg string) error {
+	switch arg {
+	case "":
+		return fmt.Errorf("usage: /tailn <n>|off")
+	case "off", "0":
+		m.tail = tailView{}
+		m.appendMessage("info", "Showing full captures")
+	default:
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return fmt.Errorf("usage: /tailn <n>|off")
+		}
+		m.tail = tailView{lines: n}
+		m.appendMessage("info", fmt.Sprintf("Showing the last %d lines; %s expands", n, m.config.KeyBindings.ToggleTail))
+	}
+	m.markChanged(changedView)
+	return nil
+}
+
+// toggleTail switches between the tail and the full capture.
+func (m *Model) toggleTail() {
+	if m.tail.lines == 0 {
+		m.appendMessage("info", "Use /tailn <n> to show only the last lines")
+		return
+	}
+	m.tail.expanded = !m.tail.expanded
+	m.markChanged(changedView)
+}
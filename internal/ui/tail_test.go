@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"hiho/internal/ansi"
+)
+
+func TestTailnShowsLastLines(t *testing.T) {
+	manager := &stubManager{
+		sessions:     []string{"hiho-123-0"},
+		outputByName: map[string]string{"hiho-123-0": "one\ntwo\n\nthree\nfour\n\n\n"},
+	}
+	model := NewModel(manager, testConfig())
+	model.currentSession = "hiho-123-0"
+	model.activeTab = tabTmux
+	if err := model.captureCurrentSession(); err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if _, err := model.handleSubmit("/tailn 2"); err != nil {
+		t.Fatalf("tailn: %v", err)
+	}
+
+	body := ansi.Strip(model.renderBody())
+	if !strings.Contains(body, "three\nfour") || strings.Contains(body, "two") {
+		t.Fatalf("expected only the last two lines, got %q", body)
+	}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: "ctrl+t"})
+	model = updated.(Model)
+	if body := ansi.Strip(model.renderBody()); !strings.Contains(body, "one\ntwo") {
+		t.Fatalf("expected expanded view to show everything, got %q", body)
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// styleFromSpec builds a style from a theme entry such as "bold fg:75" or
+// "faint bg:236". Unknown attributes are ignored; "none" or an empty spec
+// yields a plain style.
+func styleFromSpec(spec string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	for _, attr := range strings.Fields(spec) {
+		switch {
+		case attr == "bold":
+			style = style.Bold(true)
+		case attr == "faint":
+			style = style.Faint(true)
+		case attr == "reverse":
+			style = style.Reverse(true)
+		case strings.HasPrefix(attr, "fg:"):
+			style = style.Foreground(lipgloss.Color(strings.TrimPrefix(attr, "fg:")))
+		case strings.HasPrefix(attr, "bg:"):
+			style = style.Background(lipgloss.Color(strings.TrimPrefix(attr, "bg:")))
+		}
+	}
+	return style
+}
+
+// isNoStyle reports whether a theme entry turns styling off.
+func isNoStyle(spec string) bool {
+	spec = strings.TrimSpace(spec)
+	return spec == "" || spec == "none"
+}
+
+// panelStyle is the bordered style of a panel, its border colored by
+// whether area has focus.
+func (m Model) panelStyle(area focusArea) lipgloss.Style {
+	color := m.config.Theme.Border
+	if m.focus == area {
+		color = m.config.Theme.FocusBorder
+	}
+	return lipgloss.NewStyle().Border(true).BorderForeground(lipgloss.Color(color))
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// View renders the TUI with 3-panel layout.
+func (m Model) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+
+	// Render the three panels
+	sidebar := m.renderSidebar()
+	mainPanel := m.renderMainPanel()
+
+	// Join sidebar and main panel horizontally
+	topSection := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, mainPanel)
+
+	// Render session bar, input panel and status bar
+	sessionBar := m.renderSessionBar()
+	inputPanel := m.renderInputPanel()
+
+	return lipgloss.JoinVertical(lipgloss.Left, topSection, sessionBar, inputPanel, m.renderStatusBar())
+}
+
+func (m Model) renderMainPanel() string {
+	w := m.mainWidth() - 2  // Account for border
+	h := m.bodyHeight() - 2 // Account for border
+
+	var content strings.Builder
+
+	// Tab bar, with the follow state and the focused pane's scroll
+	// position at the right end
+	tabBar := m.renderTabBar()
+	scrolled := m.viewport
+	if m.split != nil && m.split.focused {
+		scrolled = m.split.viewport
+	}
+	var status []string
+	if follow := m.followIndicator(); follow != "" {
+		status = append(status, follow)
+	}
+	if indicator := scrolled.ScrollIndicator(); indicator != "" {
+		status = append(status, lipgloss.NewStyle().Faint(true).Render(indicator))
+	}
+	if right := strings.Join(status, " "); right != "" {
+		if gap := w - lipgloss.Width(tabBar); gap > lipgloss.Width(right) {
+			tabBar += lipgloss.NewStyle().Width(gap).Align(lipgloss.Right).Render(right)
+		}
+	}
+	content.WriteString(tabBar)
+	content.WriteString("\n")
+
+	// Main content (viewport), next to the split pane if one is open
+	body := m.viewport.View()
+	if m.split != nil {
+		body = m.renderSplitBody()
+	}
+	content.WriteString(body)
+
+	// Apply border and fixed dimensions
+	style := m.panelStyle(focusMain).
+		Width(w).
+		Height(h)
+
+	return style.Render(content.String())
+}
+
+func (m Model) renderInputPanel() string {
+	w := m.width - 2 // Account for border
+
+	var content strings.Builder
+
+	// Input line
+	m.input.Prompt = m.promptSymbol() + " "
+	content.WriteString(m.inputView())
+	content.WriteString("\n")
+
+	// Help line
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.HelpText))
+	content.WriteString(helpStyle.Render(m.footerHint()))
+
+	// Apply border
+	style := m.panelStyle(focusInput).
+		Width(w)
+
+	return style.Render(content.String())
+}
+
+// refreshViewport re-renders the body. With follow_output on, a viewport
+// that is following (see syncFollow) stays at the bottom as content grows;
+// otherwise the scroll position is kept.
+func (m *Model) refreshViewport() {
+	content := m.renderBody()
+	m.viewport.SetContent(content)
+	if m.following() && m.config.FollowOutput {
+		m.viewport.GotoBottom()
+	}
+}
+
+func (m *Model) renderBody() string {
+	if m.activeTab == tabTmux {
+		if m.currentSession == "" {
+			return "No active session. Use /new <command> to create one."
+		}
+		log := strings.TrimSpace(m.sessionLog)
+		if m.diff.enabled {
+			log = m.renderDiff()
+		}
+		if m.tail.active() {
+			log = strings.Join(lastLines(log, m.tail.lines), "\n")
+		}
+		if m.showEscapes {
+			log = showControlBytes(log)
+		}
+		return m.wrapSessionLog(log)
+	}
+
+	// Conversation view
+	messages := m.conversation()
+	if len(messages) == 0 {
+		return "Welcome to hiho!\n" + helpText(m.config.KeyBindings)
+	}
+
+	layout := m.config.Conversation
+	separator := "\n" + strings.Repeat("\n", max(layout.MessageSpacing, 0))
+	parts := make([]string, 0, len(messages))
+	for _, message := range messages {
+		role := lipgloss.NewStyle().Bold(true).Render(message.Role + ":")
+		entry := role + " " + strings.TrimSpace(message.Content)
+		if indent := layout.RoleIndent[message.Role]; indent > 0 {
+			entry = indentLines(entry, indent)
+		}
+		parts = append(parts, entry)
+	}
+	return strings.Join(parts, separator)
+}
+
+// indentLines prefixes every line of s with n spaces.
+func indentLines(s string, n int) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
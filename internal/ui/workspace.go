@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"hiho/internal/events"
+)
+
+// handleOpen starts one session per command of a configured workspace,
+// tagging each with the workspace name so the sidebar groups them. The
+// commands get the checks /new applies: a workspace that would run hiho
+// inside hiho is refused, and one with a command matching confirm_commands
+// asks first.
+func (m *Model) handleOpen(name string) error {
+	if name == "" {
+		return fmt.Errorf("usage: /open <workspace>")
+	}
+	commands, ok := m.config.Workspaces[name]
+	if !ok {
+		return fmt.Errorf("unknown workspace %q", name)
+	}
+	if len(commands) == 0 {
+		return fmt.Errorf("workspace %q has no commands", name)
+	}
+	if i := slices.IndexFunc(commands, launchesHiho); i >= 0 {
+		return fmt.Errorf("refusing to run hiho inside hiho: workspace %q runs %q", name, commands[i])
+	}
+	open := func(m *Model) error {
+		for _, command := range commands {
+			if err := m.createSession(newOptions{command: command, tag: name}); err != nil {
+				return fmt.Errorf("open %s: %w", name, err)
+			}
+		}
+		m.appendMessage("info", fmt.Sprintf("Opened workspace %s (%d sessions)", name, len(commands)))
+		return nil
+	}
+	if i := slices.IndexFunc(commands, m.needsConfirm); i >= 0 {
+		m.requestConfirm(fmt.Sprintf("Open workspace %s, which runs %q?", name, commands[i]), open)
+		return nil
+	}
+	return open(m)
+}
+
+// handleClose kills the sessions tagged with a workspace, leaving every
+// other session alone.
+func (m *Model) handleClose(name string) error {
+	if name == "" {
+		return fmt.Errorf("usage: /close <workspace>")
+	}
+	// Pick up sessions tagged before hiho last started
+	m.refreshSessions()
+	var members []string
+	for session, tag := range m.sessionTags {
+		if tag == name {
+			members = append(members, session)
+		}
+	}
+	if len(members) == 0 {
+		return fmt.Errorf("no open sessions in workspace %q", name)
+	}
+
+	var errs []error
+	var closed []string
+	for _, session := range members {
+		if err := m.manager.Kill(session); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.emit(events.SessionKilled, session)
+		delete(m.sessionTags, session)
+		closed = append(closed, session)
+		if session == m.currentSession {
+			m.currentSession = ""
+			m.sessionLog = ""
+			m.markChanged(changedCapture)
+		}
+	}
+	m.dropConversations(closed...)
+	m.refreshSessions()
+	m.appendMessage("info", fmt.Sprintf("Closed workspace %s", name))
+	return errors.Join(errs...)
+}
+
+// tagSession assigns a session to a sidebar group, recording the tag with
+// the session in tmux so it survives a restart of hiho.
+func (m *Model) tagSession(name, tag string) error {
+	if err := m.manager.Tag(name, tag); err != nil {
+		return err
+	}
+	m.setSessionTag(name, tag)
+	return nil
+}
+
+func (m *Model) setSessionTag(name, tag string) {
+	if m.sessionTags == nil {
+		m.sessionTags = make(map[string]string)
+	}
+	m.sessionTags[name] = tag
+	m.markChanged(changedSessions)
+}
+
+// adoptTags takes the tags tmux reports for sessions hiho does not know the
+// tag of, such as sessions opened before it restarted.
+func (m *Model) adoptTags() {
+	for _, session := range m.sessions {
+		if session.Tag != "" && m.sessionTags[session.Name] == "" {
+			m.setSessionTag(session.Name, session.Tag)
+		}
+	}
+}
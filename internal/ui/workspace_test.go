@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func workspaceModel(manager *stubManager) Model {
+	cfg := testConfig()
+	cfg.Workspaces = map[string][]string{
+		"web": {"npm run dev", "go run ./api"},
+		"db":  {"psql"},
+	}
+	return NewModel(manager, cfg)
+}
+
+func TestOpenWorkspaceTagsItsSessions(t *testing.T) {
+	manager := &stubManager{}
+	model := workspaceModel(manager)
+
+	if _, err := model.handleSubmit("/open web"); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if !slices.Equal(manager.created, []string{"npm run dev", "go run ./api"}) {
+		t.Fatalf("unexpected commands %v", manager.created)
+	}
+	for _, name := range []string{"hiho-123-0", "hiho-123-1"} {
+		if got := model.sessionTags[name]; got != "web" {
+			t.Fatalf("expected %s tagged web, got %q", name, got)
+		}
+	}
+}
+
+func TestCloseWorkspaceKillsOnlyItsGroup(t *testing.T) {
+	manager := &stubManager{}
+	model := workspaceModel(manager)
+	if _, err := model.handleSubmit("/open db"); err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if _, err := model.handleSubmit("/open web"); err != nil {
+		t.Fatalf("open web: %v", err)
+	}
+
+	if _, err := model.handleSubmit("/close web"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	slices.Sort(manager.killed)
+	if !slices.Equal(manager.killed, []string{"hiho-123-1", "hiho-123-2"}) {
+		t.Fatalf("unexpected kills %v", manager.killed)
+	}
+	if !slices.Equal(manager.sessions, []string{"hiho-123-0"}) {
+		t.Fatalf("expected db session to survive, got %v", manager.sessions)
+	}
+}
+
+func TestCloseWorkspaceAfterRestart(t *testing.T) {
+	manager := &stubManager{}
+	first := workspaceModel(manager)
+	if _, err := first.handleSubmit("/open web"); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	manager.sessions = append(manager.sessions, "hiho-123-9")
+
+	// A fresh model knows the tags only from tmux
+	model := workspaceModel(manager)
+	if _, err := model.handleSubmit("/close web"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	slices.Sort(manager.killed)
+	if !slices.Equal(manager.killed, []string{"hiho-123-0", "hiho-123-1"}) {
+		t.Fatalf("unexpected kills %v", manager.killed)
+	}
+}
+
+func TestOpenWorkspaceAppliesNewChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		commands   []string
+		wantErr    bool
+		wantPrompt string
+	}{
+		{"runs hiho", []string{"npm run dev", "sudo hiho"}, true, ""},
+		{"needs confirmation", []string{"npm run dev", "rm -rf build"}, false, `Open workspace dev, which runs "rm -rf build"? (y/n)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &stubManager{}
+			cfg := testConfig()
+			cfg.ConfirmCommands = []string{"rm *"}
+			cfg.Workspaces = map[string][]string{"dev": tt.commands}
+			model := NewModel(manager, cfg)
+
+			_, err := model.handleSubmit("/open dev")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("open error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(manager.created) != 0 {
+				t.Fatalf("expected nothing started yet, got %v", manager.created)
+			}
+			if tt.wantPrompt == "" {
+				return
+			}
+			if last := lastMessage(model); last.Role != "confirm" || last.Content != tt.wantPrompt {
+				t.Fatalf("unexpected prompt %+v", last)
+			}
+			model.handleConfirmKey("y")
+			if !slices.Equal(manager.created, tt.commands) {
+				t.Fatalf("expected the workspace opened after yes, got %v", manager.created)
+			}
+		})
+	}
+}
+
+func TestOpenUnknownWorkspace(t *testing.T) {
+	model := workspaceModel(&stubManager{})
+	if _, err := model.handleSubmit("/open nope"); err == nil {
+		t.Fatal("expected error for unknown workspace")
+	}
+}
@@ -1,13 +1,45 @@
 package textinput
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	cursorOn  = "\x1b[7m"
+	cursorOff = "\x1b[27m"
+)
+
+// EchoMode sets how View shows the value.
+type EchoMode int
+
+const (
+	// EchoNormal shows the value as typed.
+	EchoNormal EchoMode = iota
+	// EchoPassword shows an asterisk for each character.
+	EchoPassword
+	// EchoNone shows nothing, not even the length.
+	EchoNone
+)
 
 // Model holds text input state.
 type Model struct {
 	ValueStr    string
 	Placeholder string
 	Prompt      string
-	focused     bool
+	// EchoMode masks the value in View; Value still returns it as typed.
+	EchoMode EchoMode
+	// MultiLine makes alt+enter insert a line break and View show each
+	// line on its own row, indented under the first.
+	MultiLine bool
+	focused   bool
+	// tail counts the runes after the cursor. Keeping the cursor relative
+	// to the end means a zero Model, or one whose ValueStr was assigned
+	// directly, edits at the end of the line.
+	tail int
 }
 
 // New constructs a Model.
@@ -15,45 +47,186 @@ func New() Model {
 	return Model{Prompt: "> "}
 }
 
-// Focus enables editing.
+// Focus enables editing. The current value is left untouched.
 func (m *Model) Focus() {
 	m.focused = true
 }
 
-// Blur disables editing.
+// Blur disables editing. The current value is preserved so it can be
+// resumed after focus returns; only Reset clears it.
 func (m *Model) Blur() {
 	m.focused = false
 }
 
-// Update applies key messages.
+// Update applies key messages and pastes.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focused {
+		return m, nil
+	}
+	if paste, ok := msg.(tea.PasteMsg); ok {
+		m.insert(pasteRunes(paste.Text))
+		return m, nil
+	}
 	key, ok := msg.(tea.KeyMsg)
 	if !ok {
 		return m, nil
 	}
 
-	switch key.String() {
+	runes := []rune(m.ValueStr)
+	pos := m.Position()
+	switch k := key.String(); k {
+	case "left":
+		if pos > 0 {
+			pos--
+		}
+	case "right":
+		if pos < len(runes) {
+			pos++
+		}
+	case "home", "ctrl+a":
+		pos = 0
+	case "end", "ctrl+e":
+		pos = len(runes)
 	case "backspace":
-		if len(m.ValueStr) > 0 {
-			m.ValueStr = m.ValueStr[:len(m.ValueStr)-1]
+		if pos > 0 {
+			runes = append(runes[:pos-1], runes[pos:]...)
+			pos--
+		}
+	case "delete":
+		if pos < len(runes) {
+			runes = append(runes[:pos], runes[pos+1:]...)
+		}
+	case "ctrl+w":
+		start := wordStart(runes, pos)
+		runes = append(runes[:start], runes[pos:]...)
+		pos = start
+	case "ctrl+u":
+		runes, pos = nil, 0
+	case "alt+enter":
+		if !m.MultiLine {
+			return m, nil
 		}
+		runes = append(runes[:pos], append([]rune{'\n'}, runes[pos:]...)...)
+		pos++
 	case "enter", "ctrl+c":
 		// handled upstream
 	default:
-		if m.focused {
-			m.ValueStr += key.String()
+		// Named keys such as "pgup" or "f1" are not text.
+		if utf8.RuneCountInString(k) != 1 {
+			return m, nil
 		}
+		r, _ := utf8.DecodeRuneInString(k)
+		runes = append(runes[:pos], append([]rune{r}, runes[pos:]...)...)
+		pos++
 	}
+	m.ValueStr = string(runes)
+	m.tail = len(runes) - pos
 	return m, nil
 }
 
-// View renders the input.
+// insert adds runes at the cursor and moves the cursor past them.
+func (m *Model) insert(text []rune) {
+	runes := []rune(m.ValueStr)
+	pos := m.Position()
+	runes = append(runes[:pos], append(text, runes[pos:]...)...)
+	m.ValueStr = string(runes)
+	m.tail = len(runes) - pos - len(text)
+}
+
+// pasteRunes keeps the printable text and line breaks of a paste; tabs
+// become spaces and other control characters are dropped.
+func pasteRunes(s string) []rune {
+	var out []rune
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			out = append(out, r)
+		case r == '\t':
+			out = append(out, ' ')
+		case unicode.IsPrint(r) || r == ' ':
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// wordStart returns the index ctrl+w deletes back to: it skips any
+// whitespace before pos, then the word before that.
+func wordStart(runes []rune, pos int) int {
+	for pos > 0 && unicode.IsSpace(runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// View renders the input. While focused, the cell under the cursor is
+// drawn in reverse video. With MultiLine, each line of the value is drawn
+// on its own row, indented under the first (see Lines); otherwise line
+// breaks, such as from a paste, show as ↵ so the input stays on one row.
 func (m Model) View() string {
+	if m.ValueStr == "" && m.Placeholder != "" {
+		if !m.focused {
+			return m.Prompt + m.Placeholder
+		}
+		first, size := utf8.DecodeRuneInString(m.Placeholder)
+		return m.Prompt + cursorOn + string(first) + cursorOff + m.Placeholder[size:]
+	}
 	value := m.ValueStr
-	if value == "" && m.Placeholder != "" {
-		value = m.Placeholder
+	if !m.MultiLine {
+		value = strings.ReplaceAll(value, "\n", "↵")
+	}
+	pos := m.Position()
+	switch m.EchoMode {
+	case EchoPassword:
+		value = strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return '*'
+		}, value)
+	case EchoNone:
+		value, pos = "", 0
+	}
+	if !m.focused {
+		return m.indentLines(m.Prompt + value)
+	}
+	runes := []rune(value)
+	under, rest := " ", ""
+	if pos < len(runes) && runes[pos] != '\n' {
+		under, rest = string(runes[pos]), string(runes[pos+1:])
+	} else if pos < len(runes) {
+		// At a line break the cursor is drawn at the end of the line
+		rest = string(runes[pos:])
+	}
+	return m.indentLines(m.Prompt + string(runes[:pos]) + cursorOn + under + cursorOff + rest)
+}
+
+// indentLines lines up the rows after the first with the text after the
+// prompt.
+func (m Model) indentLines(view string) string {
+	indent := strings.Repeat(" ", utf8.RuneCountInString(m.Prompt))
+	return strings.ReplaceAll(view, "\n", "\n"+indent)
+}
+
+// Lines returns how many rows View takes: one, or one per line of a
+// MultiLine value.
+func (m Model) Lines() int {
+	if !m.MultiLine || m.EchoMode == EchoNone {
+		return 1
+	}
+	return strings.Count(m.ValueStr, "\n") + 1
+}
+
+// CursorLine returns the row of the cursor within View, counting from 0.
+func (m Model) CursorLine() int {
+	if m.Lines() == 1 {
+		return 0
 	}
-	return m.Prompt + value
+	runes := []rune(m.ValueStr)
+	return strings.Count(string(runes[:m.Position()]), "\n")
 }
 
 // Value returns current text.
@@ -61,9 +234,39 @@ func (m Model) Value() string {
 	return m.ValueStr
 }
 
+// SetValue replaces the current text and moves the cursor to its end.
+func (m *Model) SetValue(s string) {
+	m.ValueStr = s
+	m.tail = 0
+}
+
+// Position returns the cursor position as a rune index.
+func (m Model) Position() int {
+	n := utf8.RuneCountInString(m.ValueStr)
+	if m.tail < 0 {
+		return n
+	}
+	if m.tail > n {
+		return 0
+	}
+	return n - m.tail
+}
+
+// SetCursor moves the cursor to the given rune index, clamped to the value.
+func (m *Model) SetCursor(pos int) {
+	n := utf8.RuneCountInString(m.ValueStr)
+	m.tail = n - min(max(pos, 0), n)
+}
+
+// CursorEnd moves the cursor to the end of the value.
+func (m *Model) CursorEnd() {
+	m.tail = 0
+}
+
 // Reset clears the input.
 func (m *Model) Reset() {
 	m.ValueStr = ""
+	m.tail = 0
 }
 
 // Blink is retained for compatibility.
@@ -0,0 +1,183 @@
+package textinput
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func press(m Model, keys ...string) Model {
+	for _, k := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: k})
+	}
+	return m
+}
+
+func TestCursorEditing(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string
+		keys    []string
+		want    string
+		wantPos int
+	}{
+		{"types at end", "", []string{"a", "b", "c"}, "abc", 3},
+		{"inserts mid-line", "ac", []string{"left", "b"}, "abc", 2},
+		{"home inserts at start", "bc", []string{"home", "a"}, "abc", 1},
+		{"end after home", "ab", []string{"home", "end", "c"}, "abc", 3},
+		{"backspace before cursor", "abc", []string{"left", "backspace"}, "ac", 1},
+		{"backspace at start is a no-op", "abc", []string{"home", "backspace"}, "abc", 0},
+		{"delete under cursor", "abc", []string{"home", "delete"}, "bc", 0},
+		{"delete at end is a no-op", "abc", []string{"delete"}, "abc", 3},
+		{"left stops at start", "ab", []string{"left", "left", "left"}, "ab", 0},
+		{"right stops at end", "ab", []string{"right"}, "ab", 2},
+		{"named keys are not text", "ab", []string{"pgup", "f1", "insert"}, "ab", 2},
+		{"ctrl+w deletes the previous word", "/new --dir foo", []string{"ctrl+w"}, "/new --dir ", 11},
+		{"ctrl+w skips trailing spaces", "/new bash  ", []string{"ctrl+w"}, "/new ", 5},
+		{"ctrl+w mid-line keeps the rest", "/new bash -l", []string{"left", "left", "left", "ctrl+w"}, "/new  -l", 5},
+		{"ctrl+w stops at the start", "bash", []string{"ctrl+w", "ctrl+w"}, "", 0},
+		{"ctrl+w on empty value", "", []string{"ctrl+w"}, "", 0},
+		{"ctrl+u clears the line", "/new bash", []string{"left", "ctrl+u"}, "", 0},
+		{"multi-byte runes", "héllo", []string{"left", "left", "left", "backspace"}, "hllo", 1},
+		{"types multi-byte runes", "caf", []string{"é", " ", "日", "本", "backspace"}, "café 日", 6},
+		{"inserts multi-byte mid-line", "nave", []string{"left", "left", "ï", "delete"}, "naïe", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+			m.Focus()
+			m.SetValue(tt.initial)
+			m = press(m, tt.keys...)
+			if m.Value() != tt.want {
+				t.Fatalf("Value() = %q, want %q", m.Value(), tt.want)
+			}
+			if m.Position() != tt.wantPos {
+				t.Fatalf("Position() = %d, want %d", m.Position(), tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestBlurredInputIgnoresKeys(t *testing.T) {
+	m := New()
+	m.SetValue("ab")
+	m = press(m, "left", "x", "backspace")
+	if m.Value() != "ab" || m.Position() != 2 {
+		t.Fatalf("got %q at %d, want %q at 2", m.Value(), m.Position(), "ab")
+	}
+}
+
+func TestDirectAssignmentEditsAtEnd(t *testing.T) {
+	m := New()
+	m.Focus()
+	m.ValueStr = "ab"
+	m = press(m, "c")
+	if m.Value() != "abc" {
+		t.Fatalf("Value() = %q, want %q", m.Value(), "abc")
+	}
+}
+
+func TestViewShowsCursor(t *testing.T) {
+	m := New()
+	m.Focus()
+	m.SetValue("abc")
+	m.SetCursor(1)
+	if got, want := m.View(), "> a"+cursorOn+"b"+cursorOff+"c"; got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+	m.CursorEnd()
+	if got := m.View(); !strings.HasSuffix(got, cursorOn+" "+cursorOff) {
+		t.Fatalf("cursor at end should draw a reversed space, got %q", got)
+	}
+	m.Blur()
+	if got := m.View(); got != "> abc" {
+		t.Fatalf("blurred View() = %q, want %q", got, "> abc")
+	}
+}
+
+func TestViewMasksValue(t *testing.T) {
+	tests := []struct {
+		mode    EchoMode
+		focused string
+		blurred string
+	}{
+		{EchoNormal, "> pä" + cursorOn + "s" + cursorOff + "s", "> päss"},
+		{EchoPassword, "> **" + cursorOn + "*" + cursorOff + "*", "> ****"},
+		{EchoNone, "> " + cursorOn + " " + cursorOff, "> "},
+	}
+	for _, tt := range tests {
+		m := New()
+		m.EchoMode = tt.mode
+		m.Focus()
+		m.SetValue("päss")
+		m.SetCursor(2)
+		if got := m.View(); got != tt.focused {
+			t.Errorf("mode %d: View() = %q, want %q", tt.mode, got, tt.focused)
+		}
+		m.Blur()
+		if got := m.View(); got != tt.blurred {
+			t.Errorf("mode %d: blurred View() = %q, want %q", tt.mode, got, tt.blurred)
+		}
+		if m.Value() != "päss" {
+			t.Errorf("mode %d: Value() = %q, want the value as typed", tt.mode, m.Value())
+		}
+	}
+}
+
+func TestMultiLineBreaksAndViewRows(t *testing.T) {
+	m := New()
+	m.Focus()
+	m.SetValue("ab")
+	m, _ = m.Update(tea.KeyMsg{Type: "alt+enter"})
+	if m.Value() != "ab" {
+		t.Fatalf("expected alt+enter ignored on a single line, got %q", m.Value())
+	}
+	if got, want := m.View(), "> ab"+cursorOn+" "+cursorOff; got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+
+	m.MultiLine = true
+	m.SetCursor(1)
+	m, _ = m.Update(tea.KeyMsg{Type: "alt+enter"})
+	if m.Value() != "a\nb" || m.Position() != 2 {
+		t.Fatalf("expected a break at the cursor, got %q at %d", m.Value(), m.Position())
+	}
+	if m.Lines() != 2 || m.CursorLine() != 1 {
+		t.Fatalf("Lines() = %d, CursorLine() = %d, want 2 and 1", m.Lines(), m.CursorLine())
+	}
+	if got, want := m.View(), "> a\n  "+cursorOn+"b"+cursorOff; got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+	m.SetCursor(1)
+	if got, want := m.View(), "> a"+cursorOn+" "+cursorOff+"\n  b"; got != want {
+		t.Fatalf("cursor at a break: View() = %q, want %q", got, want)
+	}
+	m.EchoMode = EchoPassword
+	if got, want := m.View(), "> *"+cursorOn+" "+cursorOff+"\n  *"; got != want {
+		t.Fatalf("masked View() = %q, want %q", got, want)
+	}
+}
+
+func TestPasteInsertsAtCursor(t *testing.T) {
+	m := New()
+	m.Focus()
+	m.SetValue("/send ")
+	m, _ = m.Update(tea.PasteMsg{Text: "make\nmake\ttest\x1b[A"})
+	if got, want := m.Value(), "/send make\nmake test[A"; got != want {
+		t.Fatalf("Value() = %q, want %q", got, want)
+	}
+	if m.Position() != len([]rune(m.Value())) {
+		t.Fatalf("expected the cursor after the paste, got %d", m.Position())
+	}
+
+	m.SetCursor(0)
+	m, _ = m.Update(tea.PasteMsg{Text: "x"})
+	if !strings.HasPrefix(m.Value(), "x/send") || m.Position() != 1 {
+		t.Fatalf("expected paste at the cursor, got %q at %d", m.Value(), m.Position())
+	}
+	m.Blur()
+	if got := m.View(); !strings.Contains(got, "make↵make") {
+		t.Fatalf("expected line breaks shown as ↵, got %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+package viewport
+
+import (
+	"strings"
+	"unicode"
+)
+
+// search holds the active search term and the content lines matching it.
+type search struct {
+	term    []rune // lower-cased
+	matches []int  // indexes into lines
+	current int    // index into matches
+}
+
+// SetSearch highlights case-insensitive matches of term and scrolls to the
+// first match at or below the top of the view, wrapping to the first one.
+// It returns the number of matching lines. An empty term clears the search.
+func (m *Model) SetSearch(term string) int {
+	if term == "" {
+		m.ClearSearch()
+		return 0
+	}
+	m.search = search{term: lowerRunes(term)}
+	m.findMatches()
+	for i, line := range m.search.matches {
+		if line >= m.YOffset {
+			m.search.current = i
+			break
+		}
+	}
+	m.showMatch()
+	return len(m.search.matches)
+}
+
+// ClearSearch removes the search and its highlighting.
+func (m *Model) ClearSearch() {
+	m.search = search{}
+}
+
+// Searching reports whether a search term is set.
+func (m Model) Searching() bool {
+	return len(m.search.term) > 0
+}
+
+// SearchStatus returns the 1-based number of the current match and the
+// number of matching lines; current is 0 when nothing matches.
+func (m Model) SearchStatus() (current, total int) {
+	total = len(m.search.matches)
+	if total == 0 {
+		return 0, 0
+	}
+	return m.search.current + 1, total
+}
+
+// NextMatch scrolls to the next matching line, wrapping at the end.
+func (m *Model) NextMatch() {
+	m.stepMatch(1)
+}
+
+// PrevMatch scrolls to the previous matching line, wrapping at the start.
+func (m *Model) PrevMatch() {
+	m.stepMatch(-1)
+}
+
+func (m *Model) stepMatch(delta int) {
+	n := len(m.search.matches)
+	if n == 0 {
+		return
+	}
+	m.search.current = ((m.search.current+delta)%n + n) % n
+	m.showMatch()
+}
+
+// showMatch scrolls the current match into view, centering it when it was
+// off screen.
+func (m *Model) showMatch() {
+	if len(m.search.matches) == 0 {
+		return
+	}
+	line := m.search.matches[m.search.current]
+	if m.Height <= 0 || (line >= m.YOffset && line < m.YOffset+m.Height) {
+		return
+	}
+	m.SetYOffset(line - m.Height/2)
+}
+
+// findMatches recomputes the matching lines, keeping the current match in
+// range. It runs whenever the content is reflowed.
+func (m *Model) findMatches() {
+	if len(m.search.term) == 0 {
+		return
+	}
+	m.search.matches = nil
+	for i := range m.lines {
+		if len(matchSpans(lowerRunes(plainLine(m.lines, i)), m.search.term)) > 0 {
+			m.search.matches = append(m.search.matches, i)
+		}
+	}
+	m.search.current = min(m.search.current, max(len(m.search.matches)-1, 0))
+}
+
+// searchHighlight renders a line with every match in reverse video; the
+// current match's line uses a yellow background instead. Like selections,
+// matched lines are drawn without their own styling.
+func (m Model) searchHighlight(line int, text string) string {
+	if len(m.search.term) == 0 {
+		return text
+	}
+	runes := []rune(plainLine(m.lines, line))
+	spans := matchSpans(lowerRunes(string(runes)), m.search.term)
+	if len(spans) == 0 {
+		return text
+	}
+	on, off := "\x1b[7m", "\x1b[27m"
+	if len(m.search.matches) > 0 && m.search.matches[m.search.current] == line {
+		on, off = "\x1b[30;43m", "\x1b[39;49m"
+	}
+	var b strings.Builder
+	prev := 0
+	for _, start := range spans {
+		end := start + len(m.search.term)
+		b.WriteString(string(runes[prev:start]))
+		b.WriteString(on + string(runes[start:end]) + off)
+		prev = end
+	}
+	b.WriteString(string(runes[prev:]))
+	return b.String()
+}
+
+// matchSpans returns the rune offsets where term starts in text, without
+// overlaps.
+func matchSpans(text, term []rune) []int {
+	var spans []int
+	for i := 0; i+len(term) <= len(text); {
+		if string(text[i:i+len(term)]) == string(term) {
+			spans = append(spans, i)
+			i += len(term)
+			continue
+		}
+		i++
+	}
+	return spans
+}
+
+// lowerRunes lower-cases s rune by rune, so offsets match the original.
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
@@ -0,0 +1,63 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchFindsAndCyclesMatches(t *testing.T) {
+	m := New(40, 5)
+	lines := strings.Split(sampleContent(30), "\n")
+	lines[3] = "ERROR: disk full"
+	lines[17] = "retrying after error"
+	lines[25] = "\x1b[31merror\x1b[0m again"
+	m.SetContent(strings.Join(lines, "\n"))
+
+	if got := m.SetSearch("error"); got != 3 {
+		t.Fatalf("SetSearch = %d matches, want 3", got)
+	}
+	if cur, total := m.SearchStatus(); cur != 1 || total != 3 {
+		t.Fatalf("SearchStatus = %d/%d, want 1/3", cur, total)
+	}
+
+	m.NextMatch()
+	if m.YOffset > 17 || m.YOffset+m.Height <= 17 {
+		t.Fatalf("expected line 17 in view, YOffset = %d", m.YOffset)
+	}
+	m.NextMatch()
+	m.NextMatch()
+	if cur, _ := m.SearchStatus(); cur != 1 {
+		t.Fatalf("expected NextMatch to wrap to the first match, got %d", cur)
+	}
+	m.PrevMatch()
+	if cur, _ := m.SearchStatus(); cur != 3 {
+		t.Fatalf("expected PrevMatch to wrap to the last match, got %d", cur)
+	}
+	if view := m.View(); !strings.Contains(view, "\x1b[30;43merror\x1b[39;49m again") {
+		t.Fatalf("expected the current match highlighted, got %q", view)
+	}
+}
+
+func TestSearchStartsAtViewAndHighlights(t *testing.T) {
+	m := New(40, 5)
+	m.SetContent("match one\nx\nx\nx\nx\nx\nx\nMatch two\nx\nx")
+	m.YOffset = 5
+
+	m.SetSearch("MATCH")
+	if cur, _ := m.SearchStatus(); cur != 2 {
+		t.Fatalf("expected the first match below the top of the view, got %d", cur)
+	}
+	m.PrevMatch()
+	if !strings.Contains(m.View(), "\x1b[30;43mmatch\x1b[39;49m one") {
+		t.Fatalf("expected case-insensitive highlight, got %q", m.View())
+	}
+
+	m.SetContent("nothing here")
+	if _, total := m.SearchStatus(); total != 0 || !m.Searching() {
+		t.Fatalf("expected the search kept with no matches after new content")
+	}
+	m.ClearSearch()
+	if m.Searching() {
+		t.Fatal("expected ClearSearch to end the search")
+	}
+}
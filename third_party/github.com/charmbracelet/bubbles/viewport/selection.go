@@ -0,0 +1,122 @@
+package viewport
+
+import (
+	"regexp"
+	"strings"
+)
+
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]`)
+
+// Position is a cell in the content: Line indexes all content lines and
+// Col is a rune column within that line.
+type Position struct {
+	Line int
+	Col  int
+}
+
+// selection tracks an in-progress or finished text selection.
+type selection struct {
+	anchor Position
+	cursor Position
+	active bool
+}
+
+// StartSelection anchors a new selection at a visible cell, where row is
+// relative to the top of the viewport.
+func (m *Model) StartSelection(col, row int) {
+	pos := m.cellPosition(col, row)
+	m.sel = selection{anchor: pos, cursor: pos, active: true}
+}
+
+// ExtendSelection moves the free end of the selection to a visible cell.
+func (m *Model) ExtendSelection(col, row int) {
+	if !m.sel.active {
+		return
+	}
+	m.sel.cursor = m.cellPosition(col, row)
+}
+
+// EndSelection finalizes the selection and returns the selected text. A
+// press and release on the same cell selects nothing and clears it.
+func (m *Model) EndSelection() string {
+	if !m.sel.active || m.sel.anchor == m.sel.cursor {
+		m.ClearSelection()
+		return ""
+	}
+	return m.SelectedText()
+}
+
+// ClearSelection drops any selection.
+func (m *Model) ClearSelection() {
+	m.sel = selection{}
+}
+
+// Selection returns the selected range ordered from start to end.
+func (m Model) Selection() (start, end Position, ok bool) {
+	if !m.sel.active {
+		return Position{}, Position{}, false
+	}
+	start, end = m.sel.anchor, m.sel.cursor
+	if end.Line < start.Line || (end.Line == start.Line && end.Col < start.Col) {
+		start, end = end, start
+	}
+	return start, end, true
+}
+
+// SelectedText returns the plain text covered by the selection. Both ends
+// are inclusive and lines are joined with newlines.
+func (m Model) SelectedText() string {
+	start, end, ok := m.Selection()
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for line := start.Line; line <= end.Line; line++ {
+		from, to := m.lineSpan(line, start, end)
+		runes := []rune(plainLine(m.lines, line))
+		parts = append(parts, string(runes[from:to]))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// lineSpan returns the selected rune range [from, to) of a content line.
+func (m Model) lineSpan(line int, start, end Position) (int, int) {
+	width := len([]rune(plainLine(m.lines, line)))
+	from, to := 0, width
+	if line == start.Line {
+		from = min(start.Col, width)
+	}
+	if line == end.Line {
+		to = min(end.Col+1, width)
+	}
+	return from, max(from, to)
+}
+
+// highlight renders a visible line with its selected part in reverse video.
+// Selected lines are drawn without their own styling so the range is exact.
+func (m Model) highlight(line int, text string) string {
+	start, end, ok := m.Selection()
+	if !ok || line < start.Line || line > end.Line {
+		return text
+	}
+	runes := []rune(plainLine(m.lines, line))
+	from, to := m.lineSpan(line, start, end)
+	return string(runes[:from]) + "\x1b[7m" + string(runes[from:to]) + "\x1b[27m" + string(runes[to:])
+}
+
+// cellPosition converts a visible cell to a content position, clamped to
+// the content so out-of-range coordinates never index past it.
+func (m Model) cellPosition(col, row int) Position {
+	if m.Height > 0 {
+		row = min(row, m.Height-1)
+	}
+	line := min(max(m.YOffset+max(row, 0), 0), max(len(m.lines)-1, 0))
+	return Position{Line: line, Col: max(col, 0)}
+}
+
+func plainLine(lines []string, i int) string {
+	if i < 0 || i >= len(lines) {
+		return ""
+	}
+	return ansiSequence.ReplaceAllString(lines[i], "")
+}
@@ -0,0 +1,70 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectionWithinOneLine(t *testing.T) {
+	m := New(20, 3)
+	m.SetContent("hello world\nsecond line")
+
+	m.StartSelection(6, 0)
+	m.ExtendSelection(10, 0)
+	if got := m.EndSelection(); got != "world" {
+		t.Fatalf("EndSelection() = %q, want %q", got, "world")
+	}
+}
+
+func TestSelectionSpanningLinesDraggedBackwards(t *testing.T) {
+	m := New(20, 3)
+	m.SetContent("line 0\nline 1\nline 2\nline 3\nline 4")
+	m.YOffset = 1
+
+	// Press on "1" of "line 2" (row 1), drag up to "ne" of "line 1" (row 0)
+	m.StartSelection(5, 1)
+	m.ExtendSelection(2, 0)
+
+	start, end, ok := m.Selection()
+	if !ok || start != (Position{Line: 1, Col: 2}) || end != (Position{Line: 2, Col: 5}) {
+		t.Fatalf("Selection() = %+v, %+v, %v", start, end, ok)
+	}
+	if got, want := m.EndSelection(), "ne 1\nline 2"; got != want {
+		t.Fatalf("EndSelection() = %q, want %q", got, want)
+	}
+}
+
+func TestSelectionIgnoresAnsiAndClampsCoordinates(t *testing.T) {
+	m := New(20, 2)
+	m.SetContent("\x1b[31mred\x1b[0m text\nlast")
+
+	m.StartSelection(-3, -1)
+	m.ExtendSelection(99, 99)
+	if got, want := m.EndSelection(), "red text\nlast"; got != want {
+		t.Fatalf("EndSelection() = %q, want %q", got, want)
+	}
+}
+
+func TestClickWithoutDragSelectsNothing(t *testing.T) {
+	m := New(20, 2)
+	m.SetContent("hello")
+
+	m.StartSelection(1, 0)
+	if got := m.EndSelection(); got != "" {
+		t.Fatalf("expected empty selection, got %q", got)
+	}
+	if _, _, ok := m.Selection(); ok {
+		t.Fatalf("expected selection to be cleared")
+	}
+}
+
+func TestViewHighlightsSelection(t *testing.T) {
+	m := New(20, 2)
+	m.SetContent("abcdef")
+	m.StartSelection(1, 0)
+	m.ExtendSelection(3, 0)
+
+	if got := m.View(); !strings.Contains(got, "a\x1b[7mbcd\x1b[27mef") {
+		t.Fatalf("expected highlighted range, got %q", got)
+	}
+}
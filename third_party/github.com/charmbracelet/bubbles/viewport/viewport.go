@@ -1,10 +1,24 @@
 package viewport
 
-// Model holds viewport content.
+import (
+	"fmt"
+	"strings"
+)
+
+// Model holds viewport content and the current scroll position.
 type Model struct {
-	Width   int
-	Height  int
-	content string
+	Width  int
+	Height int
+	// YOffset is the index of the first visible line.
+	YOffset int
+	// Wrap soft-wraps lines longer than Width. Change it with SetWrap so
+	// the content is reflowed.
+	Wrap   bool
+	raw    []string // content as set
+	lines  []string // content as displayed, after wrapping
+	origin []int    // raw line index of each displayed line
+	sel    selection
+	search search
 }
 
 // New constructs a Model.
@@ -12,12 +26,109 @@ func New(width, height int) Model {
 	return Model{Width: width, Height: height}
 }
 
-// SetContent sets the visible content.
+// SetContent sets the visible content, wrapping it if Wrap is on and
+// keeping YOffset within range.
 func (m *Model) SetContent(content string) {
-	m.content = content
+	m.raw = nil
+	if content != "" {
+		m.raw = strings.Split(content, "\n")
+	}
+	m.reflow()
+	if m.YOffset > m.maxYOffset() {
+		m.YOffset = m.maxYOffset()
+	}
+}
+
+// TotalLineCount returns the number of lines in the content.
+func (m Model) TotalLineCount() int {
+	return len(m.lines)
+}
+
+// AtBottom reports whether the last line of content is visible.
+func (m Model) AtBottom() bool {
+	return m.YOffset >= m.maxYOffset()
+}
+
+// AtTop reports whether the first line of content is visible.
+func (m Model) AtTop() bool {
+	return m.YOffset <= 0
 }
 
-// View returns the content.
+// SetYOffset scrolls to line n, clamped to the content.
+func (m *Model) SetYOffset(n int) {
+	m.YOffset = min(max(n, 0), m.maxYOffset())
+}
+
+// LineUp scrolls up by n lines.
+func (m *Model) LineUp(n int) {
+	m.SetYOffset(m.YOffset - n)
+}
+
+// LineDown scrolls down by n lines.
+func (m *Model) LineDown(n int) {
+	m.SetYOffset(m.YOffset + n)
+}
+
+// HalfViewUp scrolls up by half the viewport height.
+func (m *Model) HalfViewUp() {
+	m.LineUp(max(m.Height/2, 1))
+}
+
+// HalfViewDown scrolls down by half the viewport height.
+func (m *Model) HalfViewDown() {
+	m.LineDown(max(m.Height/2, 1))
+}
+
+// GotoTop scrolls to the first line of content.
+func (m *Model) GotoTop() {
+	m.YOffset = 0
+}
+
+// GotoBottom scrolls so the last line of content is visible.
+func (m *Model) GotoBottom() {
+	m.YOffset = m.maxYOffset()
+}
+
+// ScrollPercent returns how far the viewport is scrolled, from 0 to 1.
+// Content that fits entirely counts as fully scrolled.
+func (m Model) ScrollPercent() float64 {
+	maxOffset := m.maxYOffset()
+	if maxOffset == 0 {
+		return 1.0
+	}
+	percent := float64(m.YOffset) / float64(maxOffset)
+	return min(1.0, max(0.0, percent))
+}
+
+// ScrollIndicator describes the scroll position as the last visible line,
+// the line count and ScrollPercent, e.g. "40/340 12%". It is empty when
+// all content fits.
+func (m Model) ScrollIndicator() string {
+	if m.maxYOffset() == 0 {
+		return ""
+	}
+	last := min(m.YOffset+m.Height, len(m.lines))
+	return fmt.Sprintf("%d/%d %d%%", last, len(m.lines), int(m.ScrollPercent()*100))
+}
+
+// View returns the Height-worth of lines starting at YOffset. A viewport
+// without a height returns all content.
 func (m Model) View() string {
-	return m.content
+	if m.Height <= 0 {
+		return strings.Join(m.lines, "\n")
+	}
+	start := min(max(m.YOffset, 0), len(m.lines))
+	end := min(start+m.Height, len(m.lines))
+	visible := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		visible = append(visible, m.highlight(i, m.searchHighlight(i, m.lines[i])))
+	}
+	return strings.Join(visible, "\n")
+}
+
+func (m Model) maxYOffset() int {
+	if m.Height <= 0 {
+		return 0
+	}
+	return max(0, len(m.lines)-m.Height)
 }
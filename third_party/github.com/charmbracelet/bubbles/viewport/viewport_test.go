@@ -0,0 +1,129 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func sampleContent(lines int) string {
+	rows := make([]string, lines)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("line %d", i)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestScrollPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   int
+		height  int
+		yOffset int
+		want    float64
+	}{
+		{"top", 30, 10, 0, 0},
+		{"middle", 30, 10, 10, 0.5},
+		{"bottom", 30, 10, 20, 1},
+		{"fits entirely", 5, 10, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(20, tt.height)
+			m.SetContent(sampleContent(tt.lines))
+			m.YOffset = tt.yOffset
+			if got := m.ScrollPercent(); got != tt.want {
+				t.Fatalf("ScrollPercent() = %v, want %v", got, tt.want)
+			}
+			if got := m.TotalLineCount(); got != tt.lines {
+				t.Fatalf("TotalLineCount() = %d, want %d", got, tt.lines)
+			}
+			if got, want := m.AtBottom(), tt.want == 1; got != want {
+				t.Fatalf("AtBottom() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestScrollIndicator(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   int
+		yOffset int
+		want    string
+	}{
+		{"top", 30, 0, "10/30 0%"},
+		{"middle", 30, 10, "20/30 50%"},
+		{"bottom", 30, 20, "30/30 100%"},
+		{"fits entirely", 5, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(20, 10)
+			m.SetContent(sampleContent(tt.lines))
+			m.SetYOffset(tt.yOffset)
+			if got := m.ScrollIndicator(); got != tt.want {
+				t.Fatalf("ScrollIndicator() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewShowsHeightLinesFromOffset(t *testing.T) {
+	m := New(20, 3)
+	m.SetContent(sampleContent(10))
+	m.YOffset = 4
+
+	if got, want := m.View(), "line 4\nline 5\nline 6"; got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+}
+
+func TestSetContentClampsOffset(t *testing.T) {
+	m := New(20, 3)
+	m.SetContent(sampleContent(10))
+	m.YOffset = 7
+
+	m.SetContent(sampleContent(5))
+	if m.YOffset != 2 {
+		t.Fatalf("expected YOffset clamped to 2, got %d", m.YOffset)
+	}
+}
+
+func TestGotoBottom(t *testing.T) {
+	m := New(20, 10)
+	m.SetContent(sampleContent(30))
+	m.GotoBottom()
+	if m.YOffset != 20 || !m.AtBottom() {
+		t.Fatalf("YOffset = %d, want 20", m.YOffset)
+	}
+}
+
+func TestScrolling(t *testing.T) {
+	m := New(20, 10)
+	m.SetContent(sampleContent(30))
+
+	tests := []struct {
+		name   string
+		scroll func(*Model)
+		want   int
+	}{
+		{"line down", func(m *Model) { m.LineDown(3) }, 3},
+		{"half view down", (*Model).HalfViewDown, 8},
+		{"line up", func(m *Model) { m.LineUp(1) }, 7},
+		{"half view up", (*Model).HalfViewUp, 2},
+		{"clamps at top", func(m *Model) { m.LineUp(50) }, 0},
+		{"clamps at bottom", func(m *Model) { m.LineDown(50) }, 20},
+		{"goto top", (*Model).GotoTop, 0},
+		{"goto bottom", (*Model).GotoBottom, 20},
+	}
+	for _, tt := range tests {
+		tt.scroll(&m)
+		if m.YOffset != tt.want {
+			t.Fatalf("%s: YOffset = %d, want %d", tt.name, m.YOffset, tt.want)
+		}
+	}
+	if got := m.View(); !strings.HasPrefix(got, "line 20\n") || strings.Count(got, "\n") != 9 {
+		t.Fatalf("expected lines 20-29, got %q", got)
+	}
+}
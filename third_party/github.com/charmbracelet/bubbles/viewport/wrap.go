@@ -0,0 +1,125 @@
+package viewport
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetWidth changes the viewport width. With Wrap on, content is re-wrapped
+// to the new width and the line that was at the top stays in view.
+func (m *Model) SetWidth(width int) {
+	if width == m.Width {
+		return
+	}
+	top := m.sourceLine(m.YOffset)
+	m.Width = width
+	m.reflow()
+	m.YOffset = min(m.firstWrappedLine(top), m.maxYOffset())
+}
+
+// SetWrap turns soft wrapping of long lines at Width on or off.
+func (m *Model) SetWrap(wrap bool) {
+	if wrap == m.Wrap {
+		return
+	}
+	top := m.sourceLine(m.YOffset)
+	m.Wrap = wrap
+	m.reflow()
+	m.YOffset = min(m.firstWrappedLine(top), m.maxYOffset())
+}
+
+// reflow rebuilds the displayed lines from the raw content.
+func (m *Model) reflow() {
+	m.lines = nil
+	m.origin = nil
+	for i, line := range m.raw {
+		for _, part := range wrapLine(line, m.wrapWidth()) {
+			m.lines = append(m.lines, part)
+			m.origin = append(m.origin, i)
+		}
+	}
+	m.findMatches()
+}
+
+// wrapWidth is the width lines wrap at, or 0 when they do not wrap.
+func (m Model) wrapWidth() int {
+	if !m.Wrap {
+		return 0
+	}
+	return m.Width
+}
+
+// sourceLine returns the raw content line shown at displayed line y.
+func (m Model) sourceLine(y int) int {
+	if y < 0 || y >= len(m.origin) {
+		return 0
+	}
+	return m.origin[y]
+}
+
+// firstWrappedLine returns the first displayed line of a raw content line.
+func (m Model) firstWrappedLine(raw int) int {
+	for i, src := range m.origin {
+		if src >= raw {
+			return i
+		}
+	}
+	return 0
+}
+
+// wrapLine splits line into pieces at most width terminal cells wide, not
+// counting ANSI escape sequences; wide characters take two cells and move to
+// the next piece whole. Colors and other text attributes active at a break
+// are reset at the end of the piece and re-applied at the start of the next.
+// A width of 0 or less leaves the line whole.
+func wrapLine(line string, width int) []string {
+	if width <= 0 {
+		return []string{line}
+	}
+	var (
+		parts   []string
+		current strings.Builder
+		cols    int
+		active  string // SGR sequences in effect
+	)
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b {
+			if loc := ansiSequence.FindStringIndex(line[i:]); loc != nil && loc[0] == 0 {
+				code := line[i : i+loc[1]]
+				current.WriteString(code)
+				active = trackSGR(active, code)
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		w := lipgloss.RuneWidth(r)
+		if cols > 0 && cols+w > width {
+			if active != "" {
+				current.WriteString("\x1b[0m")
+			}
+			parts = append(parts, current.String())
+			current.Reset()
+			current.WriteString(active)
+			cols = 0
+		}
+		current.WriteString(line[i : i+size])
+		cols += w
+		i += size
+	}
+	return append(parts, current.String())
+}
+
+// trackSGR adds code to the active SGR sequences if it is one; a reset
+// clears them.
+func trackSGR(active, code string) string {
+	switch {
+	case !strings.HasSuffix(code, "m"):
+		return active
+	case code == "\x1b[0m" || code == "\x1b[m":
+		return ""
+	}
+	return active + code
+}
@@ -0,0 +1,72 @@
+package viewport
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  []string
+	}{
+		{"no wrap", "abcdef", 0, []string{"abcdef"}},
+		{"fits", "abc", 5, []string{"abc"}},
+		{"splits", "abcdefg", 3, []string{"abc", "def", "g"}},
+		{"empty", "", 4, []string{""}},
+		{"skips escapes", "\x1b[31mabcd\x1b[0m", 2, []string{"\x1b[31mab\x1b[0m", "\x1b[31mcd\x1b[0m"}},
+		{"carries colors", "\x1b[1m\x1b[32mabc\x1b[0mdef", 2, []string{
+			"\x1b[1m\x1b[32mab\x1b[0m", "\x1b[1m\x1b[32mc\x1b[0md", "ef",
+		}},
+		{"runes", "héllo", 2, []string{"hé", "ll", "o"}},
+		{"wide characters", "日本語", 4, []string{"日本", "語"}},
+		{"wide character at the edge", "a日本", 2, []string{"a", "日", "本"}},
+		{"wider than the width", "日本", 1, []string{"日", "本"}},
+		{"combining marks", "e\u0301xy", 1, []string{"e\u0301", "x", "y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapLine(tt.line, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("wrapLine(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetWidthReflowsContent(t *testing.T) {
+	m := New(10, 2)
+	m.Wrap = true
+	m.SetContent("0123456789abcdef\nshort\nxyz")
+	if got := len(m.lines); got != 4 {
+		t.Fatalf("expected 4 wrapped lines at width 10, got %d", got)
+	}
+	m.YOffset = 2 // "short"
+
+	m.SetWidth(4)
+	want := []string{"0123", "4567", "89ab", "cdef", "shor", "t", "xyz"}
+	if !reflect.DeepEqual(m.lines, want) {
+		t.Fatalf("lines = %q, want %q", m.lines, want)
+	}
+	if m.YOffset != 4 {
+		t.Fatalf("expected top line to stay on %q, YOffset = %d", "short", m.YOffset)
+	}
+
+	m.SetWidth(20)
+	if got := len(m.lines); got != 3 {
+		t.Fatalf("expected 3 lines at width 20, got %d", got)
+	}
+	if m.YOffset != 1 {
+		t.Fatalf("YOffset = %d, want 1", m.YOffset)
+	}
+}
+
+func TestSetWidthWithoutWrapKeepsLines(t *testing.T) {
+	m := New(4, 2)
+	m.SetContent("0123456789")
+	m.SetWidth(2)
+	if len(m.lines) != 1 {
+		t.Fatalf("expected unwrapped content, got %q", m.lines)
+	}
+}
@@ -0,0 +1,62 @@
+package bubbletea
+
+import "time"
+
+// Commands run outside the event loop: Run hands each Cmd returned by
+// Update or Init to execute, which runs it in its own goroutine and feeds
+// the resulting message back into the loop. This is what lets a Tick sleep
+// without blocking input.
+
+// Tick waits for d and then returns the message built by fn.
+func Tick(d time.Duration, fn func(time.Time) Msg) Cmd {
+	return func() Msg {
+		return fn(<-time.After(d))
+	}
+}
+
+// execute runs cmd in its own goroutine and sends the resulting message to
+// msgCh. A BatchMsg is expanded so each of its commands runs concurrently.
+func execute(cmd Cmd, msgCh chan<- Msg, done <-chan struct{}) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		msg := cmd()
+		if batch, ok := msg.(BatchMsg); ok {
+			for _, c := range batch {
+				execute(c, msgCh, done)
+			}
+			return
+		}
+		if msg == nil {
+			return
+		}
+		select {
+		case msgCh <- msg:
+		case <-done:
+		}
+	}()
+}
+
+// BatchMsg asks the event loop to run several commands concurrently.
+type BatchMsg []Cmd
+
+// Batch combines commands; the event loop runs each one and delivers every
+// resulting message.
+func Batch(cmds ...Cmd) Cmd {
+	var valid []Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			valid = append(valid, cmd)
+		}
+	}
+	switch len(valid) {
+	case 0:
+		return nil
+	case 1:
+		return valid[0]
+	}
+	return func() Msg {
+		return BatchMsg(valid)
+	}
+}
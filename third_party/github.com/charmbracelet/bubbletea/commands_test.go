@@ -0,0 +1,64 @@
+package bubbletea
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBatchDeliversEveryMessage(t *testing.T) {
+	type msgA struct{}
+	type msgB struct{}
+	msgCh := make(chan Msg, 2)
+	done := make(chan struct{})
+	defer close(done)
+
+	batch := Batch(
+		func() Msg { return msgA{} },
+		nil,
+		func() Msg { return msgB{} },
+	)
+	execute(batch, msgCh, done)
+
+	got := map[Msg]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-msgCh:
+			got[msg] = true
+		case <-time.After(time.Second):
+			t.Fatalf("timed out; received %v", got)
+		}
+	}
+	if !got[msgA{}] || !got[msgB{}] {
+		t.Fatalf("expected both batched messages, got %v", got)
+	}
+}
+
+func TestBatchSimplifiesTrivialBatches(t *testing.T) {
+	if Batch() != nil || Batch(nil, nil) != nil {
+		t.Fatalf("expected an empty batch to be nil")
+	}
+	single := Batch(nil, func() Msg { return "only" })
+	if msg := single(); msg != "only" {
+		t.Fatalf("expected a single command to run directly, got %v", msg)
+	}
+}
+
+func TestTickDeliversWithoutBlockingTheLoop(t *testing.T) {
+	msgCh := make(chan Msg, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	start := time.Now()
+	execute(Tick(20*time.Millisecond, func(at time.Time) Msg { return at }), msgCh, done)
+	if elapsed := time.Since(start); elapsed >= 20*time.Millisecond {
+		t.Fatalf("execute waited %v for the tick", elapsed)
+	}
+	select {
+	case msg := <-msgCh:
+		if at, ok := msg.(time.Time); !ok || at.Sub(start) < 20*time.Millisecond {
+			t.Fatalf("expected the tick time after 20ms, got %v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the tick")
+	}
+}
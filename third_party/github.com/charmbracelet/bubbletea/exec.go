@@ -0,0 +1,150 @@
+package bubbletea
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// ExecCallback turns the result of a process run by ExecProcess into a
+// message for Update.
+type ExecCallback func(error) Msg
+
+type execMsg struct {
+	cmd *exec.Cmd
+	fn  ExecCallback
+}
+
+// ExecProcess runs c in the foreground, e.g. an editor or a tmux attach.
+// The terminal is restored to its normal mode for the process and taken
+// back once it exits; fn, which may be nil, receives its error. Stdin,
+// stdout and stderr default to the terminal.
+func ExecProcess(c *exec.Cmd, fn ExecCallback) Cmd {
+	return func() Msg {
+		return execMsg{cmd: c, fn: fn}
+	}
+}
+
+// execProcess hands the terminal to c until it exits.
+func (p *Program) execProcess(c *exec.Cmd, input *inputReader) error {
+	paused := input.pause()
+	p.releaseTerminal()
+
+	if c.Stdin == nil {
+		c.Stdin = os.Stdin
+	}
+	if c.Stdout == nil {
+		c.Stdout = os.Stdout
+	}
+	if c.Stderr == nil {
+		c.Stderr = os.Stderr
+	}
+	err := c.Run()
+
+	if acquireErr := p.acquireTerminal(); acquireErr != nil && err == nil {
+		err = acquireErr
+	}
+	if paused {
+		input.resume()
+	}
+	return err
+}
+
+// inputReader feeds terminal input to the event loop. It can be paused
+// while another process owns the terminal so that process sees every
+// keystroke.
+type inputReader struct {
+	file    *os.File
+	owned   bool // file was opened here and is closed by close
+	resumed chan struct{}
+}
+
+// newInputReader reads from /dev/tty, which unlike an inherited stdin
+// supports read deadlines and so can be paused, falling back to stdin.
+func newInputReader() *inputReader {
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		return &inputReader{file: tty, owned: true, resumed: make(chan struct{}, 1)}
+	}
+	return &inputReader{file: os.Stdin, resumed: make(chan struct{}, 1)}
+}
+
+// pause interrupts the pending read. It reports false when the file does
+// not support that, in which case reading carries on.
+func (r *inputReader) pause() bool {
+	return r.file.SetReadDeadline(time.Now()) == nil
+}
+
+// resume restarts reading after a successful pause.
+func (r *inputReader) resume() {
+	r.resumed <- struct{}{}
+}
+
+func (r *inputReader) close() {
+	if r.owned {
+		r.file.Close()
+	}
+}
+
+// run parses what read delivers into messages. Bytes the parser holds back
+// as an unfinished escape sequence are flushed as keys once escapeTimeout
+// passes without more input, so a lone Esc press is not held until the next
+// key.
+func (r *inputReader) run(msgCh chan<- Msg, done <-chan struct{}) {
+	reads := make(chan []byte)
+	go r.read(reads, done)
+	var parser inputParser
+	var flush <-chan time.Time
+	for {
+		var msgs []Msg
+		select {
+		case buf, ok := <-reads:
+			if !ok {
+				return
+			}
+			msgs = parser.feed(buf)
+		case <-flush:
+			msgs = parser.flush()
+		case <-done:
+			return
+		}
+		flush = nil
+		if parser.holding() {
+			flush = time.After(escapeTimeout)
+		}
+		for _, msg := range msgs {
+			select {
+			case msgCh <- msg:
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// read sends each read from the terminal to reads, waiting out a pause,
+// and closes reads once the terminal fails.
+func (r *inputReader) read(reads chan<- []byte, done <-chan struct{}) {
+	defer close(reads)
+	buf := make([]byte, 256)
+	for {
+		n, err := r.file.Read(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			select {
+			case <-r.resumed:
+				r.file.SetReadDeadline(time.Time{})
+				continue
+			case <-done:
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+		select {
+		case reads <- append([]byte(nil), buf[:n]...):
+		case <-done:
+			return
+		}
+	}
+}
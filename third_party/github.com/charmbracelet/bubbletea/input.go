@@ -0,0 +1,161 @@
+package bubbletea
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxPendingInput bounds how much of an unfinished escape sequence is held
+// back; anything longer is not a real sequence and is parsed as is.
+const maxPendingInput = 32
+
+// escapeTimeout is how long an unfinished escape sequence is held back
+// waiting for the rest before it is parsed as the keys it starts with.
+const escapeTimeout = 50 * time.Millisecond
+
+// inputParser parses successive reads from the terminal. An escape sequence
+// cut off at the end of one read is held back and completed by the next,
+// instead of being parsed as a stray ESC followed by garbage. A bracketed
+// paste is collected across reads and delivered as one PasteMsg.
+type inputParser struct {
+	pending []byte
+	pasting bool
+	paste   []byte
+}
+
+// Bracketed paste markers, sent around pasted text once enabled.
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// feed parses buf along with any tail held back from the previous read.
+func (p *inputParser) feed(buf []byte) []Msg {
+	data := append(p.pending, buf...)
+	p.pending = nil
+	var msgs []Msg
+	for {
+		if p.pasting {
+			end := bytes.Index(data, pasteEnd)
+			if end < 0 {
+				// The end marker may be cut off at the end of this read
+				keep := prefixSuffix(data, pasteEnd)
+				p.paste = append(p.paste, data[:len(data)-keep]...)
+				p.pending = append([]byte(nil), data[len(data)-keep:]...)
+				return msgs
+			}
+			p.paste = append(p.paste, data[:end]...)
+			msgs = append(msgs, PasteMsg{Text: normalizeNewlines(string(p.paste))})
+			p.pasting, p.paste = false, nil
+			data = data[end+len(pasteEnd):]
+			continue
+		}
+		start := bytes.Index(data, pasteStart)
+		if start < 0 {
+			n := incompleteTail(data)
+			p.pending = append([]byte(nil), data[len(data)-n:]...)
+			return append(msgs, parseInput(data[:len(data)-n])...)
+		}
+		msgs = append(msgs, parseInput(data[:start])...)
+		p.pasting = true
+		data = data[start+len(pasteStart):]
+	}
+}
+
+// holding reports whether bytes are held back as an unfinished escape
+// sequence or rune. A cut-off paste end marker does not count: the paste
+// is still going.
+func (p *inputParser) holding() bool {
+	return len(p.pending) > 0 && !p.pasting
+}
+
+// flush parses the held-back bytes as they are, for when the rest of the
+// sequence never came: a trailing ESC is the Esc key, ESC [ is Esc then [.
+func (p *inputParser) flush() []Msg {
+	if !p.holding() {
+		return nil
+	}
+	data := p.pending
+	p.pending = nil
+	return parseInput(data)
+}
+
+// prefixSuffix returns the length of the longest suffix of data that is a
+// proper prefix of marker.
+func prefixSuffix(data, marker []byte) int {
+	for n := min(len(marker)-1, len(data)); n > 0; n-- {
+		if bytes.HasSuffix(data, marker[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+// normalizeNewlines turns the carriage returns terminals paste line breaks
+// as into newlines.
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// incompleteTail returns the length of an unfinished escape sequence or
+// UTF-8 rune at the end of data, or 0 if data ends cleanly. A lone ESC that
+// makes up the whole read is a real Esc key press and is not held back.
+func incompleteTail(data []byte) int {
+	if n := incompleteRune(data); n > 0 {
+		// Keep an Alt prefix together with its rune
+		if len(data) > n && data[len(data)-n-1] == 0x1b {
+			n++
+		}
+		return n
+	}
+	start := bytes.LastIndexByte(data, 0x1b)
+	if start < 0 {
+		return 0
+	}
+	tail := data[start:]
+	if len(tail) > maxPendingInput {
+		return 0
+	}
+	switch {
+	case len(tail) == 1:
+		if len(data) == 1 {
+			return 0
+		}
+		return 1
+	case tail[1] == '[':
+		// CSI ends with a final byte in 0x40-0x7E
+		for _, c := range tail[2:] {
+			if c >= 0x40 && c <= 0x7e {
+				return 0
+			}
+		}
+		return len(tail)
+	case tail[1] == 'O':
+		// SS3: ESC O <key>
+		if len(tail) < 3 {
+			return len(tail)
+		}
+	}
+	return 0
+}
+
+// incompleteRune returns the length of a multibyte UTF-8 rune cut off at the
+// end of data, or 0 if there is none.
+func incompleteRune(data []byte) int {
+	for n := 1; n < utf8.UTFMax && n <= len(data); n++ {
+		c := data[len(data)-n]
+		if c < 0x80 {
+			return 0
+		}
+		if utf8.RuneStart(c) {
+			if utf8.FullRune(data[len(data)-n:]) {
+				return 0
+			}
+			return n
+		}
+	}
+	return 0
+}
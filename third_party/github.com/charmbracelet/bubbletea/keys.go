@@ -0,0 +1,240 @@
+package bubbletea
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseInput converts raw input bytes into messages.
+func parseInput(buf []byte) []Msg {
+	var msgs []Msg
+
+	for i := 0; i < len(buf); {
+		// Check for escape sequence
+		if buf[i] == 0x1b {
+			// SGR mouse sequence: ESC [ < Cb ; Cx ; Cy M/m
+			if i+2 < len(buf) && buf[i+1] == '[' && buf[i+2] == '<' {
+				msg, consumed := parseSGRMouse(buf[i:])
+				if consumed > 0 {
+					msgs = append(msgs, msg)
+					i += consumed
+					continue
+				}
+			}
+
+			// CSI sequence: ESC [ ...
+			if i+1 < len(buf) && buf[i+1] == '[' {
+				msg, consumed := parseCSI(buf[i:])
+				if consumed > 0 {
+					msgs = append(msgs, msg)
+					i += consumed
+					continue
+				}
+			}
+
+			// SS3 sequence: ESC O <key>
+			if i+2 < len(buf) && buf[i+1] == 'O' {
+				msg, consumed := parseSS3(buf[i:])
+				msgs = append(msgs, msg)
+				i += consumed
+				continue
+			}
+
+			// Alt+key: ESC followed by another character
+			if i+1 < len(buf) && (buf[i+1] == 0x0d || buf[i+1] == 0x0a) {
+				msgs = append(msgs, KeyMsg{Type: "alt+enter"})
+				i += 2
+				continue
+			}
+			if i+1 < len(buf) && buf[i+1] != '[' && buf[i+1] != 'O' {
+				r, size := utf8.DecodeRune(buf[i+1:])
+				msgs = append(msgs, KeyMsg{Type: "alt+" + string(r)})
+				i += 1 + size
+				continue
+			}
+
+			// Standalone ESC
+			msgs = append(msgs, KeyMsg{Type: "esc"})
+			i++
+			continue
+		}
+
+		// Control characters
+		switch buf[i] {
+		case 0x09:
+			msgs = append(msgs, KeyMsg{Type: "tab"})
+		case 0x0a, 0x0d:
+			msgs = append(msgs, KeyMsg{Type: "enter"})
+		case 0x01:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+a"})
+		case 0x02:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+b"})
+		case 0x03:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+c"})
+		case 0x04:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+d"})
+		case 0x05:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+e"})
+		case 0x06:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+f"})
+		case 0x0b:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+k"})
+		case 0x0c:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+l"})
+		case 0x0e:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+n"})
+		case 0x0f:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+o"})
+		case 0x10:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+p"})
+		case 0x12:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+r"})
+		case 0x14:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+t"})
+		case 0x15:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+u"})
+		case 0x17:
+			msgs = append(msgs, KeyMsg{Type: "ctrl+w"})
+		case 0x7f:
+			msgs = append(msgs, KeyMsg{Type: "backspace"})
+		default:
+			// Regular character
+			if buf[i] >= 0x20 && buf[i] < 0x7f {
+				msgs = append(msgs, KeyMsg{Type: string(buf[i])})
+			}
+			// Multibyte UTF-8 character; invalid bytes are dropped
+			if buf[i] >= 0x80 {
+				r, size := utf8.DecodeRune(buf[i:])
+				if r != utf8.RuneError {
+					msgs = append(msgs, KeyMsg{Type: string(r)})
+				}
+				i += size
+				continue
+			}
+		}
+		i++
+	}
+
+	return msgs
+}
+
+// parseCSI parses CSI escape sequences (ESC [ params final), including
+// modified keys such as ESC [ 1 ; 5 A (ctrl+up) and ESC [ 15 ; 2 ~
+// (shift+f5), and modified characters such as ctrl+1, which terminals
+// report as ESC [ 27 ; 5 ; 49 ~ (xterm's modifyOtherKeys) or ESC [ 49 ; 5 u.
+func parseCSI(buf []byte) (Msg, int) {
+	if len(buf) < 3 || buf[0] != 0x1b || buf[1] != '[' {
+		return nil, 0
+	}
+
+	// The final byte ends the sequence; everything before it is parameters
+	end := 2
+	for end < len(buf) && (buf[end] < 0x40 || buf[end] > 0x7e) {
+		end++
+	}
+	if end == len(buf) {
+		return KeyMsg{Type: "unknown"}, 3
+	}
+	params, final := string(buf[2:end]), buf[end]
+	code, mod, _ := strings.Cut(params, ";")
+	prefix := modifierPrefixes[mod]
+
+	switch {
+	case final == '~' && code == "27":
+		modifier, char, _ := strings.Cut(mod, ";")
+		if name, ok := modifiedChar(char, modifier); ok {
+			return KeyMsg{Type: name}, end + 1
+		}
+	case final == 'u':
+		if name, ok := modifiedChar(code, mod); ok {
+			return KeyMsg{Type: name}, end + 1
+		}
+	case final == '~':
+		if name, ok := tildeKeys[code]; ok {
+			return KeyMsg{Type: prefix + name}, end + 1
+		}
+	case final == 'Z' && params == "":
+		return KeyMsg{Type: "shift+tab"}, end + 1
+	case code == "" || code == "1":
+		if name, ok := letterKeys[final]; ok {
+			return KeyMsg{Type: prefix + name}, end + 1
+		}
+	}
+	return KeyMsg{Type: "unknown"}, end + 1
+}
+
+// modifiedChar names a printable character reported by its code point
+// with an xterm modifier parameter, e.g. "ctrl+1" for 49 and 5.
+func modifiedChar(code, mod string) (string, bool) {
+	n, err := strconv.Atoi(code)
+	if err != nil || n <= 0x20 || n == 0x7f || !utf8.ValidRune(rune(n)) {
+		return "", false
+	}
+	prefix, ok := modifierPrefixes[mod]
+	if mod != "" && !ok {
+		return "", false
+	}
+	return prefix + string(rune(n)), true
+}
+
+// parseSS3 parses ESC O <key>, which terminals send for F1-F4 and, in
+// application cursor mode, for the arrow keys.
+func parseSS3(buf []byte) (Msg, int) {
+	if len(buf) < 3 || buf[0] != 0x1b || buf[1] != 'O' {
+		return nil, 0
+	}
+	if name, ok := letterKeys[buf[2]]; ok {
+		return KeyMsg{Type: name}, 3
+	}
+	return KeyMsg{Type: "unknown"}, 3
+}
+
+// letterKeys maps the final byte of CSI and SS3 key sequences to key names.
+var letterKeys = map[byte]string{
+	'A': "up",
+	'B': "down",
+	'C': "right",
+	'D': "left",
+	'H': "home",
+	'F': "end",
+	'P': "f1",
+	'Q': "f2",
+	'R': "f3",
+	'S': "f4",
+}
+
+// tildeKeys maps the number in ESC [ n ~ sequences to key names.
+var tildeKeys = map[string]string{
+	"1":  "home",
+	"2":  "insert",
+	"3":  "delete",
+	"4":  "end",
+	"5":  "pgup",
+	"6":  "pgdown",
+	"7":  "home",
+	"8":  "end",
+	"11": "f1",
+	"12": "f2",
+	"13": "f3",
+	"14": "f4",
+	"15": "f5",
+	"17": "f6",
+	"18": "f7",
+	"19": "f8",
+	"20": "f9",
+	"21": "f10",
+	"23": "f11",
+	"24": "f12",
+}
+
+// modifierPrefixes maps the xterm modifier parameter to a key name prefix.
+var modifierPrefixes = map[string]string{
+	"2": "shift+",
+	"3": "alt+",
+	"4": "shift+alt+",
+	"5": "ctrl+",
+	"6": "shift+ctrl+",
+	"7": "alt+ctrl+",
+	"8": "shift+alt+ctrl+",
+}
@@ -0,0 +1,81 @@
+package bubbletea
+
+// parseSGRMouse parses SGR extended mouse sequences (ESC [ < Cb ; Cx ; Cy M/m).
+func parseSGRMouse(buf []byte) (Msg, int) {
+	if len(buf) < 4 || buf[0] != 0x1b || buf[1] != '[' || buf[2] != '<' {
+		return nil, 0
+	}
+
+	// Parse: <Cb;Cx;CyM or <Cb;Cx;Cym
+	var cb, cx, cy int
+	var endChar byte
+	i := 3
+
+	// Parse button code
+	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
+		cb = cb*10 + int(buf[i]-'0')
+		i++
+	}
+	if i >= len(buf) || buf[i] != ';' {
+		return nil, 0
+	}
+	i++
+
+	// Parse X coordinate
+	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
+		cx = cx*10 + int(buf[i]-'0')
+		i++
+	}
+	if i >= len(buf) || buf[i] != ';' {
+		return nil, 0
+	}
+	i++
+
+	// Parse Y coordinate
+	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
+		cy = cy*10 + int(buf[i]-'0')
+		i++
+	}
+	if i >= len(buf) {
+		return nil, 0
+	}
+	endChar = buf[i]
+	i++
+
+	if endChar != 'M' && endChar != 'm' {
+		return nil, 0
+	}
+
+	// Determine event type
+	eventType := MouseLeft
+	if endChar == 'm' {
+		eventType = MouseRelease
+	} else {
+		switch cb & 0x03 {
+		case 0:
+			eventType = MouseLeft
+		case 1:
+			eventType = MouseMiddle
+		case 2:
+			eventType = MouseRight
+		case 3:
+			eventType = MouseRelease
+		}
+		if cb&32 != 0 {
+			eventType = MouseMotion
+		}
+		if cb&64 != 0 {
+			if cb&1 == 0 {
+				eventType = MouseWheelUp
+			} else {
+				eventType = MouseWheelDown
+			}
+		}
+	}
+
+	return MouseMsg{
+		X:    cx - 1, // Convert to 0-based
+		Y:    cy - 1,
+		Type: eventType,
+	}, i
+}
@@ -0,0 +1,75 @@
+package bubbletea
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Synchronized output markers: terminals that support them show a frame
+// only once it is complete. Others ignore them.
+const (
+	syncStart = "\033[?2026h"
+	syncEnd   = "\033[?2026l"
+)
+
+// renderer draws frames by rewriting only the rows that changed since the
+// previous one, instead of clearing the screen and printing everything,
+// which flickers on slow terminals.
+type renderer struct {
+	height int
+	lines  []string // rows on screen; nil until the first frame
+}
+
+// resize records a new terminal size. Rows may have wrapped or moved, so
+// the next frame is drawn in full.
+func (r *renderer) resize(height int) {
+	r.height = height
+	r.repaint()
+}
+
+// repaint forgets what is on screen so the next frame is drawn in full, e.g.
+// after another program has used the terminal.
+func (r *renderer) repaint() {
+	r.lines = nil
+}
+
+// render returns the output that turns the screen into view, or "" when
+// nothing changed. Rows past the terminal height are dropped so the screen
+// never scrolls out from under the diff.
+func (r *renderer) render(view string) string {
+	lines := strings.Split(view, "\n")
+	if r.height > 0 && len(lines) > r.height {
+		lines = lines[:r.height]
+	}
+
+	var b strings.Builder
+	full := r.lines == nil
+	if full {
+		b.WriteString("\033[H\033[2J")
+	}
+	for i, line := range lines {
+		if !full && i < len(r.lines) && r.lines[i] == line {
+			continue
+		}
+		moveTo(&b, i)
+		b.WriteString(line)
+		// Reset before erasing so the rest of the row is not painted
+		b.WriteString("\033[0m\033[K")
+	}
+	for i := len(lines); i < len(r.lines); i++ {
+		moveTo(&b, i)
+		b.WriteString("\033[K")
+	}
+	r.lines = lines
+	if b.Len() == 0 {
+		return ""
+	}
+	return syncStart + b.String() + syncEnd
+}
+
+// moveTo writes the sequence that puts the cursor at the start of row.
+func moveTo(b *strings.Builder, row int) {
+	b.WriteString("\033[")
+	b.WriteString(strconv.Itoa(row + 1))
+	b.WriteString(";1H")
+}
@@ -0,0 +1,33 @@
+package bubbletea
+
+import "testing"
+
+func TestRendererRewritesChangedRows(t *testing.T) {
+	const eol = "\033[0m\033[K"
+	frame := func(body string) string { return syncStart + body + syncEnd }
+
+	var r renderer
+	r.resize(3)
+	steps := []struct {
+		name string
+		step func() string
+		want string
+	}{
+		{"first frame in full", func() string { return r.render("a\nb\nc") },
+			frame("\033[H\033[2J\033[1;1Ha" + eol + "\033[2;1Hb" + eol + "\033[3;1Hc" + eol)},
+		{"unchanged", func() string { return r.render("a\nb\nc") }, ""},
+		{"one row", func() string { return r.render("a\nB\nc") }, frame("\033[2;1HB" + eol)},
+		{"fewer rows", func() string { return r.render("a") }, frame("\033[2;1H\033[K\033[3;1H\033[K")},
+		{"taller than the terminal", func() string { return r.render("a\nb\nc\nd") },
+			frame("\033[2;1Hb" + eol + "\033[3;1Hc" + eol)},
+		{"after a resize", func() string { r.resize(2); return r.render("a\nb") },
+			frame("\033[H\033[2J\033[1;1Ha" + eol + "\033[2;1Hb" + eol)},
+		{"after a repaint", func() string { r.repaint(); return r.render("a\nb") },
+			frame("\033[H\033[2J\033[1;1Ha" + eol + "\033[2;1Hb" + eol)},
+	}
+	for _, s := range steps {
+		if got := s.step(); got != s.want {
+			t.Fatalf("%s: render = %q, want %q", s.name, got, s.want)
+		}
+	}
+}
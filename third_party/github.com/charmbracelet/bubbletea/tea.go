@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"golang.org/x/term"
@@ -27,6 +28,7 @@ type Program struct {
 	model        Model
 	altScreen    bool
 	mouseEnabled bool
+	oldState     *term.State // terminal state before raw mode
 }
 
 // ProgramOption configures a Program.
@@ -53,30 +55,10 @@ func WithMouseCellMotion() ProgramOption {
 
 // Run executes the event loop with proper terminal handling.
 func (p *Program) Run() (Model, error) {
-	// Save terminal state and enter raw mode
-	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
-	if err != nil {
-		return p.model, fmt.Errorf("failed to enter raw mode: %w", err)
-	}
-	defer term.Restore(int(os.Stdin.Fd()), oldState)
-
-	// Enter alternate screen if requested
-	if p.altScreen {
-		fmt.Print("\033[?1049h") // Enter alt screen
-		defer fmt.Print("\033[?1049l") // Exit alt screen
-	}
-
-	// Enable mouse if requested
-	if p.mouseEnabled {
-		fmt.Print("\033[?1000h") // Enable mouse click tracking
-		fmt.Print("\033[?1006h") // Enable SGR extended mouse mode
-		defer fmt.Print("\033[?1000l")
-		defer fmt.Print("\033[?1006l")
+	if err := p.acquireTerminal(); err != nil {
+		return p.model, err
 	}
-
-	// Hide cursor during operation
-	fmt.Print("\033[?25l")
-	defer fmt.Print("\033[?25h")
+	defer p.releaseTerminal()
 
 	m := p.model
 
@@ -106,316 +88,116 @@ func (p *Program) Run() (Model, error) {
 	}()
 
 	// Read input in separate goroutine
-	go func() {
-		buf := make([]byte, 256)
-		for {
-			n, err := os.Stdin.Read(buf)
-			if err != nil {
-				select {
-				case <-done:
-					return
-				default:
-					return
-				}
-			}
-			msgs := parseInput(buf[:n])
-			for _, msg := range msgs {
-				select {
-				case msgCh <- msg:
-				case <-done:
-					return
-				}
-			}
-		}
-	}()
+	input := newInputReader()
+	defer input.close()
+	go input.run(msgCh, done)
+
+	// Commands run in the background and feed their result back into the loop
+	run := func(cmd Cmd) { execute(cmd, msgCh, done) }
 
 	// Get initial window size
-	width, height, _ := term.GetSize(int(os.Stdout.Fd()))
-	m, _ = m.Update(WindowSizeMsg{Width: width, Height: height})
+	width, height := initialSize(term.GetSize, os.Getenv)
+	var screen renderer
+	screen.resize(height)
+	var cmd Cmd
+	m, cmd = m.Update(WindowSizeMsg{Width: width, Height: height})
+	run(cmd)
 
 	// Run init command
-	if cmd := m.Init(); cmd != nil {
-		if msg := cmd(); msg != nil {
-			m, _ = m.Update(msg)
-		}
-	}
+	run(m.Init())
 
 	// Main event loop
 	for {
-		// Clear screen and render
-		fmt.Print("\033[H\033[2J")
-		fmt.Print(m.View())
+		// Draw the rows that changed since the last frame
+		fmt.Print(screen.render(m.View()))
 
 		// Wait for message
 		msg := <-msgCh
-
-		var cmd Cmd
-		m, cmd = m.Update(msg)
-		if cmd != nil {
-			if _, ok := cmd().(quitMsg); ok {
-				return m, nil
-			}
-		}
-	}
-}
-
-// parseInput converts raw input bytes into messages.
-func parseInput(buf []byte) []Msg {
-	var msgs []Msg
-
-	for i := 0; i < len(buf); {
-		// Check for escape sequence
-		if buf[i] == 0x1b {
-			// SGR mouse sequence: ESC [ < Cb ; Cx ; Cy M/m
-			if i+2 < len(buf) && buf[i+1] == '[' && buf[i+2] == '<' {
-				msg, consumed := parseSGRMouse(buf[i:])
-				if consumed > 0 {
-					msgs = append(msgs, msg)
-					i += consumed
-					continue
-				}
-			}
-
-			// CSI sequence: ESC [ ...
-			if i+1 < len(buf) && buf[i+1] == '[' {
-				msg, consumed := parseCSI(buf[i:])
-				if consumed > 0 {
-					msgs = append(msgs, msg)
-					i += consumed
-					continue
-				}
-			}
-
-			// Alt+key: ESC followed by another character
-			if i+1 < len(buf) && buf[i+1] != '[' && buf[i+1] != 'O' {
-				key := string(buf[i+1])
-				msgs = append(msgs, KeyMsg{Type: "alt+" + key})
-				i += 2
+		switch msg := msg.(type) {
+		case quitMsg:
+			return m, nil
+		case WindowSizeMsg:
+			screen.resize(msg.Height)
+		case execMsg:
+			err := p.execProcess(msg.cmd, input)
+			// The process drew over the screen
+			screen.repaint()
+			if msg.fn == nil {
 				continue
 			}
-
-			// Standalone ESC
-			msgs = append(msgs, KeyMsg{Type: "esc"})
-			i++
+			m, cmd = m.Update(msg.fn(err))
+			run(cmd)
 			continue
 		}
 
-		// Control characters
-		switch buf[i] {
-		case 0x09:
-			msgs = append(msgs, KeyMsg{Type: "tab"})
-		case 0x0a, 0x0d:
-			msgs = append(msgs, KeyMsg{Type: "enter"})
-		case 0x01:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+a"})
-		case 0x02:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+b"})
-		case 0x03:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+c"})
-		case 0x04:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+d"})
-		case 0x05:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+e"})
-		case 0x06:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+f"})
-		case 0x0b:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+k"})
-		case 0x0c:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+l"})
-		case 0x0e:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+n"})
-		case 0x0f:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+o"})
-		case 0x10:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+p"})
-		case 0x15:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+u"})
-		case 0x17:
-			msgs = append(msgs, KeyMsg{Type: "ctrl+w"})
-		case 0x7f:
-			msgs = append(msgs, KeyMsg{Type: "backspace"})
-		default:
-			// Regular character
-			if buf[i] >= 0x20 && buf[i] < 0x7f {
-				msgs = append(msgs, KeyMsg{Type: string(buf[i])})
-			}
-		}
-		i++
+		m, cmd = m.Update(msg)
+		run(cmd)
 	}
-
-	return msgs
 }
 
-// parseCSI parses CSI escape sequences (ESC [ ...).
-func parseCSI(buf []byte) (Msg, int) {
-	if len(buf) < 3 || buf[0] != 0x1b || buf[1] != '[' {
-		return nil, 0
-	}
-
-	// Arrow keys and other simple sequences
-	switch buf[2] {
-	case 'A':
-		return KeyMsg{Type: "up"}, 3
-	case 'B':
-		return KeyMsg{Type: "down"}, 3
-	case 'C':
-		return KeyMsg{Type: "right"}, 3
-	case 'D':
-		return KeyMsg{Type: "left"}, 3
-	case 'H':
-		return KeyMsg{Type: "home"}, 3
-	case 'F':
-		return KeyMsg{Type: "end"}, 3
-	case 'Z':
-		return KeyMsg{Type: "shift+tab"}, 3
+// acquireTerminal enters raw mode, saving the previous state, and applies
+// the alternate screen and mouse modes the Program was built with.
+func (p *Program) acquireTerminal() error {
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %w", err)
 	}
+	p.oldState = state
 
-	// Modified keys: ESC [ 1 ; mod X
-	if len(buf) >= 6 && buf[2] == '1' && buf[3] == ';' {
-		mod := buf[4]
-		key := buf[5]
-		prefix := ""
-		switch mod {
-		case '2':
-			prefix = "shift+"
-		case '3':
-			prefix = "alt+"
-		case '4':
-			prefix = "shift+alt+"
-		case '5':
-			prefix = "ctrl+"
-		case '6':
-			prefix = "shift+ctrl+"
-		case '7':
-			prefix = "alt+ctrl+"
-		case '8':
-			prefix = "shift+alt+ctrl+"
-		}
-		switch key {
-		case 'A':
-			return KeyMsg{Type: prefix + "up"}, 6
-		case 'B':
-			return KeyMsg{Type: prefix + "down"}, 6
-		case 'C':
-			return KeyMsg{Type: prefix + "right"}, 6
-		case 'D':
-			return KeyMsg{Type: prefix + "left"}, 6
-		case 'H':
-			return KeyMsg{Type: prefix + "home"}, 6
-		case 'F':
-			return KeyMsg{Type: prefix + "end"}, 6
-		}
+	if p.altScreen {
+		fmt.Print("\033[?1049h") // Enter alt screen
 	}
-
-	// Page up/down, delete, insert: ESC [ N ~
-	if len(buf) >= 4 && buf[3] == '~' {
-		switch buf[2] {
-		case '2':
-			return KeyMsg{Type: "insert"}, 4
-		case '3':
-			return KeyMsg{Type: "delete"}, 4
-		case '5':
-			return KeyMsg{Type: "pgup"}, 4
-		case '6':
-			return KeyMsg{Type: "pgdown"}, 4
-		}
+	if p.mouseEnabled {
+		fmt.Print("\033[?1000h") // Enable mouse click tracking
+		fmt.Print("\033[?1002h") // Report motion while a button is held (drag)
+		fmt.Print("\033[?1006h") // Enable SGR extended mouse mode
 	}
-
-	return KeyMsg{Type: "unknown"}, 3
+	// Deliver pastes as one PasteMsg instead of keystrokes
+	fmt.Print("\033[?2004h")
+	// Report modified keys that have no legacy encoding, such as ctrl+1
+	fmt.Print("\033[>4;1m")
+	// Hide cursor during operation
+	fmt.Print("\033[?25l")
+	return nil
 }
 
-// parseSGRMouse parses SGR extended mouse sequences (ESC [ < Cb ; Cx ; Cy M/m).
-func parseSGRMouse(buf []byte) (Msg, int) {
-	if len(buf) < 4 || buf[0] != 0x1b || buf[1] != '[' || buf[2] != '<' {
-		return nil, 0
-	}
-
-	// Parse: <Cb;Cx;CyM or <Cb;Cx;Cym
-	var cb, cx, cy int
-	var endChar byte
-	i := 3
-
-	// Parse button code
-	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
-		cb = cb*10 + int(buf[i]-'0')
-		i++
-	}
-	if i >= len(buf) || buf[i] != ';' {
-		return nil, 0
-	}
-	i++
-
-	// Parse X coordinate
-	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
-		cx = cx*10 + int(buf[i]-'0')
-		i++
-	}
-	if i >= len(buf) || buf[i] != ';' {
-		return nil, 0
-	}
-	i++
-
-	// Parse Y coordinate
-	for i < len(buf) && buf[i] >= '0' && buf[i] <= '9' {
-		cy = cy*10 + int(buf[i]-'0')
-		i++
+// releaseTerminal undoes acquireTerminal in reverse order.
+func (p *Program) releaseTerminal() {
+	fmt.Print("\033[?25h")
+	fmt.Print("\033[>4m")
+	fmt.Print("\033[?2004l")
+	if p.mouseEnabled {
+		fmt.Print("\033[?1006l")
+		fmt.Print("\033[?1002l")
+		fmt.Print("\033[?1000l")
 	}
-	if i >= len(buf) {
-		return nil, 0
+	if p.altScreen {
+		fmt.Print("\033[?1049l") // Exit alt screen
 	}
-	endChar = buf[i]
-	i++
+	term.Restore(int(os.Stdin.Fd()), p.oldState)
+}
 
-	if endChar != 'M' && endChar != 'm' {
-		return nil, 0
-	}
+// Fallback size used when the terminal size cannot be detected.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
 
-	// Determine event type
-	eventType := MouseLeft
-	if endChar == 'm' {
-		eventType = MouseRelease
-	} else {
-		switch cb & 0x03 {
-		case 0:
-			eventType = MouseLeft
-		case 1:
-			eventType = MouseMiddle
-		case 2:
-			eventType = MouseRight
-		case 3:
-			eventType = MouseRelease
-		}
-		if cb&32 != 0 {
-			eventType = MouseMotion
-		}
-		if cb&64 != 0 {
-			if cb&1 == 0 {
-				eventType = MouseWheelUp
-			} else {
-				eventType = MouseWheelDown
-			}
+// initialSize works out the starting terminal size. Some pipe/PTY setups
+// report 0x0 for stdout, so it falls back to stdin, then $COLUMNS/$LINES,
+// then 80x24. Later SIGWINCH events still deliver the real size.
+func initialSize(getSize func(fd int) (int, int, error), getenv func(string) string) (int, int) {
+	for _, fd := range []int{int(os.Stdout.Fd()), int(os.Stdin.Fd())} {
+		if w, h, err := getSize(fd); err == nil && w > 0 && h > 0 {
+			return w, h
 		}
 	}
-
-	return MouseMsg{
-		X:    cx - 1, // Convert to 0-based
-		Y:    cy - 1,
-		Type: eventType,
-	}, i
-}
-
-// Batch runs commands and returns the last non-nil result.
-func Batch(cmds ...Cmd) Cmd {
-	return func() Msg {
-		var msg Msg
-		for _, cmd := range cmds {
-			if cmd == nil {
-				continue
-			}
-			msg = cmd()
-		}
-		return msg
+	w, errW := strconv.Atoi(getenv("COLUMNS"))
+	h, errH := strconv.Atoi(getenv("LINES"))
+	if errW == nil && errH == nil && w > 0 && h > 0 {
+		return w, h
 	}
+	return defaultWidth, defaultHeight
 }
 
 // KeyMsg represents a key press.
@@ -428,6 +210,12 @@ func (k KeyMsg) String() string {
 	return k.Type
 }
 
+// PasteMsg carries text pasted into the terminal in one piece. Line
+// breaks are "\n" and are not Enter key presses.
+type PasteMsg struct {
+	Text string
+}
+
 // WindowSizeMsg reports the terminal dimensions.
 type WindowSizeMsg struct {
 	Width  int
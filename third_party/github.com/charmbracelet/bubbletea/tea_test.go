@@ -0,0 +1,296 @@
+package bubbletea
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInitialSizeFallbackChain(t *testing.T) {
+	stdout, stdin := int(os.Stdout.Fd()), int(os.Stdin.Fd())
+	type size struct{ w, h int }
+
+	tests := []struct {
+		name  string
+		sizes map[int]size // missing fd means GetSize fails
+		env   map[string]string
+		wantW int
+		wantH int
+	}{
+		{"stdout", map[int]size{stdout: {120, 40}, stdin: {100, 30}}, nil, 120, 40},
+		{"stdout zero uses stdin", map[int]size{stdout: {0, 0}, stdin: {100, 30}}, nil, 100, 30},
+		{"env when no tty", nil, map[string]string{"COLUMNS": "132", "LINES": "50"}, 132, 50},
+		{"bad env uses default", nil, map[string]string{"COLUMNS": "wide", "LINES": "50"}, 80, 24},
+		{"nothing uses default", map[int]size{stdout: {0, 0}}, nil, 80, 24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getSize := func(fd int) (int, int, error) {
+				s, ok := tt.sizes[fd]
+				if !ok {
+					return 0, 0, errors.New("not a terminal")
+				}
+				return s.w, s.h, nil
+			}
+			getenv := func(key string) string { return tt.env[key] }
+
+			w, h := initialSize(getSize, getenv)
+			if w != tt.wantW || h != tt.wantH {
+				t.Fatalf("initialSize() = %dx%d, want %dx%d", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestInputParserCompletesSplitSequences(t *testing.T) {
+	tests := []struct {
+		name  string
+		reads []string
+		want  []Msg
+	}{
+		{
+			name:  "arrow split after CSI",
+			reads: []string{"a\x1b[", "A"},
+			want:  []Msg{KeyMsg{Type: "a"}, KeyMsg{Type: "up"}},
+		},
+		{
+			name:  "mouse split mid coordinates",
+			reads: []string{"\x1b[<0;1", "0;5M"},
+			want:  []Msg{MouseMsg{X: 9, Y: 4, Type: MouseLeft}},
+		},
+		{
+			name:  "split right after ESC",
+			reads: []string{"x\x1b", "[B"},
+			want:  []Msg{KeyMsg{Type: "x"}, KeyMsg{Type: "down"}},
+		},
+		{
+			name:  "rune split across reads",
+			reads: []string{"caf\xc3", "\xa9 \xe6\x97", "\xa5"},
+			want:  []Msg{KeyMsg{Type: "c"}, KeyMsg{Type: "a"}, KeyMsg{Type: "f"}, KeyMsg{Type: "é"}, KeyMsg{Type: " "}, KeyMsg{Type: "日"}},
+		},
+		{
+			name:  "alt rune split across reads",
+			reads: []string{"\x1b\xc3", "\xa9"},
+			want:  []Msg{KeyMsg{Type: "alt+é"}},
+		},
+		{
+			name:  "lone esc key",
+			reads: []string{"\x1b"},
+			want:  []Msg{KeyMsg{Type: "esc"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parser inputParser
+			var got []Msg
+			for _, read := range tt.reads {
+				got = append(got, parser.feed([]byte(read))...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("feed() = %#v, want %#v", got, tt.want)
+			}
+			if len(parser.pending) != 0 {
+				t.Fatalf("expected nothing pending, got %q", parser.pending)
+			}
+		})
+	}
+}
+
+func TestParseInputKeySequences(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"\x1bOP\x1bOQ\x1bOR\x1bOS", []string{"f1", "f2", "f3", "f4"}},
+		{"\x1b[15~\x1b[17~\x1b[18~\x1b[19~", []string{"f5", "f6", "f7", "f8"}},
+		{"\x1b[20~\x1b[21~\x1b[23~\x1b[24~", []string{"f9", "f10", "f11", "f12"}},
+		{"\x1b[15;2~\x1b[24;5~", []string{"shift+f5", "ctrl+f12"}},
+		{"\x1b[1;2P\x1b[1;3S", []string{"shift+f1", "alt+f4"}},
+		{"\x1b[A\x1b[1;5C\x1bOB", []string{"up", "ctrl+right", "down"}},
+		{"\x1b[3~\x1b[5~\x1b[6~\x1b[2~", []string{"delete", "pgup", "pgdown", "insert"}},
+		{"\x1b[Z", []string{"shift+tab"}},
+		{"\x1b[27;5;49~\x1b[50;5u", []string{"ctrl+1", "ctrl+2"}},
+		{"\x1b[27;6;33~\x1b[97u", []string{"shift+ctrl+!", "a"}},
+		{"\x1b[27;5;9~\x1b[49;99u", []string{"unknown", "unknown"}},
+		{"\x12\x10", []string{"ctrl+r", "ctrl+p"}},
+		{"\x14", []string{"ctrl+t"}},
+		{"\x1b[99~x", []string{"unknown", "x"}},
+		{"héllo", []string{"h", "é", "l", "l", "o"}},
+		{"日本\U0001F600", []string{"日", "本", "\U0001F600"}},
+		{"\x1bé", []string{"alt+é"}},
+		{"\x1b\r\r", []string{"alt+enter", "enter"}},
+		{"a\xffb", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, msg := range parseInput([]byte(tt.in)) {
+			got = append(got, msg.(KeyMsg).Type)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseInput(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestInputParserCollectsBracketedPaste(t *testing.T) {
+	tests := []struct {
+		name  string
+		reads []string
+		want  []Msg
+	}{
+		{
+			name:  "one read",
+			reads: []string{"a\x1b[200~make\rmake test\x1b[201~b"},
+			want:  []Msg{KeyMsg{Type: "a"}, PasteMsg{Text: "make\nmake test"}, KeyMsg{Type: "b"}},
+		},
+		{
+			name:  "split across reads",
+			reads: []string{"\x1b[20", "0~line one\r\n", "line two\x1b[2", "01~"},
+			want:  []Msg{PasteMsg{Text: "line one\nline two"}},
+		},
+		{
+			name:  "escape sequences inside are text",
+			reads: []string{"\x1b[200~\x1b[A\x03\x1b[201~"},
+			want:  []Msg{PasteMsg{Text: "\x1b[A\x03"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parser inputParser
+			var got []Msg
+			for _, read := range tt.reads {
+				got = append(got, parser.feed([]byte(read))...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("feed() = %#v, want %#v", got, tt.want)
+			}
+			if parser.pasting || len(parser.pending) != 0 {
+				t.Fatalf("expected the paste finished, pending %q", parser.pending)
+			}
+		})
+	}
+}
+
+func TestInputParserFlushesHeldSequences(t *testing.T) {
+	tests := []struct {
+		name string
+		read string
+		want []Msg
+	}{
+		{"trailing esc", "x\x1b", []Msg{KeyMsg{Type: "x"}, KeyMsg{Type: "esc"}}},
+		{"esc bracket", "\x1b[", []Msg{KeyMsg{Type: "esc"}, KeyMsg{Type: "["}}},
+		{"esc O", "\x1bO", []Msg{KeyMsg{Type: "esc"}, KeyMsg{Type: "O"}}},
+		{"nothing held", "a", []Msg{KeyMsg{Type: "a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parser inputParser
+			got := parser.feed([]byte(tt.read))
+			got = append(got, parser.flush()...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("feed+flush = %#v, want %#v", got, tt.want)
+			}
+			if parser.holding() {
+				t.Fatalf("expected nothing held after a flush, got %q", parser.pending)
+			}
+		})
+	}
+}
+
+func TestInputParserKeepsPasteMarkerAcrossFlush(t *testing.T) {
+	var parser inputParser
+	parser.feed([]byte("\x1b[200~text\x1b[20"))
+	if parser.holding() || len(parser.flush()) != 0 {
+		t.Fatal("expected a cut-off paste end marker to wait for the rest of the paste")
+	}
+	if got := parser.feed([]byte("1~")); !reflect.DeepEqual(got, []Msg{PasteMsg{Text: "text"}}) {
+		t.Fatalf("expected the paste delivered, got %#v", got)
+	}
+}
+
+func TestInputReaderFlushesLoneEsc(t *testing.T) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer pr.Close()
+	defer pw.Close()
+
+	r := &inputReader{file: pr, resumed: make(chan struct{}, 1)}
+	msgCh := make(chan Msg, 4)
+	done := make(chan struct{})
+	defer close(done)
+	go r.run(msgCh, done)
+
+	pw.Write([]byte("x\x1b"))
+	for _, want := range []Msg{KeyMsg{Type: "x"}, KeyMsg{Type: "esc"}} {
+		select {
+		case msg := <-msgCh:
+			if msg != want {
+				t.Fatalf("got %v, want %v", msg, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %v", want)
+		}
+	}
+}
+
+func TestInputReaderPausesAndResumes(t *testing.T) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer pr.Close()
+	defer pw.Close()
+
+	r := &inputReader{file: pr, resumed: make(chan struct{}, 1)}
+	msgCh := make(chan Msg, 4)
+	done := make(chan struct{})
+	defer close(done)
+	go r.run(msgCh, done)
+
+	receive := func() Msg {
+		select {
+		case msg := <-msgCh:
+			return msg
+		case <-time.After(time.Second):
+			return nil
+		}
+	}
+
+	pw.Write([]byte("a"))
+	if msg := receive(); msg != (KeyMsg{Type: "a"}) {
+		t.Fatalf("expected a before pausing, got %v", msg)
+	}
+
+	if !r.pause() {
+		t.Fatal("expected a pipe to support pausing")
+	}
+	pw.Write([]byte("b"))
+	select {
+	case msg := <-msgCh:
+		t.Fatalf("paused reader delivered %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	r.resume()
+	if msg := receive(); msg != (KeyMsg{Type: "b"}) {
+		t.Fatalf("expected b after resuming, got %v", msg)
+	}
+}
+
+func TestExecProcessDeliversCallback(t *testing.T) {
+	c := exec.Command("true")
+	msg := ExecProcess(c, func(err error) Msg { return err })()
+	em, ok := msg.(execMsg)
+	if !ok || em.cmd != c {
+		t.Fatalf("expected an execMsg for the command, got %#v", msg)
+	}
+	want := errors.New("boom")
+	if got := em.fn(want); got != want {
+		t.Fatalf("callback returned %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package lipgloss
+
+// Border holds the runes a bordered Style draws its box with.
+type Border struct {
+	Top         string
+	Bottom      string
+	Left        string
+	Right       string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+}
+
+// NormalBorder is a square single-line border, the default.
+func NormalBorder() Border {
+	return Border{
+		Top: "─", Bottom: "─", Left: "│", Right: "│",
+		TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+	}
+}
+
+// RoundedBorder is a single-line border with rounded corners.
+func RoundedBorder() Border {
+	return Border{
+		Top: "─", Bottom: "─", Left: "│", Right: "│",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+	}
+}
+
+// ThickBorder is a heavy single-line border.
+func ThickBorder() Border {
+	return Border{
+		Top: "━", Bottom: "━", Left: "┃", Right: "┃",
+		TopLeft: "┏", TopRight: "┓", BottomLeft: "┗", BottomRight: "┛",
+	}
+}
+
+// DoubleBorder is a double-line border.
+func DoubleBorder() Border {
+	return Border{
+		Top: "═", Bottom: "═", Left: "║", Right: "║",
+		TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+	}
+}
@@ -0,0 +1,27 @@
+package lipgloss
+
+import "testing"
+
+func TestBorderRendering(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"default", NewStyle().Border(true), "┌──┐\n│ab│\n└──┘"},
+		{"rounded", NewStyle().BorderStyle(RoundedBorder()), "╭──╮\n│ab│\n╰──╯"},
+		{"thick", NewStyle().BorderStyle(ThickBorder()), "┏━━┓\n┃ab┃\n┗━━┛"},
+		{"double", NewStyle().BorderStyle(DoubleBorder()), "╔══╗\n║ab║\n╚══╝"},
+		{"no right", NewStyle().Border(true).BorderRight(false), "┌──\n│ab\n└──"},
+		{"no top or bottom", NewStyle().Border(true).BorderTop(false).BorderBottom(false), "│ab│"},
+		{"colored", NewStyle().Border(true).BorderLeft(false).BorderRight(false).BorderBottom(false).BorderForeground("6"),
+			"\x1b[38;5;6m──\x1b[39m\nab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render("ab"); got != tt.want {
+				t.Fatalf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,29 +2,63 @@ package lipgloss
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
-// Color represents an ANSI color code.
+// Color is an ANSI 256-color palette number, such as "62", or a truecolor
+// hex value, "#rrggbb".
 type Color string
 
+// code returns the SGR parameters that select c as the foreground (base
+// 38) or background (base 48) color. A malformed hex value yields "".
+func (c Color) code(base int) string {
+	hex, ok := strings.CutPrefix(string(c), "#")
+	if !ok {
+		return fmt.Sprintf("%d;5;%s", base, c)
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return ""
+	}
+	return fmt.Sprintf("%d;2;%d;%d;%d", base, rgb>>16, rgb>>8&0xff, rgb&0xff)
+}
+
 // Style models simple styling options.
 type Style struct {
-	bold       bool
-	marginTop  int
-	fg         Color
-	bg         Color
-	paddingH   int
-	paddingV   int
-	border     bool
-	width      int
-	height     int
-	reverse    bool
+	bold         bool
+	marginTop    int
+	marginRight  int
+	marginBottom int
+	marginLeft   int
+	fg           Color
+	bg           Color
+	paddingH     int
+	paddingV     int
+	border       bool
+	borderRune   Border
+	borderFg     Color
+	hideTop      bool
+	hideRight    bool
+	hideBottom   bool
+	hideLeft     bool
+	width        int
+	height       int
+	reverse      bool
+	faint        bool
+	wrap         bool
+	align        Position
 }
 
 // NewStyle constructs a Style.
 func NewStyle() Style { return Style{} }
 
+// Faint toggles dim rendering.
+func (s Style) Faint(enabled bool) Style {
+	s.faint = enabled
+	return s
+}
+
 // Bold toggles bold rendering.
 func (s Style) Bold(enabled bool) Style {
 	s.bold = enabled
@@ -37,6 +71,24 @@ func (s Style) MarginTop(lines int) Style {
 	return s
 }
 
+// MarginRight sets the unstyled columns after each line, outside any border.
+func (s Style) MarginRight(columns int) Style {
+	s.marginRight = columns
+	return s
+}
+
+// MarginBottom sets the bottom margin lines.
+func (s Style) MarginBottom(lines int) Style {
+	s.marginBottom = lines
+	return s
+}
+
+// MarginLeft sets the unstyled columns before each line, outside any border.
+func (s Style) MarginLeft(columns int) Style {
+	s.marginLeft = columns
+	return s
+}
+
 // Foreground sets the foreground color.
 func (s Style) Foreground(c Color) Style {
 	s.fg = c
@@ -62,6 +114,43 @@ func (s Style) Border(enabled bool) Style {
 	return s
 }
 
+// BorderStyle enables the border and draws it with b's runes.
+func (s Style) BorderStyle(b Border) Style {
+	s.border = true
+	s.borderRune = b
+	return s
+}
+
+// BorderForeground sets the border color.
+func (s Style) BorderForeground(c Color) Style {
+	s.borderFg = c
+	return s
+}
+
+// BorderTop shows or hides the top side of the border.
+func (s Style) BorderTop(enabled bool) Style {
+	s.hideTop = !enabled
+	return s
+}
+
+// BorderRight shows or hides the right side of the border.
+func (s Style) BorderRight(enabled bool) Style {
+	s.hideRight = !enabled
+	return s
+}
+
+// BorderBottom shows or hides the bottom side of the border.
+func (s Style) BorderBottom(enabled bool) Style {
+	s.hideBottom = !enabled
+	return s
+}
+
+// BorderLeft shows or hides the left side of the border.
+func (s Style) BorderLeft(enabled bool) Style {
+	s.hideLeft = !enabled
+	return s
+}
+
 // Width sets a fixed width for the styled content.
 func (s Style) Width(w int) Style {
 	s.width = w
@@ -74,6 +163,21 @@ func (s Style) Height(h int) Style {
 	return s
 }
 
+// Wrap makes lines wider than the fixed Width wrap onto the next row,
+// breaking at spaces where possible, instead of being cut off.
+func (s Style) Wrap(enabled bool) Style {
+	s.wrap = enabled
+	return s
+}
+
+// Align sets the horizontal alignment of lines narrower than the content
+// width: Left (the default), Center or Right. Without a fixed Width, lines
+// are aligned to the widest one.
+func (s Style) Align(p Position) Style {
+	s.align = p
+	return s
+}
+
 // Reverse enables reverse video (swap fg/bg) for highlighting.
 func (s Style) Reverse(enabled bool) Style {
 	s.reverse = enabled
@@ -84,13 +188,23 @@ func (s Style) Reverse(enabled bool) Style {
 func (s Style) Render(str string) string {
 	var builder strings.Builder
 
-	// Top margin
-	for i := 0; i < s.marginTop; i++ {
-		builder.WriteString("\n")
-	}
-
 	// Split content into lines
 	lines := strings.Split(str, "\n")
+	if s.wrap && s.width > 0 {
+		var wrapped []string
+		for _, line := range lines {
+			wrapped = append(wrapped, wrapVisible(line, s.width-2*s.paddingH)...)
+		}
+		lines = wrapped
+	}
+
+	if s.align == Center || s.align == Right {
+		inner := s.width - 2*s.paddingH
+		if s.width == 0 {
+			inner = Width(strings.Join(lines, "\n"))
+		}
+		alignLines(lines, inner, s.align)
+	}
 
 	// Apply horizontal padding to each line
 	padding := strings.Repeat(" ", s.paddingH)
@@ -102,18 +216,21 @@ func (s Style) Render(str string) string {
 	contentWidth := s.width
 	if contentWidth == 0 {
 		for _, line := range lines {
-			if len(line) > contentWidth {
-				contentWidth = len(line)
+			if w := visibleWidth(line); w > contentWidth {
+				contentWidth = w
 			}
 		}
 	}
 
-	// Pad lines to fixed width
+	// Pad lines to fixed width, measuring and cutting around ANSI codes
 	for i, line := range lines {
-		if len(line) < contentWidth {
-			lines[i] = line + strings.Repeat(" ", contentWidth-len(line))
-		} else if len(line) > contentWidth && s.width > 0 {
-			lines[i] = line[:contentWidth]
+		w := visibleWidth(line)
+		if w < contentWidth {
+			lines[i] = line + strings.Repeat(" ", contentWidth-w)
+		} else if w > contentWidth && s.width > 0 {
+			// A wide character at the cut leaves a cell to fill
+			lines[i] = truncateVisible(line, contentWidth)
+			lines[i] += strings.Repeat(" ", contentWidth-visibleWidth(lines[i]))
 		}
 	}
 
@@ -132,14 +249,17 @@ func (s Style) Render(str string) string {
 	if s.bold {
 		codes = append(codes, "1")
 	}
+	if s.faint {
+		codes = append(codes, "2")
+	}
 	if s.reverse {
 		codes = append(codes, "7")
 	}
-	if s.fg != "" {
-		codes = append(codes, fmt.Sprintf("38;5;%s", s.fg))
+	if code := s.fg.code(38); s.fg != "" && code != "" {
+		codes = append(codes, code)
 	}
-	if s.bg != "" {
-		codes = append(codes, fmt.Sprintf("48;5;%s", s.bg))
+	if code := s.bg.code(48); s.bg != "" && code != "" {
+		codes = append(codes, code)
 	}
 
 	ansiStart := ""
@@ -151,36 +271,55 @@ func (s Style) Render(str string) string {
 
 	// Render with or without border
 	if s.border {
-		// Border characters
-		const (
-			topLeft     = "┌"
-			topRight    = "┐"
-			bottomLeft  = "└"
-			bottomRight = "┘"
-			horizontal  = "─"
-			vertical    = "│"
-		)
-
-		// Top border
-		builder.WriteString(topLeft)
-		builder.WriteString(strings.Repeat(horizontal, contentWidth))
-		builder.WriteString(topRight)
-		builder.WriteString("\n")
-
-		// Content lines with side borders
-		for _, line := range lines {
-			builder.WriteString(vertical)
+		b := s.borderRune
+		if b == (Border{}) {
+			b = NormalBorder()
+		}
+		paint := func(edge string) string {
+			code := s.borderFg.code(38)
+			if s.borderFg == "" || code == "" || edge == "" {
+				return edge
+			}
+			return fmt.Sprintf("\033[%sm%s\033[39m", code, edge)
+		}
+		left, right := paint(b.Left), paint(b.Right)
+		if s.hideLeft {
+			left = ""
+		}
+		if s.hideRight {
+			right = ""
+		}
+		// Corners only where both of their sides are drawn
+		corner := func(c string, side bool) string {
+			if !side {
+				return ""
+			}
+			return c
+		}
+
+		if !s.hideTop {
+			builder.WriteString(paint(corner(b.TopLeft, !s.hideLeft) +
+				strings.Repeat(b.Top, contentWidth) +
+				corner(b.TopRight, !s.hideRight)))
+			builder.WriteString("\n")
+		}
+
+		for i, line := range lines {
+			builder.WriteString(left)
 			builder.WriteString(ansiStart)
 			builder.WriteString(line)
 			builder.WriteString(ansiEnd)
-			builder.WriteString(vertical)
-			builder.WriteString("\n")
+			builder.WriteString(right)
+			if i < len(lines)-1 || !s.hideBottom {
+				builder.WriteString("\n")
+			}
 		}
 
-		// Bottom border
-		builder.WriteString(bottomLeft)
-		builder.WriteString(strings.Repeat(horizontal, contentWidth))
-		builder.WriteString(bottomRight)
+		if !s.hideBottom {
+			builder.WriteString(paint(corner(b.BottomLeft, !s.hideLeft) +
+				strings.Repeat(b.Bottom, contentWidth) +
+				corner(b.BottomRight, !s.hideRight)))
+		}
 	} else {
 		// No border - just render styled content
 		for i, line := range lines {
@@ -193,7 +332,21 @@ func (s Style) Render(str string) string {
 		}
 	}
 
-	return builder.String()
+	return s.applyMargins(builder.String())
+}
+
+// applyMargins surrounds the rendered block with its margins: spaces
+// before and after each line, blank lines above and below.
+func (s Style) applyMargins(block string) string {
+	if s.marginLeft > 0 || s.marginRight > 0 {
+		left, right := strings.Repeat(" ", s.marginLeft), strings.Repeat(" ", s.marginRight)
+		lines := strings.Split(block, "\n")
+		for i, line := range lines {
+			lines[i] = left + line + right
+		}
+		block = strings.Join(lines, "\n")
+	}
+	return strings.Repeat("\n", s.marginTop) + block + strings.Repeat("\n", s.marginBottom)
 }
 
 // JoinVertical concatenates segments vertically.
@@ -255,32 +408,3 @@ func JoinHorizontal(_ Position, parts ...string) string {
 
 	return result.String()
 }
-
-// visibleWidth calculates the visible width of a string, ignoring ANSI escape codes.
-func visibleWidth(s string) int {
-	width := 0
-	inEscape := false
-	for _, r := range s {
-		if r == '\033' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		width++
-	}
-	return width
-}
-
-// Position is a placeholder for compatibility.
-type Position int
-
-// Left is the default join position.
-const Left Position = 0
-
-// Top position for horizontal joins.
-const Top Position = 1
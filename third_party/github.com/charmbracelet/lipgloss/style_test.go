@@ -0,0 +1,72 @@
+package lipgloss
+
+import "testing"
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		in    string
+		want  string
+	}{
+		{"left by default", NewStyle().Width(6), "ab", "ab    "},
+		{"right", NewStyle().Width(6).Align(Right), "ab", "    ab"},
+		{"center", NewStyle().Width(6).Align(Center), "ab", "  ab  "},
+		{"center odd gap leans left", NewStyle().Width(5).Align(Center), "ab", " ab  "},
+		{"inside padding", NewStyle().Width(8).Padding(0, 1).Align(Right), "ab", "     ab "},
+		{"ignores ANSI codes", NewStyle().Width(5).Align(Right), "\x1b[1mab\x1b[0m", "   \x1b[1mab\x1b[0m"},
+		{"widest line without width", NewStyle().Align(Right), "a\nabc", "  a\nabc"},
+		{"too wide is cut", NewStyle().Width(3).Align(Center), "abcde", "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render(tt.in); got != tt.want {
+				t.Fatalf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMargins(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		in    string
+		want  string
+	}{
+		{"top", NewStyle().MarginTop(1), "ab", "\nab"},
+		{"bottom", NewStyle().MarginBottom(2), "ab", "ab\n\n"},
+		{"left and right per line", NewStyle().MarginLeft(2).MarginRight(1), "ab\nc", "  ab \n  c  "},
+		{"outside the colors", NewStyle().Bold(true).MarginLeft(1), "ab", " \x1b[1mab\x1b[0m"},
+		{"outside the border", NewStyle().Border(true).MarginLeft(1).MarginBottom(1), "ab",
+			" ┌──┐\n │ab│\n └──┘\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render(tt.in); got != tt.want {
+				t.Fatalf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColors(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"palette foreground", NewStyle().Foreground("62"), "\x1b[38;5;62mab\x1b[0m"},
+		{"truecolor foreground", NewStyle().Foreground("#005f87"), "\x1b[38;2;0;95;135mab\x1b[0m"},
+		{"truecolor background", NewStyle().Background("#FF8000"), "\x1b[48;2;255;128;0mab\x1b[0m"},
+		{"malformed hex is ignored", NewStyle().Foreground("#12345"), "ab"},
+		{"truecolor border", NewStyle().Border(true).BorderForeground("#010203").BorderTop(false).BorderBottom(false), "\x1b[38;2;1;2;3m│\x1b[39mab\x1b[38;2;1;2;3m│\x1b[39m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render("ab"); got != tt.want {
+				t.Fatalf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
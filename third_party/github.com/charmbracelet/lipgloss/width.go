@@ -0,0 +1,145 @@
+package lipgloss
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// wideRanges lists the runes a terminal draws two cells wide: East Asian
+// Wide and Fullwidth characters and emoji with emoji presentation. Ranges
+// are sorted and do not overlap.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x1F004, 0x1F004},
+	{0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202},
+	{0x1F210, 0x1F23B}, {0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265},
+	{0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F900, 0x1F9FF},
+	{0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// RuneWidth returns how many terminal cells r takes: 0 for combining marks
+// and other invisible format characters, 2 for wide characters, 1 for the
+// rest.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x300:
+		// Latin text, the common case; the soft hyphen is drawn
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	if i < len(wideRanges) && wideRanges[i][0] <= r {
+		return 2
+	}
+	return 1
+}
+
+// Width returns the visible width of the widest line in str, ignoring ANSI
+// escape codes.
+func Width(str string) int {
+	width := 0
+	for _, line := range strings.Split(str, "\n") {
+		if w := visibleWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// alignLines pads each line narrower than width on the left (Right) or on
+// both sides (Center), measuring around ANSI codes.
+func alignLines(lines []string, width int, p Position) {
+	for i, line := range lines {
+		gap := width - visibleWidth(line)
+		if gap <= 0 {
+			continue
+		}
+		left := gap
+		if p == Center {
+			left = gap / 2
+		}
+		lines[i] = strings.Repeat(" ", left) + line + strings.Repeat(" ", gap-left)
+	}
+}
+
+// truncateVisible cuts s to width terminal cells. Escape codes are never
+// split, nor is a wide character that would straddle the cut; if any codes
+// were kept, a reset is appended so colors do not leak past the cut.
+func truncateVisible(s string, width int) string {
+	var b strings.Builder
+	visible := 0
+	inEscape, styled := false, false
+	for _, r := range s {
+		if r == '\033' {
+			inEscape, styled = true, true
+			b.WriteRune(r)
+			continue
+		}
+		if inEscape {
+			b.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+			}
+			continue
+		}
+		w := RuneWidth(r)
+		if visible+w > width {
+			break
+		}
+		b.WriteRune(r)
+		visible += w
+	}
+	if styled {
+		b.WriteString("\033[0m")
+	}
+	return b.String()
+}
+
+// visibleWidth calculates the number of terminal cells a string takes,
+// ignoring ANSI escape codes and counting wide characters twice.
+func visibleWidth(s string) int {
+	width := 0
+	inEscape := false
+	for _, r := range s {
+		if r == '\033' {
+			inEscape = true
+			continue
+		}
+		if inEscape {
+			if r == 'm' {
+				inEscape = false
+			}
+			continue
+		}
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// Position selects an alignment. Joins ignore it; Style.Align uses Left,
+// Center and Right.
+type Position int
+
+// Left is the default join position.
+const Left Position = 0
+
+// Top position for horizontal joins.
+const Top Position = 1
+
+// Center aligns lines in the middle of the content width.
+const Center Position = 2
+
+// Right aligns lines against the right edge of the content width.
+const Right Position = 3
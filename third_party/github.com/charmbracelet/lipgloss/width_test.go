@@ -0,0 +1,66 @@
+package lipgloss
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"abc", 3},
+		{"\x1b[31mabc\x1b[0m", 3},
+		{"日本語", 6},
+		{"한글", 4},
+		{"ｆｕｌｌ", 8},
+		{"ok 👍", 5},
+		{"e\u0301", 1},  // e + combining acute
+		{"a\u200bb", 2}, // zero-width space
+		{"★ ● ─ │", 7},  // ambiguous symbols stay narrow
+		{"é\u00ad", 2},  // é and a soft hyphen
+	}
+	for _, tt := range tests {
+		if got := visibleWidth(tt.in); got != tt.want {
+			t.Errorf("visibleWidth(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRenderWithWideCharacters(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		in    string
+		want  string
+	}{
+		{"border fits wide text", NewStyle().Border(true), "日本\nab", "┌────┐\n│日本│\n│ab  │\n└────┘"},
+		{"pads to width", NewStyle().Width(5), "日本", "日本 "},
+		{"cut does not split a wide character", NewStyle().Width(3), "日本語", "日 "},
+		{"cut keeps combining marks", NewStyle().Width(2), "ae\u0301x", "ae\u0301"},
+		{"wraps by cells", NewStyle().Width(4).Wrap(true), "日本語", "日本\n語  "},
+		{"right aligns by cells", NewStyle().Width(6).Align(Right), "日本", "  日本"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render(tt.in); got != tt.want {
+				t.Fatalf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinHorizontalPadsWideCharacters(t *testing.T) {
+	got := JoinHorizontal(Top, "日本\na", "|")
+	if want := "日本|\na   "; got != want {
+		t.Fatalf("JoinHorizontal = %q, want %q", got, want)
+	}
+}
+
+func TestWrapVisibleWideCharacters(t *testing.T) {
+	got := wrapVisible("日本 語です", 5)
+	if want := []string{"日本", "語で", "す"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrapVisible = %q, want %q", got, want)
+	}
+}
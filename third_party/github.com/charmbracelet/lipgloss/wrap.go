@@ -0,0 +1,104 @@
+package lipgloss
+
+import "strings"
+
+// wrapVisible word-wraps s into rows of at most width terminal cells,
+// breaking at the last space that fits and hard-breaking words longer than
+// a row. ANSI escape codes take no width; styling active at a break is
+// reset at the end of the row and re-applied at the start of the next.
+func wrapVisible(s string, width int) []string {
+	if width <= 0 || visibleWidth(s) <= width {
+		return []string{s}
+	}
+
+	// escapes[i] holds the codes written just before runes[i]; the extra
+	// final entry holds codes after the last visible character.
+	var (
+		runes   []rune
+		escapes [][]string
+		pending []string
+		code    strings.Builder
+	)
+	inEscape := false
+	for _, r := range s {
+		if r == '\033' {
+			inEscape = true
+			code.Reset()
+		}
+		if inEscape {
+			code.WriteRune(r)
+			if r == 'm' {
+				inEscape = false
+				pending = append(pending, code.String())
+			}
+			continue
+		}
+		escapes = append(escapes, pending)
+		pending = nil
+		runes = append(runes, r)
+	}
+	escapes = append(escapes, pending)
+
+	var rows []string
+	active := ""
+	for start := 0; start < len(runes); {
+		end, next := wrapBreak(runes, start, width)
+		var b strings.Builder
+		b.WriteString(active)
+		for i := start; i < end; i++ {
+			for _, e := range escapes[i] {
+				b.WriteString(e)
+				active = trackStyle(active, e)
+			}
+			b.WriteRune(runes[i])
+		}
+		// Codes before the skipped space carry over to the next row
+		for i := end; i < next; i++ {
+			for _, e := range escapes[i] {
+				active = trackStyle(active, e)
+			}
+		}
+		if next == len(runes) {
+			for _, e := range escapes[next] {
+				b.WriteString(e)
+				active = trackStyle(active, e)
+			}
+		}
+		if active != "" {
+			b.WriteString("\033[0m")
+		}
+		rows = append(rows, b.String())
+		start = next
+	}
+	return rows
+}
+
+// wrapBreak returns where the row starting at start ends and where the
+// next one begins: after the last space that fits, dropping that space, or
+// after as many characters as fit when the word is too long to break. A
+// row always takes at least one character.
+func wrapBreak(runes []rune, start, width int) (end, next int) {
+	end, cells := start, 0
+	for end < len(runes) && cells+RuneWidth(runes[end]) <= width {
+		cells += RuneWidth(runes[end])
+		end++
+	}
+	if end == len(runes) {
+		return end, end
+	}
+	for i := end; i > start; i-- {
+		if runes[i] == ' ' {
+			return i, i + 1
+		}
+	}
+	end = max(end, start+1)
+	return end, end
+}
+
+// trackStyle adds code to the active styling, which a reset clears.
+func trackStyle(active, code string) string {
+	if code == "\033[0m" || code == "\033[m" {
+		return ""
+	}
+	return active + code
+}
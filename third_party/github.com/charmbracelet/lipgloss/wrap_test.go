@@ -0,0 +1,55 @@
+package lipgloss
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapVisible(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  []string
+	}{
+		{"fits", "short line", 20, []string{"short line"}},
+		{"breaks at spaces", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"hard-breaks long words", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"space at the edge", "abcd efgh", 4, []string{"abcd", "efgh"}},
+		{"zero width leaves line", "abc def", 0, []string{"abc def"}},
+		{"wide characters move whole", "a日本", 2, []string{"a", "日", "本"}},
+		{"combining marks take no cells", "café bar", 4, []string{"café", "bar"}},
+		{
+			"carries color across rows",
+			"\033[31mred words here\033[0m",
+			9,
+			[]string{"\033[31mred words\033[0m", "\033[31mhere\033[0m"},
+		},
+		{
+			"reset before break ends styling",
+			"\033[1mbold\033[0m plain text",
+			10,
+			[]string{"\033[1mbold\033[0m plain", "text"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapVisible(tt.in, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("wrapVisible(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWrapsInsteadOfTruncating(t *testing.T) {
+	got := NewStyle().Width(10).Wrap(true).Render("panic: runtime error")
+	want := "panic:    \nruntime   \nerror     "
+	if got != want {
+		t.Fatalf("Render = %q, want %q", got, want)
+	}
+
+	cut := NewStyle().Width(10).Render("panic: runtime error")
+	if cut != "panic: run" {
+		t.Fatalf("without Wrap, Render = %q, want it cut to the width", cut)
+	}
+}